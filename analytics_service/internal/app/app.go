@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
@@ -12,13 +13,17 @@ import (
 
 	"analytics_service/internal/config"
 	"analytics_service/internal/converter"
+	"analytics_service/internal/enrich"
+	"analytics_service/internal/principal"
 	"analytics_service/internal/repository/clickhouserepo"
 	"analytics_service/internal/service"
 	analytics_grpc "analytics_service/internal/transport/grpc"
+	"analytics_service/internal/transport/kafka"
 	"analytics_service/internal/transport/rest"
 	analytics "analytics_service/pkg/proto"
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/IBM/sarama"
 	"google.golang.org/grpc"
 )
 
@@ -32,6 +37,13 @@ const (
 )
 
 func Run() {
+	doneCh := make(chan struct{})
+
+	defer func() {
+		doneCh <- struct{}{}
+		close(doneCh)
+	}()
+
 	cfg, err := config.ParseConfig()
 	if err != nil {
 		panic(err)
@@ -41,7 +53,18 @@ func Run() {
 	if err != nil {
 		panic(err)
 	}
-	runGrpcServer(logger, cfg.ClickhouseConfig)
+
+	clickhouseConn, err := setupClickhouseConn(cfg.ClickhouseConfig)
+	if err != nil {
+		panic(err)
+	}
+	countryLocator, err := enrich.NewCountryLocatorFromFile(cfg.GeoIPDBPath)
+	if err != nil {
+		panic(err)
+	}
+
+	runGrpcServer(logger, cfg, clickhouseConn)
+	runConsumer(logger, cfg, clickhouseConn, countryLocator, doneCh)
 	runHttpServer(logger)
 
 	// Graceful shutdown
@@ -49,7 +72,6 @@ func Run() {
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
 	<-stop
-
 }
 
 func setupLogger(env string) (*slog.Logger, error) {
@@ -96,17 +118,18 @@ func setupClickhouseConn(clickhouseCfg config.ClickhouseConfig) (driver.Conn, er
 	return conn, err
 }
 
-func runGrpcServer(logger *slog.Logger, clickhouseCfg config.ClickhouseConfig) {
+func runGrpcServer(
+	logger *slog.Logger,
+	cfg config.Config,
+	clickhouseConn driver.Conn,
+) {
 	topURLConverter := converter.NewTopURLConverter()
 	paginationConverter := converter.NewPaginationConverter()
-
-	clickhouseConn, err := setupClickhouseConn(clickhouseCfg)
-	if err != nil {
-		panic(err)
-	}
+	urlStatsConverter := converter.NewURLStatsConverter()
+	breakdownConverter := converter.NewBreakdownConverter()
 
 	paginationRepo := clickhouserepo.NewPaginationRepoClickhouse(clickhouseConn)
-	paginationService := service.NewPaginationService(paginationRepo)
+	paginationService := service.NewPaginationService(paginationRepo, cfg.PaginationCountTTL)
 
 	analyticsRepo, err := clickhouserepo.NewAnalyticsRepoClickhouse(logger, clickhouseConn)
 	if err != nil {
@@ -115,13 +138,17 @@ func runGrpcServer(logger *slog.Logger, clickhouseCfg config.ClickhouseConfig) {
 	analyticsService := service.NewAnalyticsService(analyticsRepo)
 
 	go func() {
-		s := grpc.NewServer()
+		s := grpc.NewServer(
+			grpc.UnaryInterceptor(principal.UnaryServerInterceptor),
+		)
 		analyticsServer := analytics_grpc.NewAnalyticsServer(
 			logger,
 			analyticsService,
 			paginationService,
 			topURLConverter,
 			paginationConverter,
+			urlStatsConverter,
+			breakdownConverter,
 		)
 
 		analytics.RegisterAnalyticsServer(s, analyticsServer)
@@ -138,6 +165,56 @@ func runGrpcServer(logger *slog.Logger, clickhouseCfg config.ClickhouseConfig) {
 	}()
 }
 
+func runConsumer(
+	logger *slog.Logger,
+	cfg config.Config,
+	clickhouseConn driver.Conn,
+	countryLocator enrich.CountryLocator,
+	doneCh <-chan struct{},
+) {
+	ingestService := service.NewIngestService(
+		logger,
+		clickhouserepo.NewEventsSinkClickhouse(logger, clickhouseConn),
+		countryLocator,
+	)
+
+	consumer, err := kafka.NewConsumer(
+		logger,
+		cfg.KafkaConfig.Addrs,
+		ingestService,
+		converter.NewURLEventConverter(),
+		kafka.ConsumerConfig{
+			Topic:           cfg.KafkaConfig.EventsTopic,
+			Group:           cfg.KafkaConfig.ConsumerGroup,
+			DeadLetterTopic: cfg.KafkaConfig.DeadLetterTopic,
+			InitialOffset:   initialOffset(cfg.KafkaConfig.InitialOffset),
+			BatchSize:       cfg.IngestConfig.BatchSize,
+			FlushInterval:   cfg.IngestConfig.FlushInterval,
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-doneCh
+		cancel()
+		err := consumer.Close()
+		if err != nil {
+			logger.Error("close url events consumer", slog.String("error", err.Error()))
+		}
+	}()
+	go consumer.Run(ctx)
+}
+
+func initialOffset(offset string) int64 {
+	if offset == config.KafkaOffsetNewest {
+		return sarama.OffsetNewest
+	}
+	return sarama.OffsetOldest
+}
+
 func runHttpServer(logger *slog.Logger) {
 	healthCheckHandler := rest.NewHealthCheckHandler(logger)
 
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -13,11 +16,64 @@ const (
 	clickhouseHostKey     = "CLICKHOUSE_HOST"
 	clickhousePortKey     = "CLICKHOUSE_PORT"
 	clickhouseDatabaseKey = "CLICKHOUSE_DATABASE"
+
+	// geoIPDBPathKey is a DB-IP IP to Country Lite csv, the docker image downloads it on build
+	geoIPDBPathKey = "GEOIP_DB_PATH"
+
+	// paginationCountTTLKey is how long record counts of page numbers are cached, 0 disables the cache
+	paginationCountTTLKey     = "PAGINATION_COUNT_TTL"
+	defaultPaginationCountTTL = 30 * time.Second
+
+	kafkaAddrsKey           = "KAFKA_ADDRS"
+	kafkaEventsTopicKey     = "KAFKA_EVENTS_TOPIC"
+	kafkaConsumerGroupKey   = "KAFKA_CONSUMER_GROUP"
+	kafkaDeadLetterTopicKey = "KAFKA_DEAD_LETTER_TOPIC"
+	// kafkaInitialOffsetKey is where a group without committed offsets starts, oldest or newest
+	kafkaInitialOffsetKey = "KAFKA_INITIAL_OFFSET"
+
+	ingestBatchSizeKey     = "INGEST_BATCH_SIZE"
+	ingestFlushIntervalKey = "INGEST_FLUSH_INTERVAL"
+
+	defaultKafkaEventsTopic = "events"
+	// defaultKafkaConsumerGroup is the group of the former clickhouse kafka engine,
+	// the consumer continues from its offsets
+	defaultKafkaConsumerGroup   = "group1"
+	defaultKafkaDeadLetterTopic = "events.dlq"
+	defaultKafkaInitialOffset   = KafkaOffsetOldest
+
+	defaultIngestBatchSize     = 1000
+	defaultIngestFlushInterval = time.Second
 )
 
 type Config struct {
 	Env              string
 	ClickhouseConfig ClickhouseConfig
+	GeoIPDBPath      string
+	// PaginationCountTTL is how long record counts of page numbers are cached
+	PaginationCountTTL time.Duration
+	KafkaConfig        KafkaConfig
+	IngestConfig       IngestConfig
+}
+
+// Initial offsets of a consumer group
+const (
+	KafkaOffsetOldest = "oldest"
+	KafkaOffsetNewest = "newest"
+)
+
+type KafkaConfig struct {
+	Addrs           []string
+	EventsTopic     string
+	ConsumerGroup   string
+	DeadLetterTopic string
+	InitialOffset   string
+}
+
+// IngestConfig configures batching of consumed url events
+type IngestConfig struct {
+	// BatchSize and FlushInterval trigger storing of a batch, whichever comes first
+	BatchSize     int
+	FlushInterval time.Duration
 }
 
 type ClickhouseConfig struct {
@@ -57,6 +113,31 @@ func ParseConfig() (Config, error) {
 		return Config{}, fmt.Errorf("you did not provice env: %s", clickhouseDatabaseKey)
 	}
 
+	geoIPDBPath := os.Getenv(geoIPDBPathKey)
+	if geoIPDBPath == "" {
+		return Config{}, fmt.Errorf("you did not provide env: %s", geoIPDBPathKey)
+	}
+
+	paginationCountTTL := defaultPaginationCountTTL
+	paginationCountTTLRaw := os.Getenv(paginationCountTTLKey)
+	if paginationCountTTLRaw != "" {
+		ttl, err := time.ParseDuration(paginationCountTTLRaw)
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: %w", paginationCountTTLKey, err)
+		}
+		paginationCountTTL = ttl
+	}
+
+	kafkaCfg, err := parseKafkaConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	ingestCfg, err := parseIngestConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
 	return Config{
 		Env: env,
 		ClickhouseConfig: ClickhouseConfig{
@@ -66,5 +147,75 @@ func ParseConfig() (Config, error) {
 			Port:     port,
 			Database: database,
 		},
+		GeoIPDBPath:        geoIPDBPath,
+		PaginationCountTTL: paginationCountTTL,
+		KafkaConfig:        kafkaCfg,
+		IngestConfig:       ingestCfg,
 	}, nil
 }
+
+func parseKafkaConfig() (KafkaConfig, error) {
+	addrsRaw := os.Getenv(kafkaAddrsKey)
+	if addrsRaw == "" {
+		return KafkaConfig{}, fmt.Errorf("you did not provide env: %s", kafkaAddrsKey)
+	}
+
+	cfg := KafkaConfig{
+		Addrs:           strings.Split(addrsRaw, ","),
+		EventsTopic:     os.Getenv(kafkaEventsTopicKey),
+		ConsumerGroup:   os.Getenv(kafkaConsumerGroupKey),
+		DeadLetterTopic: os.Getenv(kafkaDeadLetterTopicKey),
+		InitialOffset:   os.Getenv(kafkaInitialOffsetKey),
+	}
+	if cfg.EventsTopic == "" {
+		cfg.EventsTopic = defaultKafkaEventsTopic
+	}
+	if cfg.ConsumerGroup == "" {
+		cfg.ConsumerGroup = defaultKafkaConsumerGroup
+	}
+	if cfg.DeadLetterTopic == "" {
+		cfg.DeadLetterTopic = defaultKafkaDeadLetterTopic
+	}
+	switch cfg.InitialOffset {
+	case "":
+		cfg.InitialOffset = defaultKafkaInitialOffset
+	case KafkaOffsetOldest, KafkaOffsetNewest:
+	default:
+		return KafkaConfig{}, fmt.Errorf("unknown %s: %s", kafkaInitialOffsetKey, cfg.InitialOffset)
+	}
+
+	return cfg, nil
+}
+
+func parseIngestConfig() (IngestConfig, error) {
+	cfg := IngestConfig{
+		BatchSize:     defaultIngestBatchSize,
+		FlushInterval: defaultIngestFlushInterval,
+	}
+
+	batchSizeRaw := os.Getenv(ingestBatchSizeKey)
+	if batchSizeRaw != "" {
+		batchSize, err := strconv.Atoi(batchSizeRaw)
+		if err != nil {
+			return IngestConfig{}, err
+		}
+		if batchSize <= 0 {
+			return IngestConfig{}, fmt.Errorf("%s must be positive", ingestBatchSizeKey)
+		}
+		cfg.BatchSize = batchSize
+	}
+
+	flushIntervalRaw := os.Getenv(ingestFlushIntervalKey)
+	if flushIntervalRaw != "" {
+		flushInterval, err := time.ParseDuration(flushIntervalRaw)
+		if err != nil {
+			return IngestConfig{}, err
+		}
+		if flushInterval <= 0 {
+			return IngestConfig{}, fmt.Errorf("%s must be positive", ingestFlushIntervalKey)
+		}
+		cfg.FlushInterval = flushInterval
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"analytics_service/internal/domain"
+	analytics "analytics_service/pkg/proto"
+)
+
+type BreakdownConverter struct {
+}
+
+func NewBreakdownConverter() BreakdownConverter {
+	return BreakdownConverter{}
+}
+
+func (c *BreakdownConverter) MapDimensionPbToDomain(pb analytics.Dimension) domain.Dimension {
+	switch pb {
+	case analytics.Dimension_DIMENSION_REFERRER_HOST:
+		return domain.DimensionReferrerHost
+	case analytics.Dimension_DIMENSION_COUNTRY:
+		return domain.DimensionCountry
+	case analytics.Dimension_DIMENSION_DEVICE:
+		return domain.DimensionDevice
+	case analytics.Dimension_DIMENSION_OS:
+		return domain.DimensionOS
+	default:
+		return domain.DimensionBrowser
+	}
+}
+
+func (c *BreakdownConverter) MapDimensionDomainToPb(d domain.Dimension) analytics.Dimension {
+	switch d {
+	case domain.DimensionReferrerHost:
+		return analytics.Dimension_DIMENSION_REFERRER_HOST
+	case domain.DimensionCountry:
+		return analytics.Dimension_DIMENSION_COUNTRY
+	case domain.DimensionDevice:
+		return analytics.Dimension_DIMENSION_DEVICE
+	case domain.DimensionOS:
+		return analytics.Dimension_DIMENSION_OS
+	default:
+		return analytics.Dimension_DIMENSION_BROWSER
+	}
+}
+
+func (c *BreakdownConverter) MapDomainToPb(d domain.URLBreakdown) *analytics.UrlBreakdownResponse {
+	values := make([]*analytics.BreakdownValue, len(d.Values))
+	for i, value := range d.Values {
+		values[i] = &analytics.BreakdownValue{
+			Value:       value.Value,
+			FollowCount: value.FollowCount,
+		}
+	}
+
+	return &analytics.UrlBreakdownResponse{
+		ShortUrl:         d.ShortURL,
+		Dimension:        c.MapDimensionDomainToPb(d.Dimension),
+		Values:           values,
+		OtherFollowCount: d.OtherFollowCount,
+		TotalFollowCount: d.TotalFollowCount,
+	}
+}
@@ -0,0 +1,98 @@
+package converter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"analytics_service/internal/domain"
+	events "analytics_service/pkg/proto/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// Content types of url events set in the content-type header of kafka messages
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+var ErrUnknownContentType = errors.New("unknown content type")
+
+// legacyURLEvent is the json event written before the protobuf envelope, event time is in seconds.
+// Event id and owner id are absent in events produced before they were added
+type legacyURLEvent struct {
+	EventID        string `json:"event_id"`
+	LongURL        string `json:"long_url"`
+	ShortURL       string `json:"short_url"`
+	EventTime      int64  `json:"event_time"`
+	EventType      int8   `json:"event_type"`
+	Referrer       string `json:"referrer"`
+	UserAgent      string `json:"user_agent"`
+	AcceptLanguage string `json:"accept_language"`
+	ClientIP       string `json:"client_ip"`
+	OwnerID        string `json:"owner_id"`
+}
+
+type URLEventConverter struct {
+}
+
+func NewURLEventConverter() URLEventConverter {
+	return URLEventConverter{}
+}
+
+// Decode accepts both encodings, messages without content type are legacy json.
+// Envelopes of newer schema versions are decoded as well, their unknown fields are skipped.
+func (c *URLEventConverter) Decode(contentType string, value []byte) (domain.URLEvent, error) {
+	switch contentType {
+	case ContentTypeProtobuf:
+		return c.decodeProtobuf(value)
+	case ContentTypeJSON, "":
+		return c.decodeJSON(value)
+	default:
+		return domain.URLEvent{}, fmt.Errorf("%w: %s", ErrUnknownContentType, contentType)
+	}
+}
+
+func (c *URLEventConverter) decodeProtobuf(value []byte) (domain.URLEvent, error) {
+	var pb events.UrlEvent
+	err := proto.Unmarshal(value, &pb)
+	if err != nil {
+		return domain.URLEvent{}, err
+	}
+
+	return domain.URLEvent{
+		ID:             pb.GetEventId(),
+		SchemaVersion:  pb.GetSchemaVersion(),
+		EventType:      domain.EventType(pb.GetEventType()),
+		ShortURL:       pb.GetShortUrl(),
+		LongURL:        pb.GetLongUrl(),
+		EventTime:      time.UnixMilli(pb.GetEventTimeMs()).UTC(),
+		Referrer:       pb.GetContext().GetReferrer(),
+		UserAgent:      pb.GetContext().GetUserAgent(),
+		AcceptLanguage: pb.GetContext().GetAcceptLanguage(),
+		ClientIP:       pb.GetContext().GetClientIp(),
+		OwnerID:        pb.GetContext().GetOwnerId(),
+	}, nil
+}
+
+func (c *URLEventConverter) decodeJSON(value []byte) (domain.URLEvent, error) {
+	var legacy legacyURLEvent
+	err := json.Unmarshal(value, &legacy)
+	if err != nil {
+		return domain.URLEvent{}, err
+	}
+
+	return domain.URLEvent{
+		ID:             legacy.EventID,
+		EventType:      domain.EventType(legacy.EventType),
+		ShortURL:       legacy.ShortURL,
+		LongURL:        legacy.LongURL,
+		EventTime:      time.Unix(legacy.EventTime, 0).UTC(),
+		Referrer:       legacy.Referrer,
+		UserAgent:      legacy.UserAgent,
+		AcceptLanguage: legacy.AcceptLanguage,
+		ClientIP:       legacy.ClientIP,
+		OwnerID:        legacy.OwnerID,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"analytics_service/internal/domain"
+	events "analytics_service/pkg/proto/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestURLEventConverterDecode(t *testing.T) {
+	protobufEvent, err := proto.Marshal(&events.UrlEvent{
+		EventId:       "0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1",
+		SchemaVersion: 1,
+		EventType:     events.EventType_EVENT_TYPE_FOLLOW,
+		ShortUrl:      "abc",
+		LongUrl:       "https://test.longurl",
+		EventTimeMs:   1700000000123,
+		Context: &events.RequestContext{
+			Referrer:       "https://referrer.test",
+			UserAgent:      "test-agent",
+			AcceptLanguage: "de-DE",
+			ClientIp:       "203.0.113.7",
+		},
+	})
+	require.NoError(t, err)
+
+	// country was field 3 of the request context, envelopes of earlier producers may still carry it
+	contextWithCountry := protowire.AppendTag(nil, 3, protowire.BytesType)
+	contextWithCountry = protowire.AppendString(contextWithCountry, "DE")
+	contextWithCountry = protowire.AppendTag(contextWithCountry, 6, protowire.BytesType)
+	contextWithCountry = protowire.AppendString(contextWithCountry, "203.0.113.7")
+	eventWithCountry, err := proto.Marshal(&events.UrlEvent{
+		EventType:   events.EventType_EVENT_TYPE_FOLLOW,
+		ShortUrl:    "abc",
+		EventTimeMs: 1700000000123,
+	})
+	require.NoError(t, err)
+	eventWithCountry = protowire.AppendTag(eventWithCountry, 7, protowire.BytesType)
+	eventWithCountry = protowire.AppendBytes(eventWithCountry, contextWithCountry)
+
+	legacyEvent := []byte(`{"long_url":"https://test.longurl","short_url":"abc","event_time":1700000000,"event_type":1}`)
+	expectedLegacyEvent := domain.URLEvent{
+		EventType: domain.EventTypeCreate,
+		ShortURL:  "abc",
+		LongURL:   "https://test.longurl",
+		EventTime: time.Unix(1700000000, 0).UTC(),
+	}
+
+	testCases := []struct {
+		name          string
+		contentType   string
+		value         []byte
+		expectedEvent domain.URLEvent
+		expectedErr   error
+	}{
+		{
+			name:        "Protobuf envelope",
+			contentType: ContentTypeProtobuf,
+			value:       protobufEvent,
+			expectedEvent: domain.URLEvent{
+				ID:             "0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1",
+				SchemaVersion:  1,
+				EventType:      domain.EventTypeFollow,
+				ShortURL:       "abc",
+				LongURL:        "https://test.longurl",
+				EventTime:      time.UnixMilli(1700000000123).UTC(),
+				Referrer:       "https://referrer.test",
+				UserAgent:      "test-agent",
+				AcceptLanguage: "de-DE",
+				ClientIP:       "203.0.113.7",
+			},
+		},
+		{
+			name:        "Protobuf envelope with country",
+			contentType: ContentTypeProtobuf,
+			value:       eventWithCountry,
+			expectedEvent: domain.URLEvent{
+				EventType: domain.EventTypeFollow,
+				ShortURL:  "abc",
+				EventTime: time.UnixMilli(1700000000123).UTC(),
+				ClientIP:  "203.0.113.7",
+			},
+		},
+		{
+			name:          "Legacy json",
+			contentType:   ContentTypeJSON,
+			value:         legacyEvent,
+			expectedEvent: expectedLegacyEvent,
+		},
+		{
+			name:          "Legacy json without content type",
+			value:         legacyEvent,
+			expectedEvent: expectedLegacyEvent,
+		},
+		{
+			name:        "Legacy json with request context",
+			contentType: ContentTypeJSON,
+			value:       []byte(`{"long_url":"https://test.longurl","short_url":"abc","event_time":1700000000,"event_type":2,"referrer":"https://referrer.test","client_ip":"203.0.113.7"}`),
+			expectedEvent: domain.URLEvent{
+				EventType: domain.EventTypeFollow,
+				ShortURL:  "abc",
+				LongURL:   "https://test.longurl",
+				EventTime: time.Unix(1700000000, 0).UTC(),
+				Referrer:  "https://referrer.test",
+				ClientIP:  "203.0.113.7",
+			},
+		},
+		{
+			name:        "Legacy json with event id and owner",
+			contentType: ContentTypeJSON,
+			value:       []byte(`{"event_id":"0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1","long_url":"https://test.longurl","short_url":"abc","event_time":1700000000,"event_type":1,"owner_id":"owner"}`),
+			expectedEvent: domain.URLEvent{
+				ID:        "0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1",
+				EventType: domain.EventTypeCreate,
+				ShortURL:  "abc",
+				LongURL:   "https://test.longurl",
+				EventTime: time.Unix(1700000000, 0).UTC(),
+				OwnerID:   "owner",
+			},
+		},
+		{
+			name:        "Unknown content type",
+			contentType: "application/avro",
+			value:       legacyEvent,
+			expectedErr: ErrUnknownContentType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlEventConverter := NewURLEventConverter()
+
+			event, err := urlEventConverter.Decode(tc.contentType, tc.value)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedEvent, event)
+		})
+	}
+}
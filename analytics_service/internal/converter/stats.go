@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"analytics_service/internal/domain"
+	analytics "analytics_service/pkg/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type URLStatsConverter struct {
+}
+
+func NewURLStatsConverter() URLStatsConverter {
+	return URLStatsConverter{}
+}
+
+func (c *URLStatsConverter) MapGranularityPbToDomain(pb analytics.Granularity) domain.Granularity {
+	switch pb {
+	case analytics.Granularity_GRANULARITY_MINUTE:
+		return domain.GranularityMinute
+	case analytics.Granularity_GRANULARITY_HOUR:
+		return domain.GranularityHour
+	default:
+		return domain.GranularityDay
+	}
+}
+
+func (c *URLStatsConverter) MapGranularityDomainToPb(d domain.Granularity) analytics.Granularity {
+	switch d {
+	case domain.GranularityMinute:
+		return analytics.Granularity_GRANULARITY_MINUTE
+	case domain.GranularityHour:
+		return analytics.Granularity_GRANULARITY_HOUR
+	default:
+		return analytics.Granularity_GRANULARITY_DAY
+	}
+}
+
+func (c *URLStatsConverter) MapDomainToPb(d domain.URLStats) *analytics.UrlStatsResponse {
+	buckets := make([]*analytics.UrlStatsBucket, len(d.Buckets))
+	for i, bucket := range d.Buckets {
+		buckets[i] = &analytics.UrlStatsBucket{
+			Start:             timestamppb.New(bucket.Start),
+			FollowCount:       bucket.FollowCount,
+			UniqueFollowCount: bucket.UniqueFollowCount,
+		}
+	}
+
+	return &analytics.UrlStatsResponse{
+		ShortUrl:               d.ShortURL,
+		Granularity:            c.MapGranularityDomainToPb(d.Granularity),
+		Buckets:                buckets,
+		TotalFollowCount:       d.TotalFollowCount,
+		TotalUniqueFollowCount: d.TotalUniqueFollowCount,
+	}
+}
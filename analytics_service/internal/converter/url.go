@@ -1,10 +1,30 @@
 package converter
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
 	"analytics_service/internal/domain"
+	"analytics_service/internal/errs"
 	analytics "analytics_service/pkg/proto"
 )
 
+// topURLsCursorVersion changes when cursors issued before can not be read anymore
+const topURLsCursorVersion = 1
+
+// topURLsCursorToken is the json of a cursor, it is opaque for clients
+type topURLsCursorToken struct {
+	Version   int            `json:"v"`
+	Ranking   domain.Ranking `json:"r"`
+	From      int64          `json:"f"`
+	To        int64          `json:"t"`
+	Primary   int64          `json:"p"`
+	Secondary int64          `json:"s"`
+	ShortURL  string         `json:"su"`
+	LongURL   string         `json:"lu"`
+}
+
 type TopURLConverter struct {
 }
 
@@ -14,10 +34,20 @@ func NewTopURLConverter() TopURLConverter {
 
 func (c *TopURLConverter) MapDomainToPb(d domain.TopURLData) *analytics.TopUrlData {
 	return &analytics.TopUrlData{
-		LongUrl:     d.LongURL,
-		ShortUrl:    d.ShortURL,
-		FollowCount: d.FollowCount,
-		CreateCount: d.CreateCount,
+		LongUrl:           d.LongURL,
+		ShortUrl:          d.ShortURL,
+		FollowCount:       d.FollowCount,
+		CreateCount:       d.CreateCount,
+		UniqueFollowCount: d.UniqueFollowCount,
+	}
+}
+
+func (c *TopURLConverter) MapRankingPbToDomain(pb analytics.Ranking) domain.Ranking {
+	switch pb {
+	case analytics.Ranking_RANKING_UNIQUE_FOLLOWS:
+		return domain.RankingUniqueFollows
+	default:
+		return domain.RankingFollows
 	}
 }
 
@@ -30,3 +60,66 @@ func (c *TopURLConverter) MapSliceDomainToPb(d []domain.TopURLData) []*analytics
 
 	return pbs
 }
+
+func (c *TopURLConverter) MapWindowPbToDomain(pb analytics.Window) domain.Window {
+	switch pb {
+	case analytics.Window_WINDOW_LAST_HOUR:
+		return domain.WindowLastHour
+	case analytics.Window_WINDOW_LAST_DAY:
+		return domain.WindowLastDay
+	case analytics.Window_WINDOW_LAST_WEEK:
+		return domain.WindowLastWeek
+	default:
+		return domain.WindowAllTime
+	}
+}
+
+// MapCursorDomainToToken encodes a cursor of a next page, it always has a key
+func (c *TopURLConverter) MapCursorDomainToToken(cursor domain.TopURLsCursor) string {
+	token := topURLsCursorToken{
+		Version: topURLsCursorVersion,
+		Ranking: cursor.Ranking,
+		From:    cursor.TimeRange.From.Unix(),
+		To:      cursor.TimeRange.To.Unix(),
+	}
+	if cursor.After != nil {
+		token.Primary = cursor.After.Primary
+		token.Secondary = cursor.After.Secondary
+		token.ShortURL = cursor.After.ShortURL
+		token.LongURL = cursor.After.LongURL
+	}
+
+	// Marshalling of numbers and strings does not fail
+	tokenJSON, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(tokenJSON)
+}
+
+func (c *TopURLConverter) MapCursorTokenToDomain(tokenStr string) (domain.TopURLsCursor, error) {
+	tokenJSON, err := base64.RawURLEncoding.DecodeString(tokenStr)
+	if err != nil {
+		return domain.TopURLsCursor{}, errs.ErrInvalidCursor
+	}
+
+	var token topURLsCursorToken
+	err = json.Unmarshal(tokenJSON, &token)
+	if err != nil || token.Version != topURLsCursorVersion {
+		return domain.TopURLsCursor{}, errs.ErrInvalidCursor
+	}
+	if token.Ranking != domain.RankingFollows && token.Ranking != domain.RankingUniqueFollows {
+		return domain.TopURLsCursor{}, errs.ErrInvalidCursor
+	}
+
+	return domain.TopURLsCursor{
+		Ranking: token.Ranking,
+		TimeRange: domain.TimeRange{
+			From: time.Unix(token.From, 0).UTC(),
+			To:   time.Unix(token.To, 0).UTC(),
+		},
+		After: &domain.TopURLsKey{
+			Primary:   token.Primary,
+			Secondary: token.Secondary,
+			ShortURL:  token.ShortURL,
+			LongURL:   token.LongURL,
+		},
+	}, nil
+}
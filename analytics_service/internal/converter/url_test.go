@@ -0,0 +1,44 @@
+package converter
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"analytics_service/internal/domain"
+	"analytics_service/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopURLConverterCursorToken(t *testing.T) {
+	c := NewTopURLConverter()
+
+	cursor := domain.TopURLsCursor{
+		Ranking: domain.RankingUniqueFollows,
+		TimeRange: domain.TimeRange{
+			From: time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, time.May, 8, 10, 0, 0, 0, time.UTC),
+		},
+		After: &domain.TopURLsKey{Primary: 7, Secondary: 12, ShortURL: "abc", LongURL: "https://test.longurl/?a=1"},
+	}
+
+	decoded, err := c.MapCursorTokenToDomain(c.MapCursorDomainToToken(cursor))
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	allTimeCursor := domain.TopURLsCursor{Ranking: domain.RankingFollows, After: cursor.After}
+	decoded, err = c.MapCursorTokenToDomain(c.MapCursorDomainToToken(allTimeCursor))
+	require.NoError(t, err)
+	assert.True(t, decoded.TimeRange.IsAllTime())
+
+	for _, token := range []string{
+		"",
+		"not a cursor",
+		base64.RawURLEncoding.EncodeToString([]byte(`{"v":2,"r":0}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"v":1,"r":5}`)),
+	} {
+		_, err = c.MapCursorTokenToDomain(token)
+		assert.ErrorIs(t, err, errs.ErrInvalidCursor, token)
+	}
+}
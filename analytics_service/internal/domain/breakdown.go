@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+type Dimension int
+
+const (
+	DimensionReferrerHost Dimension = iota + 1
+	DimensionCountry
+	DimensionDevice
+	DimensionOS
+	DimensionBrowser
+)
+
+// DirectReferrer is the referrer host of follows without a referrer
+const DirectReferrer = "direct"
+
+type URLBreakdownParams struct {
+	ShortURL string
+	// OwnerID is the authenticated caller
+	OwnerID   string
+	Dimension Dimension
+	// To is exclusive
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+type BreakdownValue struct {
+	Value       string
+	FollowCount int64
+}
+
+type URLBreakdown struct {
+	ShortURL  string
+	Dimension Dimension
+	// Values are ordered by follow count, at most Limit of them
+	Values []BreakdownValue
+	// OtherFollowCount is the sum of values that did not make it to the top
+	OtherFollowCount int64
+	TotalFollowCount int64
+}
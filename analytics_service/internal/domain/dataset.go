@@ -0,0 +1,14 @@
+package domain
+
+// Dataset is a listing paginated by page numbers, its records are counted for total pages
+type Dataset int
+
+const (
+	// DatasetTopURLs are urls with their counters, a time range keeps urls with events in it
+	DatasetTopURLs Dataset = iota + 1
+)
+
+// DatasetFilter narrows records of a dataset, zero filter keeps all of them
+type DatasetFilter struct {
+	TimeRange TimeRange
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type EventType int8
+
+const (
+	EventTypeCreate EventType = 1
+	EventTypeFollow EventType = 2
+	EventTypeDelete EventType = 3
+	EventTypeUpdate EventType = 4
+)
+
+// URLEvent is an event of url_shortener_service decoded from any of its encodings
+type URLEvent struct {
+	// ID is empty for legacy json events
+	ID string
+	// SchemaVersion is 0 for legacy json events
+	SchemaVersion  uint32
+	EventType      EventType
+	ShortURL       string
+	LongURL        string
+	EventTime      time.Time
+	Referrer       string
+	UserAgent      string
+	AcceptLanguage string
+	ClientIP       string
+	Country        string
+	OwnerID        string
+	// Device, OS and Browser are derived from the user agent of follows on ingest
+	Device  string
+	OS      string
+	Browser string
+	// ReferrerHost is derived from the referrer of follows on ingest, it outlives the referrer in the storage
+	ReferrerHost string
+}
+
+// Validate checks fields every stored event needs, the request context is optional
+func (e URLEvent) Validate() error {
+	switch e.EventType {
+	case EventTypeCreate, EventTypeFollow, EventTypeDelete, EventTypeUpdate:
+	default:
+		return fmt.Errorf("unknown event type: %d", e.EventType)
+	}
+	if e.ShortURL == "" {
+		return errors.New("short url is empty")
+	}
+	if e.EventTime.Unix() <= 0 {
+		return errors.New("event time is not set")
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+type Granularity int
+
+const (
+	GranularityMinute Granularity = iota + 1
+	GranularityHour
+	GranularityDay
+)
+
+// Duration is the length of a bucket, days are UTC days
+func (g Granularity) Duration() time.Duration {
+	switch g {
+	case GranularityMinute:
+		return time.Minute
+	case GranularityHour:
+		return time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+type URLStatsParams struct {
+	ShortURL string
+	// OwnerID is the authenticated caller
+	OwnerID string
+	// From is truncated to the granularity, To is exclusive
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+}
+
+type URLStatsBucket struct {
+	Start             time.Time
+	FollowCount       int64
+	UniqueFollowCount int64
+}
+
+type URLStats struct {
+	ShortURL    string
+	Granularity Granularity
+	// Buckets cover the whole range, buckets without follows have zero count
+	Buckets          []URLStatsBucket
+	TotalFollowCount int64
+	// TotalUniqueFollowCount counts a visitor once for the whole range
+	TotalUniqueFollowCount int64
+}
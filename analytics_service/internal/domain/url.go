@@ -1,8 +1,69 @@
 package domain
 
+import "time"
+
+// Ranking is the order of top urls
+type Ranking int
+
+const (
+	RankingFollows Ranking = iota
+	RankingUniqueFollows
+)
+
+// URLOwner is the owner of the url that uses a short url now.
+// A deleted short url may be created again by another owner
+type URLOwner struct {
+	OwnerID string
+	// CreatedAt is the creation time of the current url, earlier events belong to deleted ones
+	CreatedAt time.Time
+}
+
 type TopURLData struct {
 	LongURL     string
 	ShortURL    string
 	FollowCount int64
 	CreateCount int64
+	// UniqueFollowCount is an estimate of distinct visitors
+	UniqueFollowCount int64
+}
+
+// TopURLsKey is the position of a url in top urls ordered by a ranking
+type TopURLsKey struct {
+	// Primary and Secondary are the counts of the ranking, follows and creates
+	// or unique follows and follows
+	Primary   int64
+	Secondary int64
+	// ShortURL and LongURL break ties of the counts
+	ShortURL string
+	LongURL  string
+}
+
+// Key is the position of the url in top urls ordered by ranking
+func (d TopURLData) Key(ranking Ranking) TopURLsKey {
+	key := TopURLsKey{
+		Primary:   d.FollowCount,
+		Secondary: d.CreateCount,
+		ShortURL:  d.ShortURL,
+		LongURL:   d.LongURL,
+	}
+	if ranking == RankingUniqueFollows {
+		key.Primary = d.UniqueFollowCount
+		key.Secondary = d.FollowCount
+	}
+
+	return key
+}
+
+// TopURLsCursor is a position in top urls of a fixed time range or of all time
+type TopURLsCursor struct {
+	Ranking   Ranking
+	TimeRange TimeRange
+	// After is the key of the last url of the previous page, nil on the first page
+	After *TopURLsKey
+}
+
+type TopURLsPage struct {
+	TopURLData []TopURLData
+	// Next is nil on the last page
+	Next *TopURLsCursor
 }
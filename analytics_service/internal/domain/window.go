@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// TopURLsBucket is the step of the rollup top urls of a time range are merged from
+const TopURLsBucket = time.Hour
+
+type Window int
+
+const (
+	WindowAllTime Window = iota
+	WindowLastHour
+	WindowLastDay
+	WindowLastWeek
+)
+
+// Duration is zero for all time
+func (w Window) Duration() time.Duration {
+	switch w {
+	case WindowLastHour:
+		return time.Hour
+	case WindowLastDay:
+		return 24 * time.Hour
+	case WindowLastWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// TimeRange is [From, To), zero range is all time
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (r TimeRange) IsAllTime() bool {
+	return r.From.IsZero() && r.To.IsZero()
+}
+
+// Align extends the range to whole steps in UTC
+func (r TimeRange) Align(step time.Duration) TimeRange {
+	if r.IsAllTime() {
+		return r
+	}
+
+	from := r.From.UTC().Truncate(step)
+	to := r.To.UTC().Truncate(step)
+	if to.Before(r.To) {
+		to = to.Add(step)
+	}
+
+	return TimeRange{From: from, To: to}
+}
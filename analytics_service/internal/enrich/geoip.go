@@ -0,0 +1,120 @@
+package enrich
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// UnknownCountry is returned for addresses missing in the database, private and reserved ones
+const UnknownCountry = "unknown"
+
+// privateCountryCode marks private and reserved ranges in DB-IP databases
+const privateCountryCode = "ZZ"
+
+type CountryLocator interface {
+	// Country returns ISO 3166 alpha-2 code of the country of ip or UnknownCountry
+	Country(ip string) string
+}
+
+type ipRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+// ipRangeDB looks up countries by sorted non-overlapping ip ranges
+type ipRangeDB struct {
+	ranges []ipRange
+}
+
+// NewCountryLocatorFromFile reads a DB-IP IP to Country Lite csv file, gzipped as it is
+// published when the name ends with .gz
+func NewCountryLocatorFromFile(path string) (CountryLocator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if !strings.HasSuffix(path, ".gz") {
+		return NewCountryLocator(file)
+	}
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	return NewCountryLocator(gzipReader)
+}
+
+// NewCountryLocator reads ranges in the format of DB-IP IP to Country Lite csv
+func NewCountryLocator(r io.Reader) (CountryLocator, error) {
+	return newIPRangeDB(r)
+}
+
+func newIPRangeDB(r io.Reader) (*ipRangeDB, error) {
+	ranges := make([]ipRange, 0)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("country database line %d: expected 3 fields, got %d", line, len(fields))
+		}
+		start, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("country database line %d: %w", line, err)
+		}
+		end, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("country database line %d: %w", line, err)
+		}
+
+		country := fields[2]
+		if country == privateCountryCode {
+			country = UnknownCountry
+		}
+		ranges = append(ranges, ipRange{start: start, end: end, country: country})
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Less(ranges[j].start)
+	})
+
+	return &ipRangeDB{ranges: ranges}, nil
+}
+
+func (db *ipRangeDB) Country(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return UnknownCountry
+	}
+	addr = addr.Unmap().WithZone("")
+
+	// The last range starting at or before addr is the only one that may contain it
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return addr.Less(db.ranges[i].start)
+	}) - 1
+	if i < 0 || db.ranges[i].end.Less(addr) {
+		return UnknownCountry
+	}
+
+	return db.ranges[i].country
+}
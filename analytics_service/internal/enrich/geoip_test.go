@@ -0,0 +1,66 @@
+package enrich
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCountryDB is a few ranges of DB-IP IP to Country Lite
+const testCountryDB = "testdata/dbip-country-lite.csv"
+
+func TestCountry(t *testing.T) {
+	locator, err := NewCountryLocatorFromFile(testCountryDB)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		ip              string
+		expectedCountry string
+	}{
+		{name: "Start of range", ip: "17.0.0.0", expectedCountry: "US"},
+		{name: "Inside range", ip: "53.12.34.56", expectedCountry: "DE"},
+		{name: "End of range", ip: "77.88.63.255", expectedCountry: "RU"},
+		{name: "Between ranges", ip: "77.88.64.0", expectedCountry: UnknownCountry},
+		{name: "Before first range", ip: "1.2.3.4", expectedCountry: UnknownCountry},
+		{name: "Private range", ip: "192.168.1.1", expectedCountry: UnknownCountry},
+		{name: "Ipv4 mapped ipv6", ip: "::ffff:133.1.2.3", expectedCountry: "JP"},
+		{name: "Ipv6", ip: "2001:4860:4860::8888", expectedCountry: "US"},
+		{name: "Invalid ip", ip: "not an ip", expectedCountry: UnknownCountry},
+		{name: "Empty ip", ip: "", expectedCountry: UnknownCountry},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCountry, locator.Country(tc.ip))
+		})
+	}
+}
+
+func TestNewIPRangeDBInvalidLine(t *testing.T) {
+	_, err := newIPRangeDB(strings.NewReader("1.0.0.0,1.0.0.255,AU\n1.0.1.0,CN\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestNewCountryLocatorFromGzipFile(t *testing.T) {
+	csv, err := os.ReadFile(testCountryDB)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "dbip-country-lite.csv.gz")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	_, err = gzipWriter.Write(csv)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, file.Close())
+
+	locator, err := NewCountryLocatorFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "DE", locator.Country("53.12.34.56"))
+}
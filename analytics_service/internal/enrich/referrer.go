@@ -0,0 +1,26 @@
+package enrich
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ReferrerHost returns the host of a referrer without www, it is empty for follows
+// without a referrer and for referrers that are not urls
+func ReferrerHost(referrer string) string {
+	referrer = strings.TrimSpace(referrer)
+	if referrer == "" {
+		return ""
+	}
+	// Browsers send absolute urls, a referrer without scheme is taken as host and path
+	if !strings.Contains(referrer, "://") {
+		referrer = "//" + referrer
+	}
+
+	u, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,47 @@
+package enrich
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferrerHost(t *testing.T) {
+	testCases := []struct {
+		name         string
+		referrer     string
+		expectedHost string
+	}{
+		{
+			name:         "Url with path and query",
+			referrer:     "https://www.Example.com/search?q=short+links",
+			expectedHost: "example.com",
+		},
+		{
+			name:         "Url with port",
+			referrer:     "http://blog.example.com:8080/post",
+			expectedHost: "blog.example.com",
+		},
+		{
+			name:         "Host without scheme",
+			referrer:     "example.com/page",
+			expectedHost: "example.com",
+		},
+		{
+			name:         "No referrer",
+			referrer:     "",
+			expectedHost: "",
+		},
+		{
+			name:         "Not a url",
+			referrer:     "http://[::1",
+			expectedHost: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedHost, ReferrerHost(tc.referrer))
+		})
+	}
+}
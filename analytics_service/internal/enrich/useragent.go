@@ -0,0 +1,87 @@
+package enrich
+
+import (
+	"strings"
+
+	"github.com/mssola/useragent"
+)
+
+// Device classes of user agents
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+)
+
+// UnknownUserAgent is every field of an empty or unrecognized user agent
+const UnknownUserAgent = "unknown"
+
+type UserAgentInfo struct {
+	Device  string
+	OS      string
+	Browser string
+}
+
+// ParseUserAgent classifies a user agent by rules compiled into the binary
+func ParseUserAgent(userAgent string) UserAgentInfo {
+	if strings.TrimSpace(userAgent) == "" {
+		return UserAgentInfo{Device: UnknownUserAgent, OS: UnknownUserAgent, Browser: UnknownUserAgent}
+	}
+
+	ua := useragent.New(userAgent)
+	browser, _ := ua.Browser()
+
+	return UserAgentInfo{
+		Device:  device(ua, userAgent),
+		OS:      osName(ua),
+		Browser: orUnknown(browser),
+	}
+}
+
+func device(ua *useragent.UserAgent, userAgent string) string {
+	switch {
+	case ua.Bot():
+		return DeviceBot
+	case ua.Platform() == "iPad" || strings.Contains(userAgent, "Tablet"):
+		return DeviceTablet
+	// Android tablets omit Mobile from the user agent
+	case strings.Contains(userAgent, "Android") && !strings.Contains(userAgent, "Mobile"):
+		return DeviceTablet
+	case ua.Mobile():
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// osName drops versions and merges names the parser gives to the same system
+func osName(ua *useragent.UserAgent) string {
+	switch ua.Platform() {
+	case "iPhone", "iPad", "iPod", "iPod touch":
+		return "iOS"
+	}
+
+	name := ua.OSInfo().Name
+	switch {
+	case strings.HasPrefix(name, "Windows"):
+		return "Windows"
+	case strings.HasPrefix(name, "Mac OS"):
+		return "macOS"
+	case strings.HasPrefix(name, "Android"):
+		return "Android"
+	case strings.HasPrefix(name, "CrOS"):
+		return "ChromeOS"
+	case strings.HasPrefix(name, "Linux"), strings.HasPrefix(name, "Ubuntu"):
+		return "Linux"
+	}
+
+	return orUnknown(name)
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return UnknownUserAgent
+	}
+	return value
+}
@@ -0,0 +1,67 @@
+package enrich
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	testCases := []struct {
+		name         string
+		userAgent    string
+		expectedInfo UserAgentInfo
+	}{
+		{
+			name:         "Chrome on Windows",
+			userAgent:    "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			expectedInfo: UserAgentInfo{Device: DeviceDesktop, OS: "Windows", Browser: "Chrome"},
+		},
+		{
+			name:         "Firefox on Linux",
+			userAgent:    "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			expectedInfo: UserAgentInfo{Device: DeviceDesktop, OS: "Linux", Browser: "Firefox"},
+		},
+		{
+			name:         "Safari on iPhone",
+			userAgent:    "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			expectedInfo: UserAgentInfo{Device: DeviceMobile, OS: "iOS", Browser: "Safari"},
+		},
+		{
+			name:         "Safari on iPad",
+			userAgent:    "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1",
+			expectedInfo: UserAgentInfo{Device: DeviceTablet, OS: "iOS", Browser: "Safari"},
+		},
+		{
+			name:         "Chrome on Android phone",
+			userAgent:    "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+			expectedInfo: UserAgentInfo{Device: DeviceMobile, OS: "Android", Browser: "Chrome"},
+		},
+		{
+			name:         "Chrome on Android tablet",
+			userAgent:    "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			expectedInfo: UserAgentInfo{Device: DeviceTablet, OS: "Android", Browser: "Chrome"},
+		},
+		{
+			name:         "Safari on macOS",
+			userAgent:    "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+			expectedInfo: UserAgentInfo{Device: DeviceDesktop, OS: "macOS", Browser: "Safari"},
+		},
+		{
+			name:         "Googlebot",
+			userAgent:    "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expectedInfo: UserAgentInfo{Device: DeviceBot, OS: UnknownUserAgent, Browser: "Googlebot"},
+		},
+		{
+			name:         "Empty user agent",
+			userAgent:    "",
+			expectedInfo: UserAgentInfo{Device: UnknownUserAgent, OS: UnknownUserAgent, Browser: UnknownUserAgent},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedInfo, ParseUserAgent(tc.userAgent))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package errs
+
+import "errors"
+
+var (
+	ErrInvalidTimeRange = errors.New("from must be before to")
+	ErrTooManyBuckets   = errors.New("time range has too many buckets for the granularity")
+	ErrWindowWithRange  = errors.New("window and custom time range are mutually exclusive")
+	ErrNoRangeStart     = errors.New("from is required for a custom time range")
+	ErrPageWithCursor   = errors.New("page and cursor are mutually exclusive")
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrInvalidEvent     = errors.New("invalid url event")
+	ErrNoURL            = errors.New("url not found")
+	ErrNotURLOwner      = errors.New("url belongs to another owner")
+)
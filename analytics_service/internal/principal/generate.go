@@ -0,0 +1,6 @@
+// Package principal is generated from url_shortener_service/internal/principal,
+// services are built separately and can not import each other.
+package principal
+
+//go:generate sh -c "{ echo '// Code generated from url_shortener_service/internal/principal/principal.go. DO NOT EDIT.'; echo; cat ../../../url_shortener_service/internal/principal/principal.go; } > principal.go"
+//go:generate sh -c "{ echo '// Code generated from url_shortener_service/internal/principal/interceptor.go. DO NOT EDIT.'; echo; cat ../../../url_shortener_service/internal/principal/interceptor.go; } > interceptor.go"
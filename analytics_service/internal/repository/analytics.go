@@ -2,11 +2,41 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"analytics_service/internal/domain"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name AnalyticsRepo
 type AnalyticsRepo interface {
-	GetTopUrls(ctx context.Context, paginationParams domain.PaginationParams) ([]domain.TopURLData, error)
+	GetTopUrls(
+		ctx context.Context,
+		paginationParams domain.PaginationParams,
+		ranking domain.Ranking,
+		timeRange domain.TimeRange,
+	) ([]domain.TopURLData, error)
+	// GetTopUrlsAfter returns up to limit top urls of the cursor range that follow its key
+	GetTopUrlsAfter(ctx context.Context, cursor domain.TopURLsCursor, limit int) ([]domain.TopURLData, error)
+	// GetURLOwner returns the owner of the current url of the short url, errs.ErrNoURL when it was deleted
+	// or never created
+	GetURLOwner(ctx context.Context, shortURL string) (domain.URLOwner, error)
+	// GetFollowCounts returns non-empty buckets of [from, to) ordered by start
+	// and the number of unique visitors of the whole range
+	GetFollowCounts(
+		ctx context.Context,
+		shortURL string,
+		from time.Time,
+		to time.Time,
+		granularity domain.Granularity,
+	) ([]domain.URLStatsBucket, int64, error)
+	// GetFollowCountsBy returns limit values of the dimension with the most follows in [from, to)
+	// ordered by follow count and the number of follows of all values
+	GetFollowCountsBy(
+		ctx context.Context,
+		shortURL string,
+		dimension domain.Dimension,
+		from time.Time,
+		to time.Time,
+		limit int,
+	) ([]domain.BreakdownValue, int64, error)
 }
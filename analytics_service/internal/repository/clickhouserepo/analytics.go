@@ -2,9 +2,12 @@ package clickhouserepo
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"analytics_service/internal/domain"
+	"analytics_service/internal/errs"
 	"analytics_service/internal/repository"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 )
@@ -45,18 +48,73 @@ func NewAnalyticsRepoClickhouse(
 	}, nil
 }
 
-const getTopUrlsQuery = `select long_url, short_url, follow_count, create_count from url_events_counter FINAL 
-ORDER BY (follow_count, create_count) DESC 
+var topUrlsOrderExprs = map[domain.Ranking]string{
+	domain.RankingFollows:       "(follow_count, create_count)",
+	domain.RankingUniqueFollows: "(unique_follow_count, follow_count)",
+}
+
+// Ranking by unique follows merges visitors of every url, urls without follows
+// have no unique visitors row and get zero from the left join
+const getTopUrlsByUniqueFollowsQuery = `SELECT c.long_url, c.short_url, c.follow_count, c.create_count, v.unique_follow_count 
+FROM url_events_counter AS c FINAL 
+LEFT JOIN (
+    SELECT short_url, uniqCombinedMerge(unique_visitors) AS unique_follow_count 
+    FROM url_unique_visitors 
+    GROUP BY short_url
+) AS v ON c.short_url = v.short_url 
+ORDER BY %s DESC 
+LIMIT $1
+OFFSET $2;`
+
+// Ranking by follows needs no visitors, they are merged for urls of the page afterwards
+const getTopUrlsByFollowsQuery = `SELECT long_url, short_url, follow_count, create_count, toUInt64(0) 
+FROM url_events_counter FINAL 
+ORDER BY %s DESC 
+LIMIT $1
+OFFSET $2;`
+
+const getUniqueFollowCountsQuery = `SELECT short_url, uniqCombinedMerge(unique_visitors) 
+FROM url_unique_visitors 
+WHERE short_url IN ($1) 
+GROUP BY short_url;`
+
+// Columns are qualified, otherwise aliases of the sums would refer to themselves
+const getTopUrlsInRangeQuery = `SELECT h.long_url, 
+       h.short_url, 
+       sum(h.follow_count) AS follow_count, 
+       sum(h.create_count) AS create_count, 
+       uniqCombinedMerge(h.unique_visitors) AS unique_follow_count 
+FROM url_events_by_hour AS h 
+WHERE h.bucket >= $3 AND h.bucket < $4 
+GROUP BY h.long_url, h.short_url 
+ORDER BY %s DESC 
 LIMIT $1
 OFFSET $2;`
 
 func (r *analyticsRepoClickhouse) GetTopUrls(
 	ctx context.Context,
 	paginationParams domain.PaginationParams,
+	ranking domain.Ranking,
+	timeRange domain.TimeRange,
 ) ([]domain.TopURLData, error) {
+	orderExpr, ok := topUrlsOrderExprs[ranking]
+	if !ok {
+		return nil, fmt.Errorf("unknown ranking: %d", ranking)
+	}
+
 	offset := paginationParams.Limit * (paginationParams.Page - 1)
 
-	rows, err := r.conn.Query(ctx, getTopUrlsQuery, paginationParams.Limit, offset)
+	query := fmt.Sprintf(getTopUrlsByUniqueFollowsQuery, orderExpr)
+	if ranking == domain.RankingFollows {
+		query = fmt.Sprintf(getTopUrlsByFollowsQuery, orderExpr)
+	}
+	args := []any{paginationParams.Limit, offset}
+	if !timeRange.IsAllTime() {
+		query = fmt.Sprintf(getTopUrlsInRangeQuery, orderExpr)
+		args = append(args, timeRange.From, timeRange.To)
+	}
+
+	rows, err := r.conn.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,14 +129,379 @@ func (r *analyticsRepoClickhouse) GetTopUrls(
 	topURLs := make([]domain.TopURLData, 0)
 	for rows.Next() {
 		var urlData domain.TopURLData
-		err = rows.Scan(&urlData.LongURL, &urlData.ShortURL, &urlData.FollowCount, &urlData.CreateCount)
+		var uniqueFollowCount uint64
+		err = rows.Scan(&urlData.LongURL, &urlData.ShortURL, &urlData.FollowCount, &urlData.CreateCount, &uniqueFollowCount)
 		if err != nil {
 			r.logger.Error(err.Error())
 			continue
 		}
+		urlData.UniqueFollowCount = int64(uniqueFollowCount)
 
 		topURLs = append(topURLs, urlData)
 	}
 
+	if ranking == domain.RankingFollows && timeRange.IsAllTime() {
+		err = r.setUniqueFollowCounts(ctx, topURLs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return topURLs, nil
+}
+
+// setUniqueFollowCounts merges visitors of the given urls only, urls without follows keep zero
+func (r *analyticsRepoClickhouse) setUniqueFollowCounts(ctx context.Context, topURLs []domain.TopURLData) error {
+	if len(topURLs) == 0 {
+		return nil
+	}
+
+	shortURLs := make([]string, len(topURLs))
+	for i, urlData := range topURLs {
+		shortURLs[i] = urlData.ShortURL
+	}
+
+	rows, err := r.conn.Query(ctx, getUniqueFollowCountsQuery, shortURLs)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.logger.Error(err.Error())
+		}
+	}()
+
+	uniqueFollowCounts := make(map[string]int64, len(shortURLs))
+	for rows.Next() {
+		var shortURL string
+		var uniqueFollowCount uint64
+		err = rows.Scan(&shortURL, &uniqueFollowCount)
+		if err != nil {
+			return err
+		}
+		uniqueFollowCounts[shortURL] = int64(uniqueFollowCount)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range topURLs {
+		topURLs[i].UniqueFollowCount = uniqueFollowCounts[topURLs[i].ShortURL]
+	}
+
+	return nil
+}
+
+// Keyset pages skip groups up to the key of the previous page instead of an offset,
+// short and long url make the order total
+const getTopUrlsAfterQuery = `SELECT h.long_url, 
+       h.short_url, 
+       sum(h.follow_count) AS follow_count, 
+       sum(h.create_count) AS create_count, 
+       uniqCombinedMerge(h.unique_visitors) AS unique_follow_count 
+FROM url_events_by_hour AS h 
+WHERE h.bucket >= $2 AND h.bucket < $3 
+GROUP BY h.long_url, h.short_url 
+%s 
+ORDER BY (%s, short_url, long_url) DESC 
+LIMIT $1;`
+
+const topUrlsAfterKeyClause = "HAVING (%s, short_url, long_url) < ($4, $5, $6, $7)"
+
+// All time pages are read from the live counters instead of merging the whole rollup,
+// the keyset condition applies to merged rows and skips urls of previous pages
+const getAllTimeTopUrlsAfterQuery = `SELECT long_url, short_url, follow_count, create_count, unique_follow_count 
+FROM (%s) 
+%s 
+ORDER BY (%s, short_url, long_url) DESC 
+LIMIT $1;`
+
+const allTimeTopUrlsAfterKeyClause = "WHERE (%s, short_url, long_url) < ($2, $3, $4, $5)"
+
+// Urls ranked by follows get unique follows for the page only
+var allTimeTopUrlsSources = map[domain.Ranking]string{
+	domain.RankingFollows: `SELECT long_url, short_url, follow_count, create_count, toUInt64(0) AS unique_follow_count 
+    FROM url_events_counter FINAL`,
+	domain.RankingUniqueFollows: `SELECT c.long_url AS long_url, 
+           c.short_url AS short_url, 
+           c.follow_count AS follow_count, 
+           c.create_count AS create_count, 
+           v.unique_follow_count AS unique_follow_count 
+    FROM url_events_counter AS c FINAL 
+    LEFT JOIN (
+        SELECT short_url, uniqCombinedMerge(unique_visitors) AS unique_follow_count 
+        FROM url_unique_visitors 
+        GROUP BY short_url
+    ) AS v ON c.short_url = v.short_url`,
+}
+
+// Order columns of rankings without parentheses, keys of keyset pages extend them
+var topUrlsKeyColumns = map[domain.Ranking]string{
+	domain.RankingFollows:       "follow_count, create_count",
+	domain.RankingUniqueFollows: "unique_follow_count, follow_count",
+}
+
+func (r *analyticsRepoClickhouse) GetTopUrlsAfter(
+	ctx context.Context,
+	cursor domain.TopURLsCursor,
+	limit int,
+) ([]domain.TopURLData, error) {
+	keyColumns, ok := topUrlsKeyColumns[cursor.Ranking]
+	if !ok {
+		return nil, fmt.Errorf("unknown ranking: %d", cursor.Ranking)
+	}
+
+	if cursor.TimeRange.IsAllTime() {
+		return r.getAllTimeTopUrlsAfter(ctx, cursor, keyColumns, limit)
+	}
+
+	afterKeyClause := ""
+	args := []any{limit, cursor.TimeRange.From, cursor.TimeRange.To}
+	if cursor.After != nil {
+		afterKeyClause = fmt.Sprintf(topUrlsAfterKeyClause, keyColumns)
+		args = append(args, cursor.After.Primary, cursor.After.Secondary, cursor.After.ShortURL, cursor.After.LongURL)
+	}
+
+	query := fmt.Sprintf(getTopUrlsAfterQuery, afterKeyClause, keyColumns)
+	return r.queryTopUrlsPage(ctx, query, args, limit)
+}
+
+func (r *analyticsRepoClickhouse) getAllTimeTopUrlsAfter(
+	ctx context.Context,
+	cursor domain.TopURLsCursor,
+	keyColumns string,
+	limit int,
+) ([]domain.TopURLData, error) {
+	afterKeyClause := ""
+	args := []any{limit}
+	if cursor.After != nil {
+		afterKeyClause = fmt.Sprintf(allTimeTopUrlsAfterKeyClause, keyColumns)
+		args = append(args, cursor.After.Primary, cursor.After.Secondary, cursor.After.ShortURL, cursor.After.LongURL)
+	}
+
+	query := fmt.Sprintf(getAllTimeTopUrlsAfterQuery, allTimeTopUrlsSources[cursor.Ranking], afterKeyClause, keyColumns)
+	topURLs, err := r.queryTopUrlsPage(ctx, query, args, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Ranking == domain.RankingFollows {
+		err = r.setUniqueFollowCounts(ctx, topURLs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return topURLs, nil
 }
+
+func (r *analyticsRepoClickhouse) queryTopUrlsPage(
+	ctx context.Context,
+	query string,
+	args []any,
+	limit int,
+) ([]domain.TopURLData, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.logger.Error(err.Error())
+		}
+	}()
+
+	topURLs := make([]domain.TopURLData, 0, limit)
+	for rows.Next() {
+		var urlData domain.TopURLData
+		var uniqueFollowCount uint64
+		err = rows.Scan(&urlData.LongURL, &urlData.ShortURL, &urlData.FollowCount, &urlData.CreateCount, &uniqueFollowCount)
+		if err != nil {
+			return nil, err
+		}
+		urlData.UniqueFollowCount = int64(uniqueFollowCount)
+
+		topURLs = append(topURLs, urlData)
+	}
+
+	return topURLs, rows.Err()
+}
+
+// The latest create event names the owner, a delete event after it frees the short url.
+// Events of one second are taken as a url deleted and created again
+const getURLOwnerQuery = `SELECT argMaxIf(owner_id, event_time, event_type = 'create'), 
+maxIf(event_time, event_type = 'create'), maxIf(event_time, event_type = 'delete') 
+FROM url_events_log 
+WHERE short_url = $1 AND event_type IN ('create', 'delete');`
+
+func (r *analyticsRepoClickhouse) GetURLOwner(ctx context.Context, shortURL string) (domain.URLOwner, error) {
+	var ownerID string
+	var createdAt, deletedAt time.Time
+	err := r.conn.QueryRow(ctx, getURLOwnerQuery, shortURL).Scan(&ownerID, &createdAt, &deletedAt)
+	if err != nil {
+		return domain.URLOwner{}, err
+	}
+
+	// Aggregates over no events are zero, which is the epoch for time
+	if createdAt.Unix() <= 0 || createdAt.Before(deletedAt) {
+		return domain.URLOwner{}, errs.ErrNoURL
+	}
+
+	return domain.URLOwner{
+		OwnerID:   ownerID,
+		CreatedAt: createdAt.UTC(),
+	}, nil
+}
+
+// Minute buckets are stored, hours and days are merged from them
+var bucketStartExprs = map[domain.Granularity]string{
+	domain.GranularityMinute: "bucket",
+	domain.GranularityHour:   "toStartOfHour(bucket)",
+	domain.GranularityDay:    "toStartOfDay(bucket)",
+}
+
+// Totals merge unique visitors of the whole range, the sum of buckets counts returning visitors again
+const getFollowCountsQuery = `SELECT %s AS start, countMerge(follow_count), uniqCombinedMerge(unique_visitors) 
+FROM url_follows_by_minute 
+WHERE short_url = $1 AND bucket >= $2 AND bucket < $3 
+GROUP BY start WITH TOTALS 
+ORDER BY start;`
+
+func (r *analyticsRepoClickhouse) GetFollowCounts(
+	ctx context.Context,
+	shortURL string,
+	from time.Time,
+	to time.Time,
+	granularity domain.Granularity,
+) ([]domain.URLStatsBucket, int64, error) {
+	bucketStartExpr, ok := bucketStartExprs[granularity]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown granularity: %d", granularity)
+	}
+
+	query := fmt.Sprintf(getFollowCountsQuery, bucketStartExpr)
+	rows, err := r.conn.Query(ctx, query, shortURL, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.logger.Error(err.Error())
+		}
+	}()
+
+	buckets := make([]domain.URLStatsBucket, 0)
+	for rows.Next() {
+		var start time.Time
+		var followCount, uniqueFollowCount uint64
+		err = rows.Scan(&start, &followCount, &uniqueFollowCount)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		buckets = append(buckets, domain.URLStatsBucket{
+			Start:             start.UTC(),
+			FollowCount:       int64(followCount),
+			UniqueFollowCount: int64(uniqueFollowCount),
+		})
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Totals are absent when no bucket matched
+	if len(buckets) == 0 {
+		return buckets, 0, nil
+	}
+	var totalStart time.Time
+	var totalFollowCount, totalUniqueFollowCount uint64
+	err = rows.Totals(&totalStart, &totalFollowCount, &totalUniqueFollowCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return buckets, int64(totalUniqueFollowCount), nil
+}
+
+// Referrer hosts, countries, devices, systems and browsers are derived on ingest,
+// follows stored before have empty values and are counted as unknown
+var breakdownValueExprs = map[domain.Dimension]string{
+	domain.DimensionReferrerHost: "if(referrer_host = '', 'direct', referrer_host)",
+	domain.DimensionCountry:      "if(country = '', 'unknown', country)",
+	domain.DimensionDevice:       "if(device = '', 'unknown', device)",
+	domain.DimensionOS:           "if(os = '', 'unknown', os)",
+	domain.DimensionBrowser:      "if(browser = '', 'unknown', browser)",
+}
+
+// Totals count follows of all values, the limit cuts rows only
+const getFollowCountsByQuery = `SELECT %s AS value, count() AS follow_count FROM url_events_log 
+WHERE short_url = $1 AND event_type = 'follow' AND event_time >= $2 AND event_time < $3 
+GROUP BY value WITH TOTALS 
+ORDER BY follow_count DESC, value 
+LIMIT $4;`
+
+func (r *analyticsRepoClickhouse) GetFollowCountsBy(
+	ctx context.Context,
+	shortURL string,
+	dimension domain.Dimension,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]domain.BreakdownValue, int64, error) {
+	valueExpr, ok := breakdownValueExprs[dimension]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown dimension: %d", dimension)
+	}
+
+	query := fmt.Sprintf(getFollowCountsByQuery, valueExpr)
+	rows, err := r.conn.Query(ctx, query, shortURL, from, to, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.logger.Error(err.Error())
+		}
+	}()
+
+	values := make([]domain.BreakdownValue, 0)
+	for rows.Next() {
+		var value string
+		var followCount uint64
+		err = rows.Scan(&value, &followCount)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		values = append(values, domain.BreakdownValue{
+			Value:       value,
+			FollowCount: int64(followCount),
+		})
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Totals are absent when no follow matched
+	if len(values) == 0 {
+		return values, 0, nil
+	}
+	var totalValue string
+	var totalFollowCount uint64
+	err = rows.Totals(&totalValue, &totalFollowCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return values, int64(totalFollowCount), nil
+}
@@ -4,10 +4,27 @@ import (
 	"context"
 	"fmt"
 
+	"analytics_service/internal/domain"
 	"analytics_service/internal/repository"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 )
 
+// countQueries are the only queries records are counted with. uniq of the sorting key
+// is approximate, but unlike count() FINAL it does not merge parts of the whole table
+type countQueries struct {
+	all string
+	// inRange counts records with events in [$1, $2), it is empty when the dataset
+	// has no time range filter
+	inRange string
+}
+
+var datasetCountQueries = map[domain.Dataset]countQueries{
+	domain.DatasetTopURLs: {
+		all:     "SELECT uniq(long_url, short_url) FROM url_events_counter",
+		inRange: "SELECT uniq(long_url, short_url) FROM url_events_by_hour WHERE bucket >= $1 AND bucket < $2",
+	},
+}
+
 type paginationRepoClickhouse struct {
 	conn driver.Conn
 }
@@ -18,12 +35,28 @@ func NewPaginationRepoClickhouse(conn driver.Conn) repository.PaginationRepo {
 	}
 }
 
-func (r *paginationRepoClickhouse) GetRecordsCount(table string) (int, error) {
-	sqlTableQuery := fmt.Sprintf("SELECT count(*) FROM %s FINAL", table)
-	row := r.conn.QueryRow(context.Background(), sqlTableQuery)
+func (r *paginationRepoClickhouse) GetRecordsCount(
+	ctx context.Context,
+	dataset domain.Dataset,
+	filter domain.DatasetFilter,
+) (int, error) {
+	queries, ok := datasetCountQueries[dataset]
+	if !ok {
+		return 0, fmt.Errorf("unknown dataset: %d", dataset)
+	}
+
+	query := queries.all
+	var args []any
+	if !filter.TimeRange.IsAllTime() {
+		if queries.inRange == "" {
+			return 0, fmt.Errorf("dataset %d has no time range filter", dataset)
+		}
+		query = queries.inRange
+		args = append(args, filter.TimeRange.From, filter.TimeRange.To)
+	}
 
 	var recordsCount uint64
-	err := row.Scan(&recordsCount)
+	err := r.conn.QueryRow(ctx, query, args...).Scan(&recordsCount)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,121 @@
+package clickhouserepo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"analytics_service/internal/domain"
+	"analytics_service/internal/repository"
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// Materialized views of url_events_ingest store events in the log and rollups,
+// the table itself keeps nothing
+const insertURLEventsQuery = `INSERT INTO url_events_ingest 
+(event_id, long_url, short_url, event_time, event_type, referrer, user_agent, accept_language, client_ip, country, owner_id, device, os, browser, referrer_host)`
+
+// The log is sorted by short url and event time, both narrow the lookup down to a few granules
+const getStoredEventIDsQuery = `SELECT DISTINCT event_id FROM url_events_log 
+WHERE short_url IN $1 AND event_time >= $2 AND event_time <= $3 AND event_id IN $4;`
+
+type eventsSinkClickhouse struct {
+	logger *slog.Logger
+	conn   driver.Conn
+}
+
+func NewEventsSinkClickhouse(logger *slog.Logger, conn driver.Conn) repository.Sink {
+	return &eventsSinkClickhouse{
+		logger: logger,
+		conn:   conn,
+	}
+}
+
+// Write inserts the batch at once, views see it as a single block
+func (s *eventsSinkClickhouse) Write(ctx context.Context, events []domain.URLEvent) error {
+	batch, err := s.conn.PrepareBatch(ctx, insertURLEventsQuery)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		err = batch.Append(
+			event.ID,
+			event.LongURL,
+			event.ShortURL,
+			event.EventTime,
+			int8(event.EventType),
+			event.Referrer,
+			event.UserAgent,
+			event.AcceptLanguage,
+			event.ClientIP,
+			event.Country,
+			event.OwnerID,
+			event.Device,
+			event.OS,
+			event.Browser,
+			event.ReferrerHost,
+		)
+		if err != nil {
+			_ = batch.Abort()
+			return err
+		}
+	}
+
+	return batch.Send()
+}
+
+func (s *eventsSinkClickhouse) StoredIDs(ctx context.Context, events []domain.URLEvent) (map[string]struct{}, error) {
+	storedIDs := make(map[string]struct{})
+
+	var shortURLs, ids []any
+	var from, to time.Time
+	for _, event := range events {
+		if event.ID == "" {
+			continue
+		}
+		if len(ids) == 0 || event.EventTime.Before(from) {
+			from = event.EventTime
+		}
+		if len(ids) == 0 || event.EventTime.After(to) {
+			to = event.EventTime
+		}
+		shortURLs = append(shortURLs, event.ShortURL)
+		ids = append(ids, event.ID)
+	}
+	if len(ids) == 0 {
+		return storedIDs, nil
+	}
+
+	// The log keeps event time in seconds
+	rows, err := s.conn.Query(
+		ctx,
+		getStoredEventIDsQuery,
+		clickhouse.GroupSet{Value: shortURLs},
+		from.Truncate(time.Second),
+		to,
+		clickhouse.GroupSet{Value: ids},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			s.logger.Error(err.Error())
+		}
+	}()
+
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		storedIDs[id] = struct{}{}
+	}
+
+	return storedIDs, rows.Err()
+}
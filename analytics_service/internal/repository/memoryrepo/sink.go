@@ -0,0 +1,75 @@
+package memoryrepo
+
+import (
+	"context"
+	"sync"
+
+	"analytics_service/internal/domain"
+)
+
+// EventsSink keeps written events in memory, it stands in for clickhouse in tests
+type EventsSink struct {
+	mu     sync.Mutex
+	events []domain.URLEvent
+	// failures is the number of writes left to fail with err
+	failures int
+	err      error
+}
+
+func NewEventsSink() *EventsSink {
+	return &EventsSink{}
+}
+
+func (s *EventsSink) Write(ctx context.Context, events []domain.URLEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return s.err
+	}
+	s.events = append(s.events, events...)
+
+	return nil
+}
+
+func (s *EventsSink) StoredIDs(ctx context.Context, events []domain.URLEvent) (map[string]struct{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make(map[string]struct{}, len(events))
+	for _, event := range events {
+		if event.ID != "" {
+			ids[event.ID] = struct{}{}
+		}
+	}
+
+	storedIDs := make(map[string]struct{})
+	for _, stored := range s.events {
+		if _, ok := ids[stored.ID]; ok {
+			storedIDs[stored.ID] = struct{}{}
+		}
+	}
+
+	return storedIDs, nil
+}
+
+// FailWrites makes the next n writes fail with err without storing anything
+func (s *EventsSink) FailWrites(n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = n
+	s.err = err
+}
+
+// Events returns a copy of the stored events in the order they were written
+func (s *EventsSink) Events() []domain.URLEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]domain.URLEvent, len(s.events))
+	copy(events, s.events)
+
+	return events
+}
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // AnalyticsRepo is an autogenerated mock type for the AnalyticsRepo type
@@ -14,9 +16,83 @@ type AnalyticsRepo struct {
 	mock.Mock
 }
 
-// GetTopUrls provides a mock function with given fields: ctx, paginationParams
-func (_m *AnalyticsRepo) GetTopUrls(ctx context.Context, paginationParams domain.PaginationParams) ([]domain.TopURLData, error) {
-	ret := _m.Called(ctx, paginationParams)
+// GetFollowCounts provides a mock function with given fields: ctx, shortURL, from, to, granularity
+func (_m *AnalyticsRepo) GetFollowCounts(ctx context.Context, shortURL string, from time.Time, to time.Time, granularity domain.Granularity) ([]domain.URLStatsBucket, int64, error) {
+	ret := _m.Called(ctx, shortURL, from, to, granularity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFollowCounts")
+	}
+
+	var r0 []domain.URLStatsBucket
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, domain.Granularity) ([]domain.URLStatsBucket, int64, error)); ok {
+		return rf(ctx, shortURL, from, to, granularity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, domain.Granularity) []domain.URLStatsBucket); ok {
+		r0 = rf(ctx, shortURL, from, to, granularity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.URLStatsBucket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time, domain.Granularity) int64); ok {
+		r1 = rf(ctx, shortURL, from, to, granularity)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time, time.Time, domain.Granularity) error); ok {
+		r2 = rf(ctx, shortURL, from, to, granularity)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetFollowCountsBy provides a mock function with given fields: ctx, shortURL, dimension, from, to, limit
+func (_m *AnalyticsRepo) GetFollowCountsBy(ctx context.Context, shortURL string, dimension domain.Dimension, from time.Time, to time.Time, limit int) ([]domain.BreakdownValue, int64, error) {
+	ret := _m.Called(ctx, shortURL, dimension, from, to, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFollowCountsBy")
+	}
+
+	var r0 []domain.BreakdownValue
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Dimension, time.Time, time.Time, int) ([]domain.BreakdownValue, int64, error)); ok {
+		return rf(ctx, shortURL, dimension, from, to, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Dimension, time.Time, time.Time, int) []domain.BreakdownValue); ok {
+		r0 = rf(ctx, shortURL, dimension, from, to, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BreakdownValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Dimension, time.Time, time.Time, int) int64); ok {
+		r1 = rf(ctx, shortURL, dimension, from, to, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, domain.Dimension, time.Time, time.Time, int) error); ok {
+		r2 = rf(ctx, shortURL, dimension, from, to, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTopUrls provides a mock function with given fields: ctx, paginationParams, ranking, timeRange
+func (_m *AnalyticsRepo) GetTopUrls(ctx context.Context, paginationParams domain.PaginationParams, ranking domain.Ranking, timeRange domain.TimeRange) ([]domain.TopURLData, error) {
+	ret := _m.Called(ctx, paginationParams, ranking, timeRange)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTopUrls")
@@ -24,19 +100,77 @@ func (_m *AnalyticsRepo) GetTopUrls(ctx context.Context, paginationParams domain
 
 	var r0 []domain.TopURLData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams) ([]domain.TopURLData, error)); ok {
-		return rf(ctx, paginationParams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams, domain.Ranking, domain.TimeRange) ([]domain.TopURLData, error)); ok {
+		return rf(ctx, paginationParams, ranking, timeRange)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams) []domain.TopURLData); ok {
-		r0 = rf(ctx, paginationParams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams, domain.Ranking, domain.TimeRange) []domain.TopURLData); ok {
+		r0 = rf(ctx, paginationParams, ranking, timeRange)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.TopURLData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.PaginationParams) error); ok {
-		r1 = rf(ctx, paginationParams)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PaginationParams, domain.Ranking, domain.TimeRange) error); ok {
+		r1 = rf(ctx, paginationParams, ranking, timeRange)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTopUrlsAfter provides a mock function with given fields: ctx, cursor, limit
+func (_m *AnalyticsRepo) GetTopUrlsAfter(ctx context.Context, cursor domain.TopURLsCursor, limit int) ([]domain.TopURLData, error) {
+	ret := _m.Called(ctx, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopUrlsAfter")
+	}
+
+	var r0 []domain.TopURLData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TopURLsCursor, int) ([]domain.TopURLData, error)); ok {
+		return rf(ctx, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TopURLsCursor, int) []domain.TopURLData); ok {
+		r0 = rf(ctx, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TopURLData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TopURLsCursor, int) error); ok {
+		r1 = rf(ctx, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLOwner provides a mock function with given fields: ctx, shortURL
+func (_m *AnalyticsRepo) GetURLOwner(ctx context.Context, shortURL string) (domain.URLOwner, error) {
+	ret := _m.Called(ctx, shortURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLOwner")
+	}
+
+	var r0 domain.URLOwner
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.URLOwner, error)); ok {
+		return rf(ctx, shortURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.URLOwner); ok {
+		r0 = rf(ctx, shortURL)
+	} else {
+		r0 = ret.Get(0).(domain.URLOwner)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shortURL)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2,16 +2,21 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	domain "analytics_service/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // PaginationRepo is an autogenerated mock type for the PaginationRepo type
 type PaginationRepo struct {
 	mock.Mock
 }
 
-// GetRecordsCount provides a mock function with given fields: table
-func (_m *PaginationRepo) GetRecordsCount(table string) (int, error) {
-	ret := _m.Called(table)
+// GetRecordsCount provides a mock function with given fields: ctx, dataset, filter
+func (_m *PaginationRepo) GetRecordsCount(ctx context.Context, dataset domain.Dataset, filter domain.DatasetFilter) (int, error) {
+	ret := _m.Called(ctx, dataset, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetRecordsCount")
@@ -19,17 +24,17 @@ func (_m *PaginationRepo) GetRecordsCount(table string) (int, error) {
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
-		return rf(table)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Dataset, domain.DatasetFilter) (int, error)); ok {
+		return rf(ctx, dataset, filter)
 	}
-	if rf, ok := ret.Get(0).(func(string) int); ok {
-		r0 = rf(table)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Dataset, domain.DatasetFilter) int); ok {
+		r0 = rf(ctx, dataset, filter)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(table)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Dataset, domain.DatasetFilter) error); ok {
+		r1 = rf(ctx, dataset, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,6 +1,13 @@
 package repository
 
+import (
+	"context"
+
+	"analytics_service/internal/domain"
+)
+
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name PaginationRepo
 type PaginationRepo interface {
-	GetRecordsCount(table string) (int, error)
+	// GetRecordsCount approximately counts records of the dataset matching filter
+	GetRecordsCount(ctx context.Context, dataset domain.Dataset, filter domain.DatasetFilter) (int, error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"analytics_service/internal/domain"
+)
+
+// Sink stores batches of url events. A batch is delivered again after a failed write,
+// a rebalance or a restart, StoredIDs tells which of its events were already stored
+type Sink interface {
+	Write(ctx context.Context, events []domain.URLEvent) error
+	// StoredIDs returns ids of the events that are stored, events without id are never reported
+	StoredIDs(ctx context.Context, events []domain.URLEvent) (map[string]struct{}, error)
+}
@@ -2,28 +2,188 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"analytics_service/internal/domain"
+	"analytics_service/internal/errs"
 	"analytics_service/internal/repository"
 )
 
+// maxStatsBuckets bounds a response to a day of minutes, two months of hours or four years of days
+const maxStatsBuckets = 1440
+
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name AnalyticsService
 type AnalyticsService interface {
-	GetTopUrls(ctx context.Context, paginationParams domain.PaginationParams) ([]domain.TopURLData, error)
+	GetTopUrls(
+		ctx context.Context,
+		paginationParams domain.PaginationParams,
+		ranking domain.Ranking,
+		timeRange domain.TimeRange,
+	) ([]domain.TopURLData, error)
+	// GetTopUrlsPage returns limit top urls from the cursor position and the cursor of the next page
+	GetTopUrlsPage(ctx context.Context, cursor domain.TopURLsCursor, limit int) (domain.TopURLsPage, error)
+	GetURLStats(ctx context.Context, params domain.URLStatsParams) (domain.URLStats, error)
+	GetURLBreakdown(ctx context.Context, params domain.URLBreakdownParams) (domain.URLBreakdown, error)
 }
 
 type analyticsService struct {
 	analyticsRepo repository.AnalyticsRepo
 }
 
-func NewAnalyticsService(
-	analyticsRepo repository.AnalyticsRepo,
-) AnalyticsService {
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepo) AnalyticsService {
 	return &analyticsService{
 		analyticsRepo: analyticsRepo,
 	}
 }
 
-func (s *analyticsService) GetTopUrls(ctx context.Context, paginationParams domain.PaginationParams) ([]domain.TopURLData, error) {
-	return s.analyticsRepo.GetTopUrls(ctx, paginationParams)
+func (s *analyticsService) GetTopUrls(
+	ctx context.Context,
+	paginationParams domain.PaginationParams,
+	ranking domain.Ranking,
+	timeRange domain.TimeRange,
+) ([]domain.TopURLData, error) {
+	return s.analyticsRepo.GetTopUrls(ctx, paginationParams, ranking, timeRange)
+}
+
+func (s *analyticsService) GetTopUrlsPage(
+	ctx context.Context,
+	cursor domain.TopURLsCursor,
+	limit int,
+) (domain.TopURLsPage, error) {
+	// A snapshot taken within the first hour of a range has no whole hours yet
+	if !cursor.TimeRange.IsAllTime() && !cursor.TimeRange.From.Before(cursor.TimeRange.To) {
+		return domain.TopURLsPage{TopURLData: []domain.TopURLData{}}, nil
+	}
+
+	// The url after the page tells whether there is a next one
+	topURLs, err := s.analyticsRepo.GetTopUrlsAfter(ctx, cursor, limit+1)
+	if err != nil {
+		return domain.TopURLsPage{}, err
+	}
+	if len(topURLs) <= limit {
+		return domain.TopURLsPage{TopURLData: topURLs}, nil
+	}
+
+	topURLs = topURLs[:limit]
+	lastKey := topURLs[limit-1].Key(cursor.Ranking)
+	return domain.TopURLsPage{
+		TopURLData: topURLs,
+		Next: &domain.TopURLsCursor{
+			Ranking:   cursor.Ranking,
+			TimeRange: cursor.TimeRange,
+			After:     &lastKey,
+		},
+	}, nil
+}
+
+func (s *analyticsService) GetURLStats(ctx context.Context, params domain.URLStatsParams) (domain.URLStats, error) {
+	step := params.Granularity.Duration()
+	from := params.From.UTC().Truncate(step)
+	to := params.To.UTC()
+	if !from.Before(to) {
+		return domain.URLStats{}, errs.ErrInvalidTimeRange
+	}
+	if to.Sub(from) > maxStatsBuckets*step {
+		return domain.URLStats{}, errs.ErrTooManyBuckets
+	}
+
+	owner, err := s.urlOwner(ctx, params.ShortURL, params.OwnerID)
+	if err != nil {
+		return domain.URLStats{}, err
+	}
+
+	// Follows of deleted urls with the same short url are not counted, buckets before
+	// the creation stay empty. Rollups are kept by minute, so it is the precision of the cut
+	var counts []domain.URLStatsBucket
+	var totalUniqueFollowCount int64
+	countsFrom := from
+	if createdAt := owner.CreatedAt.Truncate(time.Minute); createdAt.After(countsFrom) {
+		countsFrom = createdAt
+	}
+	if countsFrom.Before(to) {
+		counts, totalUniqueFollowCount, err = s.analyticsRepo.GetFollowCounts(ctx, params.ShortURL, countsFrom, to, params.Granularity)
+		if err != nil {
+			return domain.URLStats{}, err
+		}
+	}
+
+	stats := domain.URLStats{
+		ShortURL:               params.ShortURL,
+		Granularity:            params.Granularity,
+		TotalUniqueFollowCount: totalUniqueFollowCount,
+	}
+	next := 0
+	for start := from; start.Before(to); start = start.Add(step) {
+		bucket := domain.URLStatsBucket{Start: start}
+		if next < len(counts) && counts[next].Start.Equal(start) {
+			bucket = counts[next]
+			next++
+		}
+
+		stats.Buckets = append(stats.Buckets, bucket)
+		stats.TotalFollowCount += bucket.FollowCount
+	}
+
+	return stats, nil
+}
+
+func (s *analyticsService) GetURLBreakdown(ctx context.Context, params domain.URLBreakdownParams) (domain.URLBreakdown, error) {
+	if !params.From.Before(params.To) {
+		return domain.URLBreakdown{}, errs.ErrInvalidTimeRange
+	}
+
+	owner, err := s.urlOwner(ctx, params.ShortURL, params.OwnerID)
+	if err != nil {
+		return domain.URLBreakdown{}, err
+	}
+
+	breakdown := domain.URLBreakdown{
+		ShortURL:  params.ShortURL,
+		Dimension: params.Dimension,
+		Values:    []domain.BreakdownValue{},
+	}
+
+	// Follows of deleted urls with the same short url are not counted
+	from := params.From
+	if owner.CreatedAt.After(from) {
+		from = owner.CreatedAt
+	}
+	if !from.Before(params.To) {
+		return breakdown, nil
+	}
+
+	values, totalFollowCount, err := s.analyticsRepo.GetFollowCountsBy(
+		ctx,
+		params.ShortURL,
+		params.Dimension,
+		from,
+		params.To,
+		params.Limit,
+	)
+	if err != nil {
+		return domain.URLBreakdown{}, err
+	}
+
+	breakdown.Values = values
+	breakdown.TotalFollowCount = totalFollowCount
+	breakdown.OtherFollowCount = totalFollowCount
+	for _, value := range values {
+		breakdown.OtherFollowCount -= value.FollowCount
+	}
+
+	return breakdown, nil
+}
+
+// urlOwner returns the owner of the current url of the short url when it is the caller.
+// Anonymous urls have no owner, their stats are not returned to anyone
+func (s *analyticsService) urlOwner(ctx context.Context, shortURL string, ownerID string) (domain.URLOwner, error) {
+	owner, err := s.analyticsRepo.GetURLOwner(ctx, shortURL)
+	if err != nil {
+		return domain.URLOwner{}, err
+	}
+	if owner.OwnerID == "" || owner.OwnerID != ownerID {
+		return domain.URLOwner{}, errs.ErrNotURLOwner
+	}
+
+	return owner, nil
 }
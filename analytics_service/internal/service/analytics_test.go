@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"analytics_service/internal/domain"
+	"analytics_service/internal/enrich"
+	"analytics_service/internal/errs"
 	"analytics_service/internal/repository"
 	"analytics_service/internal/repository/mocks"
 	"github.com/stretchr/testify/assert"
@@ -14,7 +17,11 @@ import (
 
 func TestGetTopUrls(t *testing.T) {
 	testTopUrlData := []domain.TopURLData{
-		{LongURL: "http://test.long", ShortURL: "test", FollowCount: 10, CreateCount: 2},
+		{LongURL: "http://test.long", ShortURL: "test", FollowCount: 10, CreateCount: 2, UniqueFollowCount: 4},
+	}
+	testTimeRange := domain.TimeRange{
+		From: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC),
 	}
 	errTest := errors.New("test error")
 
@@ -22,6 +29,8 @@ func TestGetTopUrls(t *testing.T) {
 		name               string
 		buildAnalyticsRepo func() repository.AnalyticsRepo
 		paginationParams   domain.PaginationParams
+		ranking            domain.Ranking
+		timeRange          domain.TimeRange
 		expectedUrlData    []domain.TopURLData
 		expectedErr        error
 	}{
@@ -29,12 +38,28 @@ func TestGetTopUrls(t *testing.T) {
 			name: "get top urls without error",
 			buildAnalyticsRepo: func() repository.AnalyticsRepo {
 				mockRepo := mocks.NewAnalyticsRepo(t)
-				mockRepo.On("GetTopUrls", mock.Anything, mock.Anything).
+				mockRepo.On("GetTopUrls", mock.Anything, mock.Anything, domain.RankingFollows, domain.TimeRange{}).
+					Return(testTopUrlData, nil)
+
+				return mockRepo
+			},
+			paginationParams: domain.PaginationParams{Page: 1, Limit: 10},
+			ranking:          domain.RankingFollows,
+			expectedUrlData:  testTopUrlData,
+			expectedErr:      nil,
+		},
+		{
+			name: "get top urls of time range ranked by unique follows",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetTopUrls", mock.Anything, mock.Anything, domain.RankingUniqueFollows, testTimeRange).
 					Return(testTopUrlData, nil)
 
 				return mockRepo
 			},
 			paginationParams: domain.PaginationParams{Page: 1, Limit: 10},
+			ranking:          domain.RankingUniqueFollows,
+			timeRange:        testTimeRange,
 			expectedUrlData:  testTopUrlData,
 			expectedErr:      nil,
 		},
@@ -42,7 +67,7 @@ func TestGetTopUrls(t *testing.T) {
 			name: "get top urls error occurred",
 			buildAnalyticsRepo: func() repository.AnalyticsRepo {
 				mockRepo := mocks.NewAnalyticsRepo(t)
-				mockRepo.On("GetTopUrls", mock.Anything, mock.Anything).
+				mockRepo.On("GetTopUrls", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, errTest)
 
 				return mockRepo
@@ -57,9 +82,515 @@ func TestGetTopUrls(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			analyticsService := NewAnalyticsService(tc.buildAnalyticsRepo())
 
-			urlData, err := analyticsService.GetTopUrls(context.Background(), tc.paginationParams)
+			urlData, err := analyticsService.GetTopUrls(context.Background(), tc.paginationParams, tc.ranking, tc.timeRange)
 			assert.Equal(t, tc.expectedUrlData, urlData)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
+
+func TestGetTopUrlsPage(t *testing.T) {
+	testTimeRange := domain.TimeRange{
+		From: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC),
+	}
+	testTopUrlData := []domain.TopURLData{
+		{LongURL: "http://test.long1", ShortURL: "test1", FollowCount: 30, CreateCount: 3, UniqueFollowCount: 9},
+		{LongURL: "http://test.long2", ShortURL: "test2", FollowCount: 20, CreateCount: 2, UniqueFollowCount: 12},
+		{LongURL: "http://test.long3", ShortURL: "test3", FollowCount: 10, CreateCount: 1, UniqueFollowCount: 5},
+	}
+	testKey := domain.TopURLsKey{Primary: 40, Secondary: 4, ShortURL: "test0", LongURL: "http://test.long0"}
+	errTest := errors.New("test error")
+
+	testCases := []struct {
+		name               string
+		buildAnalyticsRepo func() repository.AnalyticsRepo
+		cursor             domain.TopURLsCursor
+		limit              int
+		expectedPage       domain.TopURLsPage
+		expectedErr        error
+	}{
+		{
+			name: "Next cursor starts after the last url of the page",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetTopUrlsAfter", mock.Anything, domain.TopURLsCursor{
+					Ranking:   domain.RankingUniqueFollows,
+					TimeRange: testTimeRange,
+				}, 3).
+					Return(testTopUrlData, nil)
+
+				return mockRepo
+			},
+			cursor: domain.TopURLsCursor{Ranking: domain.RankingUniqueFollows, TimeRange: testTimeRange},
+			limit:  2,
+			expectedPage: domain.TopURLsPage{
+				TopURLData: testTopUrlData[:2],
+				Next: &domain.TopURLsCursor{
+					Ranking:   domain.RankingUniqueFollows,
+					TimeRange: testTimeRange,
+					After:     &domain.TopURLsKey{Primary: 12, Secondary: 20, ShortURL: "test2", LongURL: "http://test.long2"},
+				},
+			},
+		},
+		{
+			name: "Last page has no next cursor",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetTopUrlsAfter", mock.Anything, domain.TopURLsCursor{
+					Ranking:   domain.RankingFollows,
+					TimeRange: testTimeRange,
+					After:     &testKey,
+				}, 4).
+					Return(testTopUrlData, nil)
+
+				return mockRepo
+			},
+			cursor:       domain.TopURLsCursor{Ranking: domain.RankingFollows, TimeRange: testTimeRange, After: &testKey},
+			limit:        3,
+			expectedPage: domain.TopURLsPage{TopURLData: testTopUrlData},
+		},
+		{
+			name: "All time is paged without a time range",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetTopUrlsAfter", mock.Anything, domain.TopURLsCursor{Ranking: domain.RankingFollows}, 4).
+					Return(testTopUrlData, nil)
+
+				return mockRepo
+			},
+			cursor:       domain.TopURLsCursor{Ranking: domain.RankingFollows},
+			limit:        3,
+			expectedPage: domain.TopURLsPage{TopURLData: testTopUrlData},
+		},
+		{
+			name: "Snapshot without whole hours is empty",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				return mocks.NewAnalyticsRepo(t)
+			},
+			cursor: domain.TopURLsCursor{
+				Ranking:   domain.RankingFollows,
+				TimeRange: domain.TimeRange{From: testTimeRange.To, To: testTimeRange.To},
+			},
+			limit:        3,
+			expectedPage: domain.TopURLsPage{TopURLData: []domain.TopURLData{}},
+		},
+		{
+			name: "Repository error",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetTopUrlsAfter", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errTest)
+
+				return mockRepo
+			},
+			cursor:      domain.TopURLsCursor{Ranking: domain.RankingFollows, TimeRange: testTimeRange},
+			limit:       3,
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			analyticsService := NewAnalyticsService(tc.buildAnalyticsRepo())
+
+			page, err := analyticsService.GetTopUrlsPage(context.Background(), tc.cursor, tc.limit)
+			assert.Equal(t, tc.expectedPage, page)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGetURLStats(t *testing.T) {
+	testFrom := time.Date(2024, time.May, 1, 10, 30, 0, 0, time.UTC)
+	testHour := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	testOwner := domain.URLOwner{OwnerID: "owner", CreatedAt: testHour.Add(-24 * time.Hour)}
+	errTest := errors.New("test error")
+
+	testCases := []struct {
+		name               string
+		buildAnalyticsRepo func() repository.AnalyticsRepo
+		params             domain.URLStatsParams
+		expectedStats      domain.URLStats
+		expectedErr        error
+	}{
+		{
+			name: "Missing buckets are filled with zeros",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(testOwner, nil).
+					Once()
+				mockRepo.On("GetFollowCounts", mock.Anything, "test", testHour, testHour.Add(3*time.Hour), domain.GranularityHour).
+					Return([]domain.URLStatsBucket{
+						{Start: testHour, FollowCount: 2, UniqueFollowCount: 1},
+						{Start: testHour.Add(2 * time.Hour), FollowCount: 5, UniqueFollowCount: 3},
+					}, int64(3), nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testFrom,
+				To:          testHour.Add(3 * time.Hour),
+				Granularity: domain.GranularityHour,
+			},
+			expectedStats: domain.URLStats{
+				ShortURL:    "test",
+				Granularity: domain.GranularityHour,
+				Buckets: []domain.URLStatsBucket{
+					{Start: testHour, FollowCount: 2, UniqueFollowCount: 1},
+					{Start: testHour.Add(time.Hour), FollowCount: 0},
+					{Start: testHour.Add(2 * time.Hour), FollowCount: 5, UniqueFollowCount: 3},
+				},
+				TotalFollowCount:       7,
+				TotalUniqueFollowCount: 3,
+			},
+		},
+		{
+			name: "Follows before creation are not counted",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(domain.URLOwner{OwnerID: "owner", CreatedAt: testHour.Add(90*time.Minute + 10*time.Second)}, nil).
+					Once()
+				mockRepo.On("GetFollowCounts", mock.Anything, "test", testHour.Add(90*time.Minute), testHour.Add(3*time.Hour), domain.GranularityHour).
+					Return([]domain.URLStatsBucket{
+						{Start: testHour.Add(time.Hour), FollowCount: 1, UniqueFollowCount: 1},
+					}, int64(1), nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testHour,
+				To:          testHour.Add(3 * time.Hour),
+				Granularity: domain.GranularityHour,
+			},
+			expectedStats: domain.URLStats{
+				ShortURL:    "test",
+				Granularity: domain.GranularityHour,
+				Buckets: []domain.URLStatsBucket{
+					{Start: testHour},
+					{Start: testHour.Add(time.Hour), FollowCount: 1, UniqueFollowCount: 1},
+					{Start: testHour.Add(2 * time.Hour)},
+				},
+				TotalFollowCount:       1,
+				TotalUniqueFollowCount: 1,
+			},
+		},
+		{
+			name: "Range before creation",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(domain.URLOwner{OwnerID: "owner", CreatedAt: testHour.Add(time.Hour)}, nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testHour,
+				To:          testHour.Add(time.Hour),
+				Granularity: domain.GranularityHour,
+			},
+			expectedStats: domain.URLStats{
+				ShortURL:    "test",
+				Granularity: domain.GranularityHour,
+				Buckets:     []domain.URLStatsBucket{{Start: testHour}},
+			},
+		},
+		{
+			name: "Url of another owner",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(testOwner, nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "other",
+				From:        testFrom,
+				To:          testFrom.Add(time.Hour),
+				Granularity: domain.GranularityHour,
+			},
+			expectedErr: errs.ErrNotURLOwner,
+		},
+		{
+			name: "Anonymous url",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(domain.URLOwner{CreatedAt: testHour}, nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testFrom,
+				To:          testFrom.Add(time.Hour),
+				Granularity: domain.GranularityHour,
+			},
+			expectedErr: errs.ErrNotURLOwner,
+		},
+		{
+			name: "Deleted url",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(domain.URLOwner{}, errs.ErrNoURL).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testFrom,
+				To:          testFrom.Add(time.Hour),
+				Granularity: domain.GranularityHour,
+			},
+			expectedErr: errs.ErrNoURL,
+		},
+		{
+			name: "From after to",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				return mocks.NewAnalyticsRepo(t)
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testFrom,
+				To:          testFrom.Add(-time.Hour),
+				Granularity: domain.GranularityMinute,
+			},
+			expectedErr: errs.ErrInvalidTimeRange,
+		},
+		{
+			name: "Too many minute buckets",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				return mocks.NewAnalyticsRepo(t)
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testFrom,
+				To:          testFrom.Add(48 * time.Hour),
+				Granularity: domain.GranularityMinute,
+			},
+			expectedErr: errs.ErrTooManyBuckets,
+		},
+		{
+			name: "Repository error",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(testOwner, nil).
+					Once()
+				mockRepo.On("GetFollowCounts", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, int64(0), errTest).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLStatsParams{
+				ShortURL:    "test",
+				OwnerID:     "owner",
+				From:        testFrom,
+				To:          testFrom.Add(24 * time.Hour),
+				Granularity: domain.GranularityDay,
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			analyticsService := NewAnalyticsService(tc.buildAnalyticsRepo())
+
+			stats, err := analyticsService.GetURLStats(context.Background(), tc.params)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedStats, stats)
+		})
+	}
+}
+
+func TestGetURLBreakdown(t *testing.T) {
+	testFrom := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	testTo := testFrom.Add(24 * time.Hour)
+	testOwner := domain.URLOwner{OwnerID: "owner", CreatedAt: testFrom.Add(-time.Hour)}
+	errTest := errors.New("test error")
+
+	testCases := []struct {
+		name               string
+		buildAnalyticsRepo func() repository.AnalyticsRepo
+		params             domain.URLBreakdownParams
+		expectedBreakdown  domain.URLBreakdown
+		expectedErr        error
+	}{
+		{
+			name: "Values beyond limit are counted as other",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(testOwner, nil).
+					Once()
+				mockRepo.On("GetFollowCountsBy", mock.Anything, "test", domain.DimensionDevice, testFrom, testTo, 1).
+					Return([]domain.BreakdownValue{{Value: enrich.DeviceDesktop, FollowCount: 4}}, int64(7), nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLBreakdownParams{
+				ShortURL:  "test",
+				OwnerID:   "owner",
+				Dimension: domain.DimensionDevice,
+				From:      testFrom,
+				To:        testTo,
+				Limit:     1,
+			},
+			expectedBreakdown: domain.URLBreakdown{
+				ShortURL:  "test",
+				Dimension: domain.DimensionDevice,
+				Values: []domain.BreakdownValue{
+					{Value: enrich.DeviceDesktop, FollowCount: 4},
+				},
+				OtherFollowCount: 3,
+				TotalFollowCount: 7,
+			},
+		},
+		{
+			name: "Follows before creation are not counted",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(domain.URLOwner{OwnerID: "owner", CreatedAt: testFrom.Add(time.Hour)}, nil).
+					Once()
+				mockRepo.On("GetFollowCountsBy", mock.Anything, "test", domain.DimensionCountry, testFrom.Add(time.Hour), testTo, 10).
+					Return([]domain.BreakdownValue{{Value: "DE", FollowCount: 2}}, int64(2), nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLBreakdownParams{
+				ShortURL:  "test",
+				OwnerID:   "owner",
+				Dimension: domain.DimensionCountry,
+				From:      testFrom,
+				To:        testTo,
+				Limit:     10,
+			},
+			expectedBreakdown: domain.URLBreakdown{
+				ShortURL:  "test",
+				Dimension: domain.DimensionCountry,
+				Values: []domain.BreakdownValue{
+					{Value: "DE", FollowCount: 2},
+				},
+				TotalFollowCount: 2,
+			},
+		},
+		{
+			name: "Range before creation",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(domain.URLOwner{OwnerID: "owner", CreatedAt: testTo}, nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLBreakdownParams{
+				ShortURL:  "test",
+				OwnerID:   "owner",
+				Dimension: domain.DimensionBrowser,
+				From:      testFrom,
+				To:        testTo,
+				Limit:     10,
+			},
+			expectedBreakdown: domain.URLBreakdown{
+				ShortURL:  "test",
+				Dimension: domain.DimensionBrowser,
+				Values:    []domain.BreakdownValue{},
+			},
+		},
+		{
+			name: "Url of another owner",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(testOwner, nil).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLBreakdownParams{
+				ShortURL:  "test",
+				OwnerID:   "other",
+				Dimension: domain.DimensionOS,
+				From:      testFrom,
+				To:        testTo,
+				Limit:     10,
+			},
+			expectedErr: errs.ErrNotURLOwner,
+		},
+		{
+			name: "From after to",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				return mocks.NewAnalyticsRepo(t)
+			},
+			params: domain.URLBreakdownParams{
+				ShortURL:  "test",
+				OwnerID:   "owner",
+				Dimension: domain.DimensionOS,
+				From:      testTo,
+				To:        testFrom,
+				Limit:     10,
+			},
+			expectedErr: errs.ErrInvalidTimeRange,
+		},
+		{
+			name: "Repository error",
+			buildAnalyticsRepo: func() repository.AnalyticsRepo {
+				mockRepo := mocks.NewAnalyticsRepo(t)
+				mockRepo.On("GetURLOwner", mock.Anything, "test").
+					Return(testOwner, nil).
+					Once()
+				mockRepo.On("GetFollowCountsBy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, int64(0), errTest).
+					Once()
+
+				return mockRepo
+			},
+			params: domain.URLBreakdownParams{
+				ShortURL:  "test",
+				OwnerID:   "owner",
+				Dimension: domain.DimensionOS,
+				From:      testFrom,
+				To:        testTo,
+				Limit:     10,
+			},
+			expectedErr: errTest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			analyticsService := NewAnalyticsService(tc.buildAnalyticsRepo())
+
+			breakdown, err := analyticsService.GetURLBreakdown(context.Background(), tc.params)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedBreakdown, breakdown)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"analytics_service/internal/domain"
+	"analytics_service/internal/enrich"
+	"analytics_service/internal/errs"
+	"analytics_service/internal/repository"
+)
+
+const (
+	initialSinkBackoff = 100 * time.Millisecond
+	maxSinkBackoff     = 10 * time.Second
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name IngestService
+type IngestService interface {
+	// Prepare validates a decoded event and enriches it, invalid events wrap errs.ErrInvalidEvent
+	Prepare(event domain.URLEvent) (domain.URLEvent, error)
+	// Store writes a batch through the sink, retrying until it is stored or ctx is done.
+	// Events stored by an earlier delivery and repeated events are skipped
+	Store(ctx context.Context, events []domain.URLEvent) error
+}
+
+type ingestService struct {
+	logger         *slog.Logger
+	sink           repository.Sink
+	countryLocator enrich.CountryLocator
+}
+
+func NewIngestService(
+	logger *slog.Logger,
+	sink repository.Sink,
+	countryLocator enrich.CountryLocator,
+) IngestService {
+	return &ingestService{
+		logger:         logger,
+		sink:           sink,
+		countryLocator: countryLocator,
+	}
+}
+
+func (s *ingestService) Prepare(event domain.URLEvent) (domain.URLEvent, error) {
+	err := event.Validate()
+	if err != nil {
+		return domain.URLEvent{}, fmt.Errorf("%w: %v", errs.ErrInvalidEvent, err)
+	}
+
+	// Values of breakdowns are derived once, so follows are grouped by them in the storage
+	if event.EventType == domain.EventTypeFollow {
+		event.Country = s.countryLocator.Country(event.ClientIP)
+		userAgent := enrich.ParseUserAgent(event.UserAgent)
+		event.Device = userAgent.Device
+		event.OS = userAgent.OS
+		event.Browser = userAgent.Browser
+		event.ReferrerHost = enrich.ReferrerHost(event.Referrer)
+	}
+
+	return event, nil
+}
+
+// Store retries with exponential backoff without a limit. The consumer does not fetch
+// while it waits, so a slow sink holds events back in kafka instead of dropping them
+func (s *ingestService) Store(ctx context.Context, events []domain.URLEvent) error {
+	events = skipRepeated(events)
+
+	backoff := initialSinkBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.storeNew(ctx, events)
+		if err == nil {
+			return nil
+		}
+
+		s.logger.Warn(
+			"store url events",
+			slog.String("error", err.Error()),
+			slog.Int("attempt", attempt),
+			slog.Int("events", len(events)),
+		)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxSinkBackoff)
+	}
+}
+
+// storeNew writes events the sink does not have yet. The check is repeated on every attempt,
+// a failed write may have stored a part of the batch
+func (s *ingestService) storeNew(ctx context.Context, events []domain.URLEvent) error {
+	storedIDs, err := s.sink.StoredIDs(ctx, events)
+	if err != nil {
+		return err
+	}
+
+	newEvents := make([]domain.URLEvent, 0, len(events))
+	for _, event := range events {
+		if _, stored := storedIDs[event.ID]; !stored {
+			newEvents = append(newEvents, event)
+		}
+	}
+	if skipped := len(events) - len(newEvents); skipped > 0 {
+		s.logger.Debug("skipped stored url events", slog.Int("count", skipped))
+	}
+	if len(newEvents) == 0 {
+		return nil
+	}
+
+	return s.sink.Write(ctx, newEvents)
+}
+
+// skipRepeated drops events delivered twice within a batch, legacy events have no id and are kept
+func skipRepeated(events []domain.URLEvent) []domain.URLEvent {
+	ids := make(map[string]struct{}, len(events))
+	unique := make([]domain.URLEvent, 0, len(events))
+	for _, event := range events {
+		if event.ID != "" {
+			if _, repeated := ids[event.ID]; repeated {
+				continue
+			}
+			ids[event.ID] = struct{}{}
+		}
+		unique = append(unique, event)
+	}
+
+	return unique
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"analytics_service/internal/domain"
+	"analytics_service/internal/enrich"
+	"analytics_service/internal/errs"
+	"analytics_service/internal/repository/memoryrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestPrepare(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	countryLocator, err := enrich.NewCountryLocator(strings.NewReader("8.8.8.0,8.8.8.255,US"))
+	require.NoError(t, err)
+
+	eventTime := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	chromeAndroid := "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36"
+
+	testCases := []struct {
+		name          string
+		event         domain.URLEvent
+		expectedEvent domain.URLEvent
+		expectedErr   error
+	}{
+		{
+			name: "Follow dimensions are derived",
+			event: domain.URLEvent{
+				EventType: domain.EventTypeFollow,
+				ShortURL:  "abc",
+				EventTime: eventTime,
+				ClientIP:  "8.8.8.8",
+				UserAgent: chromeAndroid,
+				Referrer:  "https://www.example.com/search?q=abc",
+			},
+			expectedEvent: domain.URLEvent{
+				EventType:    domain.EventTypeFollow,
+				ShortURL:     "abc",
+				EventTime:    eventTime,
+				ClientIP:     "8.8.8.8",
+				UserAgent:    chromeAndroid,
+				Referrer:     "https://www.example.com/search?q=abc",
+				Country:      "US",
+				Device:       enrich.DeviceMobile,
+				OS:           "Android",
+				Browser:      "Chrome",
+				ReferrerHost: "example.com",
+			},
+		},
+		{
+			name: "Follow without request context",
+			event: domain.URLEvent{
+				EventType: domain.EventTypeFollow,
+				ShortURL:  "abc",
+				EventTime: eventTime,
+			},
+			expectedEvent: domain.URLEvent{
+				EventType: domain.EventTypeFollow,
+				ShortURL:  "abc",
+				EventTime: eventTime,
+				Country:   enrich.UnknownCountry,
+				Device:    enrich.UnknownUserAgent,
+				OS:        enrich.UnknownUserAgent,
+				Browser:   enrich.UnknownUserAgent,
+			},
+		},
+		{
+			name: "Create without request context",
+			event: domain.URLEvent{
+				EventType: domain.EventTypeCreate,
+				ShortURL:  "abc",
+				EventTime: eventTime,
+			},
+			expectedEvent: domain.URLEvent{
+				EventType: domain.EventTypeCreate,
+				ShortURL:  "abc",
+				EventTime: eventTime,
+			},
+		},
+		{
+			name: "Unknown event type",
+			event: domain.URLEvent{
+				EventType: 7,
+				ShortURL:  "abc",
+				EventTime: eventTime,
+			},
+			expectedErr: errs.ErrInvalidEvent,
+		},
+		{
+			name: "Empty short url",
+			event: domain.URLEvent{
+				EventType: domain.EventTypeFollow,
+				EventTime: eventTime,
+			},
+			expectedErr: errs.ErrInvalidEvent,
+		},
+		{
+			name: "Event time is not set",
+			event: domain.URLEvent{
+				EventType: domain.EventTypeFollow,
+				ShortURL:  "abc",
+			},
+			expectedErr: errs.ErrInvalidEvent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ingestService := NewIngestService(logger, memoryrepo.NewEventsSink(), countryLocator)
+
+			event, err := ingestService.Prepare(tc.event)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedEvent, event)
+		})
+	}
+}
+
+func TestIngestStore(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	countryLocator, err := enrich.NewCountryLocator(strings.NewReader("8.8.8.0,8.8.8.255,US"))
+	require.NoError(t, err)
+
+	first := domain.URLEvent{ID: "1", EventType: domain.EventTypeFollow, ShortURL: "abc"}
+	second := domain.URLEvent{ID: "2", EventType: domain.EventTypeFollow, ShortURL: "abc"}
+	third := domain.URLEvent{ID: "3", EventType: domain.EventTypeFollow, ShortURL: "abc"}
+	legacy := domain.URLEvent{EventType: domain.EventTypeFollow, ShortURL: "abc"}
+
+	testCases := []struct {
+		name           string
+		batches        [][]domain.URLEvent
+		expectedEvents []domain.URLEvent
+	}{
+		{
+			name:           "Redelivered events are skipped",
+			batches:        [][]domain.URLEvent{{first, second}, {second, third, third}},
+			expectedEvents: []domain.URLEvent{first, second, third},
+		},
+		{
+			name:           "Legacy events without id are kept",
+			batches:        [][]domain.URLEvent{{legacy, legacy}, {legacy}},
+			expectedEvents: []domain.URLEvent{legacy, legacy, legacy},
+		},
+		{
+			name:           "Batch of stored events",
+			batches:        [][]domain.URLEvent{{first, second}, {first, second}},
+			expectedEvents: []domain.URLEvent{first, second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := memoryrepo.NewEventsSink()
+
+			for _, batch := range tc.batches {
+				// Every batch is stored by a new service as after a restart or a rebalance
+				ingestService := NewIngestService(logger, sink, countryLocator)
+				err := ingestService.Store(context.Background(), batch)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedEvents, sink.Events())
+		})
+	}
+}
+
+func TestIngestStoreRetries(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	countryLocator, err := enrich.NewCountryLocator(strings.NewReader("8.8.8.0,8.8.8.255,US"))
+	require.NoError(t, err)
+
+	events := []domain.URLEvent{{ID: "1", EventType: domain.EventTypeFollow, ShortURL: "abc"}}
+	errSink := errors.New("clickhouse is unavailable")
+
+	t.Run("Stored after failed writes", func(t *testing.T) {
+		sink := memoryrepo.NewEventsSink()
+		sink.FailWrites(2, errSink)
+		ingestService := NewIngestService(logger, sink, countryLocator)
+
+		err := ingestService.Store(context.Background(), events)
+		assert.NoError(t, err)
+		assert.Equal(t, events, sink.Events())
+	})
+
+	t.Run("Gives up when ctx is done", func(t *testing.T) {
+		sink := memoryrepo.NewEventsSink()
+		sink.FailWrites(100, errSink)
+		ingestService := NewIngestService(logger, sink, countryLocator)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := ingestService.Store(ctx, events)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, sink.Events())
+
+		// The batch was not stored, so it is not skipped when it is delivered again
+		sink.FailWrites(0, nil)
+		err = ingestService.Store(context.Background(), events)
+		assert.NoError(t, err)
+		assert.Equal(t, events, sink.Events())
+	})
+}
@@ -14,9 +14,9 @@ type AnalyticsService struct {
 	mock.Mock
 }
 
-// GetTopUrls provides a mock function with given fields: ctx, paginationParams
-func (_m *AnalyticsService) GetTopUrls(ctx context.Context, paginationParams domain.PaginationParams) ([]domain.TopURLData, error) {
-	ret := _m.Called(ctx, paginationParams)
+// GetTopUrls provides a mock function with given fields: ctx, paginationParams, ranking, timeRange
+func (_m *AnalyticsService) GetTopUrls(ctx context.Context, paginationParams domain.PaginationParams, ranking domain.Ranking, timeRange domain.TimeRange) ([]domain.TopURLData, error) {
+	ret := _m.Called(ctx, paginationParams, ranking, timeRange)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTopUrls")
@@ -24,19 +24,103 @@ func (_m *AnalyticsService) GetTopUrls(ctx context.Context, paginationParams dom
 
 	var r0 []domain.TopURLData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams) ([]domain.TopURLData, error)); ok {
-		return rf(ctx, paginationParams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams, domain.Ranking, domain.TimeRange) ([]domain.TopURLData, error)); ok {
+		return rf(ctx, paginationParams, ranking, timeRange)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams) []domain.TopURLData); ok {
-		r0 = rf(ctx, paginationParams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PaginationParams, domain.Ranking, domain.TimeRange) []domain.TopURLData); ok {
+		r0 = rf(ctx, paginationParams, ranking, timeRange)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.TopURLData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.PaginationParams) error); ok {
-		r1 = rf(ctx, paginationParams)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PaginationParams, domain.Ranking, domain.TimeRange) error); ok {
+		r1 = rf(ctx, paginationParams, ranking, timeRange)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTopUrlsPage provides a mock function with given fields: ctx, cursor, limit
+func (_m *AnalyticsService) GetTopUrlsPage(ctx context.Context, cursor domain.TopURLsCursor, limit int) (domain.TopURLsPage, error) {
+	ret := _m.Called(ctx, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopUrlsPage")
+	}
+
+	var r0 domain.TopURLsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TopURLsCursor, int) (domain.TopURLsPage, error)); ok {
+		return rf(ctx, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TopURLsCursor, int) domain.TopURLsPage); ok {
+		r0 = rf(ctx, cursor, limit)
+	} else {
+		r0 = ret.Get(0).(domain.TopURLsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TopURLsCursor, int) error); ok {
+		r1 = rf(ctx, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLBreakdown provides a mock function with given fields: ctx, params
+func (_m *AnalyticsService) GetURLBreakdown(ctx context.Context, params domain.URLBreakdownParams) (domain.URLBreakdown, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLBreakdown")
+	}
+
+	var r0 domain.URLBreakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.URLBreakdownParams) (domain.URLBreakdown, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.URLBreakdownParams) domain.URLBreakdown); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(domain.URLBreakdown)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.URLBreakdownParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLStats provides a mock function with given fields: ctx, params
+func (_m *AnalyticsService) GetURLStats(ctx context.Context, params domain.URLStatsParams) (domain.URLStats, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLStats")
+	}
+
+	var r0 domain.URLStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.URLStatsParams) (domain.URLStats, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.URLStatsParams) domain.URLStats); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(domain.URLStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.URLStatsParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,75 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "analytics_service/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IngestService is an autogenerated mock type for the IngestService type
+type IngestService struct {
+	mock.Mock
+}
+
+// Prepare provides a mock function with given fields: event
+func (_m *IngestService) Prepare(event domain.URLEvent) (domain.URLEvent, error) {
+	ret := _m.Called(event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prepare")
+	}
+
+	var r0 domain.URLEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.URLEvent) (domain.URLEvent, error)); ok {
+		return rf(event)
+	}
+	if rf, ok := ret.Get(0).(func(domain.URLEvent) domain.URLEvent); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Get(0).(domain.URLEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.URLEvent) error); ok {
+		r1 = rf(event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, events
+func (_m *IngestService) Store(ctx context.Context, events []domain.URLEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Store")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIngestService creates a new instance of IngestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIngestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IngestService {
+	mock := &IngestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
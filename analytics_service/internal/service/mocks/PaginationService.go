@@ -4,6 +4,7 @@ package mocks
 
 import (
 	domain "analytics_service/internal/domain"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,9 +14,9 @@ type PaginationService struct {
 	mock.Mock
 }
 
-// GetPaginationInfo provides a mock function with given fields: table, paginationParams
-func (_m *PaginationService) GetPaginationInfo(table string, paginationParams domain.PaginationParams) (domain.Pagination, error) {
-	ret := _m.Called(table, paginationParams)
+// GetPaginationInfo provides a mock function with given fields: ctx, dataset, filter, paginationParams
+func (_m *PaginationService) GetPaginationInfo(ctx context.Context, dataset domain.Dataset, filter domain.DatasetFilter, paginationParams domain.PaginationParams) (domain.Pagination, error) {
+	ret := _m.Called(ctx, dataset, filter, paginationParams)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPaginationInfo")
@@ -23,17 +24,17 @@ func (_m *PaginationService) GetPaginationInfo(table string, paginationParams do
 
 	var r0 domain.Pagination
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, domain.PaginationParams) (domain.Pagination, error)); ok {
-		return rf(table, paginationParams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Dataset, domain.DatasetFilter, domain.PaginationParams) (domain.Pagination, error)); ok {
+		return rf(ctx, dataset, filter, paginationParams)
 	}
-	if rf, ok := ret.Get(0).(func(string, domain.PaginationParams) domain.Pagination); ok {
-		r0 = rf(table, paginationParams)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Dataset, domain.DatasetFilter, domain.PaginationParams) domain.Pagination); ok {
+		r0 = rf(ctx, dataset, filter, paginationParams)
 	} else {
 		r0 = ret.Get(0).(domain.Pagination)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, domain.PaginationParams) error); ok {
-		r1 = rf(table, paginationParams)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Dataset, domain.DatasetFilter, domain.PaginationParams) error); ok {
+		r1 = rf(ctx, dataset, filter, paginationParams)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,32 +1,67 @@
 package service
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"analytics_service/internal/domain"
 	"analytics_service/internal/repository"
 )
 
+// maxCachedCounts bounds the count cache, custom time ranges make a key each
+const maxCachedCounts = 1024
+
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name PaginationService
 type PaginationService interface {
-	GetPaginationInfo(table string, paginationParams domain.PaginationParams) (domain.Pagination, error)
+	// GetPaginationInfo counts records of the dataset matching filter,
+	// counts are approximate and cached for a while
+	GetPaginationInfo(
+		ctx context.Context,
+		dataset domain.Dataset,
+		filter domain.DatasetFilter,
+		paginationParams domain.PaginationParams,
+	) (domain.Pagination, error)
+}
+
+type countKey struct {
+	dataset domain.Dataset
+	from    int64
+	to      int64
+}
+
+type cachedCount struct {
+	count     int
+	expiresAt time.Time
 }
 
 type paginationService struct {
 	paginationRepo repository.PaginationRepo
+	countTTL       time.Duration
+
+	mu     sync.Mutex
+	counts map[countKey]cachedCount
 }
 
+// NewPaginationService caches counts for countTTL, zero countTTL disables the cache
 func NewPaginationService(
 	paginationRepo repository.PaginationRepo,
+	countTTL time.Duration,
 ) PaginationService {
 	return &paginationService{
 		paginationRepo: paginationRepo,
+		countTTL:       countTTL,
+		counts:         make(map[countKey]cachedCount),
 	}
 }
 
 func (s *paginationService) GetPaginationInfo(
-	table string,
+	ctx context.Context,
+	dataset domain.Dataset,
+	filter domain.DatasetFilter,
 	paginationParams domain.PaginationParams,
 ) (domain.Pagination, error) {
-	recordsCount, err := s.paginationRepo.GetRecordsCount(table)
+	recordsCount, err := s.recordsCount(ctx, dataset, filter)
 	if err != nil {
 		return domain.Pagination{}, err
 	}
@@ -59,3 +94,48 @@ func (s *paginationService) GetPaginationInfo(
 
 	return pagination, nil
 }
+
+func (s *paginationService) recordsCount(
+	ctx context.Context,
+	dataset domain.Dataset,
+	filter domain.DatasetFilter,
+) (int, error) {
+	key := countKey{dataset: dataset}
+	if !filter.TimeRange.IsAllTime() {
+		key.from = filter.TimeRange.From.Unix()
+		key.to = filter.TimeRange.To.Unix()
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.counts[key]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.count, nil
+	}
+
+	recordsCount, err := s.paginationRepo.GetRecordsCount(ctx, dataset, filter)
+	if err != nil {
+		return 0, err
+	}
+	if s.countTTL <= 0 {
+		return recordsCount, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.counts) >= maxCachedCounts {
+		for k, c := range s.counts {
+			if !now.Before(c.expiresAt) {
+				delete(s.counts, k)
+			}
+		}
+		// Every count is fresh, the cache starts over rather than growing
+		if len(s.counts) >= maxCachedCounts {
+			clear(s.counts)
+		}
+	}
+	s.counts[key] = cachedCount{count: recordsCount, expiresAt: now.Add(s.countTTL)}
+
+	return recordsCount, nil
+}
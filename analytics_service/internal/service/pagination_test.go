@@ -1,23 +1,30 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"analytics_service/internal/domain"
 	"analytics_service/internal/repository"
 	"analytics_service/internal/repository/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetPaginationInfo(t *testing.T) {
-	tableName := "table"
 	errRowsCnt := errors.New("errors while getting cont")
+	testRange := domain.TimeRange{
+		From: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC),
+	}
 
 	testCases := []struct {
 		name                string
 		buildPaginationRepo func() repository.PaginationRepo
 		paginationParams    domain.PaginationParams
+		timeRange           domain.TimeRange
 		expectedPagination  domain.Pagination
 		expectedErr         error
 	}{
@@ -26,7 +33,7 @@ func TestGetPaginationInfo(t *testing.T) {
 			buildPaginationRepo: func() repository.PaginationRepo {
 				mockRepo := mocks.NewPaginationRepo(t)
 
-				mockRepo.On("GetRecordsCount", tableName).
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
 					Return(100, nil)
 
 				return mockRepo
@@ -46,7 +53,7 @@ func TestGetPaginationInfo(t *testing.T) {
 			buildPaginationRepo: func() repository.PaginationRepo {
 				mockRepo := mocks.NewPaginationRepo(t)
 
-				mockRepo.On("GetRecordsCount", tableName).
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
 					Return(100, nil)
 
 				return mockRepo
@@ -66,7 +73,7 @@ func TestGetPaginationInfo(t *testing.T) {
 			buildPaginationRepo: func() repository.PaginationRepo {
 				mockRepo := mocks.NewPaginationRepo(t)
 
-				mockRepo.On("GetRecordsCount", tableName).
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
 					Return(100, nil)
 
 				return mockRepo
@@ -86,7 +93,7 @@ func TestGetPaginationInfo(t *testing.T) {
 			buildPaginationRepo: func() repository.PaginationRepo {
 				mockRepo := mocks.NewPaginationRepo(t)
 
-				mockRepo.On("GetRecordsCount", tableName).
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
 					Return(100, nil)
 
 				return mockRepo
@@ -106,7 +113,7 @@ func TestGetPaginationInfo(t *testing.T) {
 			buildPaginationRepo: func() repository.PaginationRepo {
 				mockRepo := mocks.NewPaginationRepo(t)
 
-				mockRepo.On("GetRecordsCount", tableName).
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
 					Return(101, nil)
 
 				return mockRepo
@@ -126,7 +133,7 @@ func TestGetPaginationInfo(t *testing.T) {
 			buildPaginationRepo: func() repository.PaginationRepo {
 				mockRepo := mocks.NewPaginationRepo(t)
 
-				mockRepo.On("GetRecordsCount", tableName).
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
 					Return(100, nil)
 
 				return mockRepo
@@ -146,7 +153,7 @@ func TestGetPaginationInfo(t *testing.T) {
 			buildPaginationRepo: func() repository.PaginationRepo {
 				mockRepo := mocks.NewPaginationRepo(t)
 
-				mockRepo.On("GetRecordsCount", tableName).
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
 					Return(0, errRowsCnt)
 
 				return mockRepo
@@ -155,16 +162,80 @@ func TestGetPaginationInfo(t *testing.T) {
 			expectedPagination: domain.Pagination{},
 			expectedErr:        errRowsCnt,
 		},
+		{
+			name: "15 records in time range",
+			buildPaginationRepo: func() repository.PaginationRepo {
+				mockRepo := mocks.NewPaginationRepo(t)
+
+				mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{TimeRange: testRange}).
+					Return(15, nil)
+
+				return mockRepo
+			},
+			paginationParams: domain.PaginationParams{Page: 1, Limit: 10},
+			timeRange:        testRange,
+			expectedPagination: domain.Pagination{
+				Next:          2,
+				Previous:      0,
+				RecordPerPage: 10,
+				CurrentPage:   1,
+				TotalPage:     2,
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			paginationRepo := tc.buildPaginationRepo()
-			paginationService := NewPaginationService(paginationRepo)
-
-			pagination, err := paginationService.GetPaginationInfo(tableName, tc.paginationParams)
+			paginationService := NewPaginationService(paginationRepo, 0)
+
+			pagination, err := paginationService.GetPaginationInfo(
+				context.Background(),
+				domain.DatasetTopURLs,
+				domain.DatasetFilter{TimeRange: tc.timeRange},
+				tc.paginationParams,
+			)
 			assert.Equal(t, tc.expectedErr, err)
 			assert.Equal(t, tc.expectedPagination, pagination)
 		})
 	}
 }
+
+func TestGetPaginationInfoCachesCounts(t *testing.T) {
+	testRange := domain.TimeRange{
+		From: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC),
+	}
+	paginationParams := domain.PaginationParams{Page: 1, Limit: 10}
+
+	mockRepo := mocks.NewPaginationRepo(t)
+	mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}).
+		Return(100, nil).
+		Once()
+	mockRepo.On("GetRecordsCount", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{TimeRange: testRange}).
+		Return(15, nil).
+		Once()
+
+	paginationService := NewPaginationService(mockRepo, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		pagination, err := paginationService.GetPaginationInfo(
+			context.Background(),
+			domain.DatasetTopURLs,
+			domain.DatasetFilter{},
+			paginationParams,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, pagination.TotalPage)
+
+		pagination, err = paginationService.GetPaginationInfo(
+			context.Background(),
+			domain.DatasetTopURLs,
+			domain.DatasetFilter{TimeRange: testRange},
+			paginationParams,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, pagination.TotalPage)
+	}
+}
@@ -2,26 +2,28 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
 
 	"analytics_service/internal/converter"
 	"analytics_service/internal/domain"
+	"analytics_service/internal/errs"
+	"analytics_service/internal/principal"
 	"analytics_service/internal/service"
 	analytics "analytics_service/pkg/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-const (
-	urlEventsCounterTableName = "url_events_counter"
-)
-
 type AnalyticsServer struct {
 	logger              *slog.Logger
 	analyticsService    service.AnalyticsService
 	paginationService   service.PaginationService
 	topURLConverter     converter.TopURLConverter
 	paginationConverter converter.PaginationConverter
+	urlStatsConverter   converter.URLStatsConverter
+	breakdownConverter  converter.BreakdownConverter
 	analytics.UnimplementedAnalyticsServer
 }
 
@@ -31,6 +33,8 @@ func NewAnalyticsServer(
 	paginationService service.PaginationService,
 	topURLConverter converter.TopURLConverter,
 	paginationConverter converter.PaginationConverter,
+	urlStatsConverter converter.URLStatsConverter,
+	breakdownConverter converter.BreakdownConverter,
 ) *AnalyticsServer {
 	return &AnalyticsServer{
 		logger:              logger,
@@ -38,6 +42,8 @@ func NewAnalyticsServer(
 		paginationService:   paginationService,
 		topURLConverter:     topURLConverter,
 		paginationConverter: paginationConverter,
+		urlStatsConverter:   urlStatsConverter,
+		breakdownConverter:  breakdownConverter,
 	}
 }
 
@@ -50,18 +56,36 @@ func (s *AnalyticsServer) GetTopUrls(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if req.Page == 0 {
+		return s.getTopUrlsByCursor(ctx, req)
+	}
+	if req.Cursor != "" {
+		return nil, status.Error(codes.InvalidArgument, errs.ErrPageWithCursor.Error())
+	}
+
 	paginationParams := domain.PaginationParams{
 		Page:  int(req.Page),
 		Limit: int(req.Limit),
 	}
 
-	topUrls, err := s.analyticsService.GetTopUrls(ctx, paginationParams)
+	timeRange, err := s.topURLsTimeRange(req, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ranking := s.topURLConverter.MapRankingPbToDomain(req.Ranking)
+	topUrls, err := s.analyticsService.GetTopUrls(ctx, paginationParams, ranking, timeRange)
 	if err != nil {
 		s.logger.Error(err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	pagination, err := s.paginationService.GetPaginationInfo(urlEventsCounterTableName, paginationParams)
+	pagination, err := s.paginationService.GetPaginationInfo(
+		ctx,
+		domain.DatasetTopURLs,
+		domain.DatasetFilter{TimeRange: timeRange},
+		paginationParams,
+	)
 	if err != nil {
 		s.logger.Error(err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
@@ -72,3 +96,175 @@ func (s *AnalyticsServer) GetTopUrls(
 		Pagination: s.paginationConverter.MapDomainToPb(pagination),
 	}, nil
 }
+
+// getTopUrlsByCursor pages over a snapshot of whole hours taken on the first page,
+// urls do not shift between pages as events stream in and nothing is counted twice.
+// All time is not a snapshot, see topURLsSnapshot
+func (s *AnalyticsServer) getTopUrlsByCursor(
+	ctx context.Context,
+	req *analytics.TopUrlsRequest,
+) (*analytics.TopUrlsResponse, error) {
+	var cursor domain.TopURLsCursor
+	var err error
+	if req.Cursor == "" {
+		cursor, err = s.topURLsSnapshot(req, time.Now())
+	} else {
+		cursor, err = s.topURLConverter.MapCursorTokenToDomain(req.Cursor)
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	page, err := s.analyticsService.GetTopUrlsPage(ctx, cursor, int(req.Limit))
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &analytics.TopUrlsResponse{
+		TopUrlData: s.topURLConverter.MapSliceDomainToPb(page.TopURLData),
+	}
+	if page.Next != nil {
+		resp.NextCursor = s.topURLConverter.MapCursorDomainToToken(*page.Next)
+	}
+
+	return resp, nil
+}
+
+// topURLsSnapshot is the cursor of the first page. The hour in progress still receives
+// events, so the range of the request is cut at its start.
+// All time is paged over the live counters instead, merging the whole rollup for every page
+// costs too much. Its pages are fresh but not consistent: a url whose counts change
+// while the listing is paged may be skipped or returned twice.
+func (s *AnalyticsServer) topURLsSnapshot(req *analytics.TopUrlsRequest, now time.Time) (domain.TopURLsCursor, error) {
+	timeRange, err := s.topURLsTimeRange(req, now)
+	if err != nil {
+		return domain.TopURLsCursor{}, err
+	}
+
+	snapshotEnd := now.UTC().Truncate(domain.TopURLsBucket)
+	if !timeRange.IsAllTime() && timeRange.To.After(snapshotEnd) {
+		timeRange.To = snapshotEnd
+	}
+
+	return domain.TopURLsCursor{
+		Ranking:   s.topURLConverter.MapRankingPbToDomain(req.Ranking),
+		TimeRange: timeRange,
+	}, nil
+}
+
+// topURLsTimeRange resolves the window or the custom range of the request,
+// both are aligned to buckets of the rollup
+func (s *AnalyticsServer) topURLsTimeRange(req *analytics.TopUrlsRequest, now time.Time) (domain.TimeRange, error) {
+	if req.From == nil && req.To == nil {
+		window := s.topURLConverter.MapWindowPbToDomain(req.Window)
+		if window == domain.WindowAllTime {
+			return domain.TimeRange{}, nil
+		}
+		timeRange := domain.TimeRange{From: now.Add(-window.Duration()), To: now}
+		return timeRange.Align(domain.TopURLsBucket), nil
+	}
+
+	if req.Window != analytics.Window_WINDOW_ALL_TIME {
+		return domain.TimeRange{}, errs.ErrWindowWithRange
+	}
+	if req.From == nil {
+		return domain.TimeRange{}, errs.ErrNoRangeStart
+	}
+	timeRange := domain.TimeRange{From: req.From.AsTime(), To: now}
+	if req.To != nil {
+		timeRange.To = req.To.AsTime()
+	}
+	if !timeRange.From.Before(timeRange.To) {
+		return domain.TimeRange{}, errs.ErrInvalidTimeRange
+	}
+
+	return timeRange.Align(domain.TopURLsBucket), nil
+}
+
+func (s *AnalyticsServer) GetUrlStats(
+	ctx context.Context,
+	req *analytics.UrlStatsRequest,
+) (*analytics.UrlStatsResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ownerID, err := ownerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.analyticsService.GetURLStats(ctx, domain.URLStatsParams{
+		ShortURL:    req.ShortUrl,
+		OwnerID:     ownerID,
+		From:        req.From.AsTime(),
+		To:          req.To.AsTime(),
+		Granularity: s.urlStatsConverter.MapGranularityPbToDomain(req.Granularity),
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidTimeRange) || errors.Is(err, errs.ErrTooManyBuckets) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, errs.ErrNoURL) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, errs.ErrNotURLOwner) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return s.urlStatsConverter.MapDomainToPb(stats), nil
+}
+
+func (s *AnalyticsServer) GetUrlBreakdown(
+	ctx context.Context,
+	req *analytics.UrlBreakdownRequest,
+) (*analytics.UrlBreakdownResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ownerID, err := ownerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown, err := s.analyticsService.GetURLBreakdown(ctx, domain.URLBreakdownParams{
+		ShortURL:  req.ShortUrl,
+		OwnerID:   ownerID,
+		Dimension: s.breakdownConverter.MapDimensionPbToDomain(req.Dimension),
+		From:      req.From.AsTime(),
+		To:        req.To.AsTime(),
+		Limit:     int(req.Limit),
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidTimeRange) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, errs.ErrNoURL) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, errs.ErrNotURLOwner) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return s.breakdownConverter.MapDomainToPb(breakdown), nil
+}
+
+// ownerIDFromContext returns the caller forwarded by the api gateway, stats of a url are not public
+func ownerIDFromContext(ctx context.Context) (string, error) {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "caller is not identified")
+	}
+
+	return p.OwnerID, nil
+}
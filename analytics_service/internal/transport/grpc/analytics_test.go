@@ -8,9 +8,12 @@ import (
 	"net"
 	"os"
 	"testing"
+	"time"
 
 	"analytics_service/internal/converter"
 	"analytics_service/internal/domain"
+	"analytics_service/internal/errs"
+	"analytics_service/internal/principal"
 	"analytics_service/internal/service"
 	"analytics_service/internal/service/mocks"
 	analytics "analytics_service/pkg/proto"
@@ -19,8 +22,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func initAnalyticsClient(
@@ -33,6 +38,8 @@ func initAnalyticsClient(
 
 	topUrlConverter := converter.NewTopURLConverter()
 	paginationConverter := converter.NewPaginationConverter()
+	urlStatsConverter := converter.NewURLStatsConverter()
+	breakdownConverter := converter.NewBreakdownConverter()
 
 	analyticsServer := NewAnalyticsServer(
 		logger,
@@ -40,9 +47,13 @@ func initAnalyticsClient(
 		paginationService,
 		topUrlConverter,
 		paginationConverter,
+		urlStatsConverter,
+		breakdownConverter,
 	)
 
-	baseServer := grpc.NewServer()
+	baseServer := grpc.NewServer(
+		grpc.UnaryInterceptor(principal.UnaryServerInterceptor),
+	)
 
 	analytics.RegisterAnalyticsServer(baseServer, analyticsServer)
 	go func() {
@@ -83,13 +94,13 @@ func TestGetTopUrls(t *testing.T) {
 	testPaginationParamsReq := &analytics.TopUrlsRequest{Page: 1, Limit: 3}
 
 	testTopUrls := []domain.TopURLData{
-		{LongURL: "http://test.long1", ShortURL: "test", FollowCount: 10, CreateCount: 1},
+		{LongURL: "http://test.long1", ShortURL: "test", FollowCount: 10, CreateCount: 1, UniqueFollowCount: 7},
 		{LongURL: "http://test.long2", ShortURL: "test2", FollowCount: 20, CreateCount: 2},
 		{LongURL: "http://test.long3", ShortURL: "tes3", FollowCount: 30, CreateCount: 3},
 	}
 
 	testTopUrlsResp := []*analytics.TopUrlData{
-		{LongUrl: "http://test.long1", ShortUrl: "test", FollowCount: 10, CreateCount: 1},
+		{LongUrl: "http://test.long1", ShortUrl: "test", FollowCount: 10, CreateCount: 1, UniqueFollowCount: 7},
 		{LongUrl: "http://test.long2", ShortUrl: "test2", FollowCount: 20, CreateCount: 2},
 		{LongUrl: "http://test.long3", ShortUrl: "tes3", FollowCount: 30, CreateCount: 3},
 	}
@@ -101,6 +112,28 @@ func TestGetTopUrls(t *testing.T) {
 		Next: 2, RecordPerPage: 3, CurrentPage: 1, TotalPage: 10,
 	}
 
+	testAlignedRange := domain.TimeRange{
+		From: time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.May, 1, 14, 0, 0, 0, time.UTC),
+	}
+	// The last day is extended to whole hours, so it spans 24 or 25 of them
+	isLastDay := func(timeRange domain.TimeRange) bool {
+		length := timeRange.To.Sub(timeRange.From)
+		return timeRange.From.Minute() == 0 && length >= 24*time.Hour && length <= 25*time.Hour
+	}
+
+	// All time is paged over the live counters without a snapshot
+	isAllTimeCursor := func(cursor domain.TopURLsCursor) bool {
+		return cursor.After == nil && cursor.TimeRange.IsAllTime()
+	}
+	testCursor := domain.TopURLsCursor{
+		Ranking:   domain.RankingFollows,
+		TimeRange: testAlignedRange,
+		After:     &domain.TopURLsKey{Primary: 30, Secondary: 3, ShortURL: "tes3", LongURL: "http://test.long3"},
+	}
+	topURLConverter := converter.NewTopURLConverter()
+	testCursorToken := topURLConverter.MapCursorDomainToToken(testCursor)
+
 	testErr := errors.New("test error")
 
 	testCases := []struct {
@@ -116,14 +149,14 @@ func TestGetTopUrls(t *testing.T) {
 			name: "test get top urls without error",
 			buildAnalyticsService: func() service.AnalyticsService {
 				mockService := mocks.NewAnalyticsService(t)
-				mockService.On("GetTopUrls", mock.Anything, mock.Anything).
+				mockService.On("GetTopUrls", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(testTopUrls, nil)
 
 				return mockService
 			},
 			buildPaginationService: func() service.PaginationService {
 				mockService := mocks.NewPaginationService(t)
-				mockService.On("GetPaginationInfo", mock.Anything, testPaginationParams).
+				mockService.On("GetPaginationInfo", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}, testPaginationParams).
 					Return(testPagination, nil)
 
 				return mockService
@@ -137,7 +170,86 @@ func TestGetTopUrls(t *testing.T) {
 			expectedCode:  codes.OK,
 		},
 		{
-			name: "Given empty page should return error. 3 Invalid Argument",
+			name: "test get top urls ranked by unique follows",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetTopUrls", mock.Anything, testPaginationParams, domain.RankingUniqueFollows, domain.TimeRange{}).
+					Return(testTopUrls, nil)
+
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				mockService.On("GetPaginationInfo", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{}, testPaginationParams).
+					Return(testPagination, nil)
+
+				return mockService
+			},
+			request: &analytics.TopUrlsRequest{Page: 1, Limit: 3, Ranking: analytics.Ranking_RANKING_UNIQUE_FOLLOWS},
+			expectedResp: &analytics.TopUrlsResponse{
+				TopUrlData: testTopUrlsResp,
+				Pagination: testPaginationResp,
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "test get top urls of the last day",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetTopUrls", mock.Anything, testPaginationParams, domain.RankingFollows, mock.MatchedBy(isLastDay)).
+					Return(testTopUrls, nil)
+
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				mockService.On("GetPaginationInfo", mock.Anything, domain.DatasetTopURLs, mock.MatchedBy(func(filter domain.DatasetFilter) bool {
+					return isLastDay(filter.TimeRange)
+				}), testPaginationParams).
+					Return(testPagination, nil)
+
+				return mockService
+			},
+			request: &analytics.TopUrlsRequest{Page: 1, Limit: 3, Window: analytics.Window_WINDOW_LAST_DAY},
+			expectedResp: &analytics.TopUrlsResponse{
+				TopUrlData: testTopUrlsResp,
+				Pagination: testPaginationResp,
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "test get top urls of custom range aligned to hours",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetTopUrls", mock.Anything, testPaginationParams, domain.RankingFollows, testAlignedRange).
+					Return(testTopUrls, nil)
+
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				mockService.On("GetPaginationInfo", mock.Anything, domain.DatasetTopURLs, domain.DatasetFilter{TimeRange: testAlignedRange}, testPaginationParams).
+					Return(testPagination, nil)
+
+				return mockService
+			},
+			request: &analytics.TopUrlsRequest{
+				Page:  1,
+				Limit: 3,
+				From:  timestamppb.New(testAlignedRange.From.Add(30 * time.Minute)),
+				To:    timestamppb.New(testAlignedRange.To.Add(-30 * time.Minute)),
+			},
+			expectedResp: &analytics.TopUrlsResponse{
+				TopUrlData: testTopUrlsResp,
+				Pagination: testPaginationResp,
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "Given window with custom range should return error. 3 Invalid Argument",
 			buildAnalyticsService: func() service.AnalyticsService {
 				mockService := mocks.NewAnalyticsService(t)
 				return mockService
@@ -146,7 +258,123 @@ func TestGetTopUrls(t *testing.T) {
 				mockService := mocks.NewPaginationService(t)
 				return mockService
 			},
-			request:       &analytics.TopUrlsRequest{Page: 0, Limit: 10},
+			request: &analytics.TopUrlsRequest{
+				Page:   1,
+				Limit:  10,
+				Window: analytics.Window_WINDOW_LAST_WEEK,
+				From:   timestamppb.New(testAlignedRange.From),
+			},
+			expectedResp:  &analytics.TopUrlsResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "Given from after to should return error. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				return mockService
+			},
+			request: &analytics.TopUrlsRequest{
+				Page:  1,
+				Limit: 10,
+				From:  timestamppb.New(testAlignedRange.To),
+				To:    timestamppb.New(testAlignedRange.From),
+			},
+			expectedResp:  &analytics.TopUrlsResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "Given unknown ranking should return error. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				return mockService
+			},
+			request:       &analytics.TopUrlsRequest{Page: 1, Limit: 10, Ranking: 5},
+			expectedResp:  &analytics.TopUrlsResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "test get first page of top urls by cursor",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetTopUrlsPage", mock.Anything, mock.MatchedBy(isAllTimeCursor), 3).
+					Return(domain.TopURLsPage{TopURLData: testTopUrls, Next: &testCursor}, nil)
+
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				return mockService
+			},
+			request: &analytics.TopUrlsRequest{Page: 0, Limit: 3},
+			expectedResp: &analytics.TopUrlsResponse{
+				TopUrlData: testTopUrlsResp,
+				NextCursor: testCursorToken,
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "test get last page of top urls by cursor",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetTopUrlsPage", mock.Anything, testCursor, 3).
+					Return(domain.TopURLsPage{TopURLData: testTopUrls}, nil)
+
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				return mockService
+			},
+			request: &analytics.TopUrlsRequest{
+				Limit:   3,
+				Ranking: analytics.Ranking_RANKING_FOLLOWS,
+				Window:  analytics.Window_WINDOW_LAST_HOUR,
+				Cursor:  testCursorToken,
+			},
+			expectedResp: &analytics.TopUrlsResponse{
+				TopUrlData: testTopUrlsResp,
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "Given malformed cursor should return error. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				return mockService
+			},
+			request:       &analytics.TopUrlsRequest{Limit: 10, Cursor: "not a cursor"},
+			expectedResp:  &analytics.TopUrlsResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "Given page with cursor should return error. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				return mockService
+			},
+			buildPaginationService: func() service.PaginationService {
+				mockService := mocks.NewPaginationService(t)
+				return mockService
+			},
+			request:       &analytics.TopUrlsRequest{Page: 2, Limit: 10, Cursor: testCursorToken},
 			expectedResp:  &analytics.TopUrlsResponse{},
 			isErrExpected: true,
 			expectedCode:  codes.InvalidArgument,
@@ -170,7 +398,7 @@ func TestGetTopUrls(t *testing.T) {
 			name: "internal error when get top urls. 13 Internal",
 			buildAnalyticsService: func() service.AnalyticsService {
 				mockService := mocks.NewAnalyticsService(t)
-				mockService.On("GetTopUrls", mock.Anything, mock.Anything).
+				mockService.On("GetTopUrls", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, testErr)
 
 				return mockService
@@ -188,14 +416,14 @@ func TestGetTopUrls(t *testing.T) {
 			name: "internal error when get pagination. 13 Internal",
 			buildAnalyticsService: func() service.AnalyticsService {
 				mockService := mocks.NewAnalyticsService(t)
-				mockService.On("GetTopUrls", mock.Anything, mock.Anything).
+				mockService.On("GetTopUrls", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(testTopUrls, nil)
 
 				return mockService
 			},
 			buildPaginationService: func() service.PaginationService {
 				mockService := mocks.NewPaginationService(t)
-				mockService.On("GetPaginationInfo", mock.Anything, mock.Anything).
+				mockService.On("GetPaginationInfo", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(domain.Pagination{}, testErr)
 
 				return mockService
@@ -242,13 +470,319 @@ func TestGetTopUrls(t *testing.T) {
 				assert.Equal(t, expectedData.ShortUrl, actualData.ShortUrl)
 				assert.Equal(t, expectedData.FollowCount, actualData.FollowCount)
 				assert.Equal(t, expectedData.CreateCount, actualData.CreateCount)
+				assert.Equal(t, expectedData.UniqueFollowCount, actualData.UniqueFollowCount)
+			}
+
+			assert.Equal(t, tc.expectedResp.NextCursor, resp.NextCursor)
+			assert.Equal(t, tc.expectedResp.Pagination.GetTotalPage(), resp.Pagination.GetTotalPage())
+			assert.Equal(t, tc.expectedResp.Pagination.GetNext(), resp.Pagination.GetNext())
+			assert.Equal(t, tc.expectedResp.Pagination.GetCurrentPage(), resp.Pagination.GetCurrentPage())
+			assert.Equal(t, tc.expectedResp.Pagination.GetRecordPerPage(), resp.Pagination.GetRecordPerPage())
+			assert.Equal(t, tc.expectedResp.Pagination.GetPrevious(), resp.Pagination.GetPrevious())
+		})
+	}
+}
+
+func TestGetUrlStats(t *testing.T) {
+	testFrom := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	testTo := testFrom.Add(2 * time.Hour)
+	testStats := domain.URLStats{
+		ShortURL:    "test",
+		Granularity: domain.GranularityHour,
+		Buckets: []domain.URLStatsBucket{
+			{Start: testFrom, FollowCount: 3, UniqueFollowCount: 2},
+			{Start: testFrom.Add(time.Hour), FollowCount: 0},
+		},
+		TotalFollowCount:       3,
+		TotalUniqueFollowCount: 2,
+	}
+	validRequest := &analytics.UrlStatsRequest{
+		ShortUrl:    "test",
+		From:        timestamppb.New(testFrom),
+		To:          timestamppb.New(testTo),
+		Granularity: analytics.Granularity_GRANULARITY_HOUR,
+	}
+
+	testCases := []struct {
+		name                  string
+		buildAnalyticsService func() service.AnalyticsService
+		request               *analytics.UrlStatsRequest
+		anonymous             bool
+		expectedCode          codes.Code
+	}{
+		{
+			name: "get url stats without error. 0 OK",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLStats", mock.Anything, domain.URLStatsParams{
+					ShortURL:    "test",
+					OwnerID:     "owner",
+					From:        testFrom,
+					To:          testTo,
+					Granularity: domain.GranularityHour,
+				}).
+					Return(testStats, nil)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.OK,
+		},
+		{
+			name: "unspecified granularity. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				return mocks.NewAnalyticsService(t)
+			},
+			request: &analytics.UrlStatsRequest{
+				ShortUrl: "test",
+				From:     timestamppb.New(testFrom),
+				To:       timestamppb.New(testTo),
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "range has too many buckets. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(domain.URLStats{}, errs.ErrTooManyBuckets)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "anonymous caller. 16 Unauthenticated",
+			buildAnalyticsService: func() service.AnalyticsService {
+				return mocks.NewAnalyticsService(t)
+			},
+			request:      validRequest,
+			anonymous:    true,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name: "url of another owner. 7 Permission Denied",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(domain.URLStats{}, errs.ErrNotURLOwner)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "url not found. 5 Not Found",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(domain.URLStats{}, errs.ErrNoURL)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "internal error when get url stats. 13 Internal",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(domain.URLStats{}, errors.New("test error"))
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			analyticsClient, cancel := initAnalyticsClient(
+				logger,
+				tc.buildAnalyticsService(),
+				mocks.NewPaginationService(t),
+			)
+			defer cancel()
+
+			ctx := context.Background()
+			if !tc.anonymous {
+				ctx = metadata.AppendToOutgoingContext(ctx, principal.OwnerIDMetadataKey, "owner")
+			}
+
+			resp, err := analyticsClient.GetUrlStats(ctx, tc.request)
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode != codes.OK {
+				return
+			}
+
+			assert.Equal(t, "test", resp.ShortUrl)
+			assert.Equal(t, analytics.Granularity_GRANULARITY_HOUR, resp.Granularity)
+			assert.Equal(t, int64(3), resp.TotalFollowCount)
+			assert.Equal(t, int64(2), resp.TotalUniqueFollowCount)
+			assert.Equal(t, int64(2), resp.Buckets[0].UniqueFollowCount)
+			assert.Len(t, resp.Buckets, 2)
+			assert.Equal(t, testFrom.Add(time.Hour), resp.Buckets[1].Start.AsTime())
+			assert.Equal(t, int64(0), resp.Buckets[1].FollowCount)
+		})
+	}
+}
+
+func TestGetUrlBreakdown(t *testing.T) {
+	testFrom := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	testTo := testFrom.Add(24 * time.Hour)
+	testBreakdown := domain.URLBreakdown{
+		ShortURL:  "test",
+		Dimension: domain.DimensionCountry,
+		Values: []domain.BreakdownValue{
+			{Value: "DE", FollowCount: 3},
+		},
+		OtherFollowCount: 2,
+		TotalFollowCount: 5,
+	}
+	validRequest := &analytics.UrlBreakdownRequest{
+		ShortUrl:  "test",
+		Dimension: analytics.Dimension_DIMENSION_COUNTRY,
+		From:      timestamppb.New(testFrom),
+		To:        timestamppb.New(testTo),
+		Limit:     1,
+	}
+
+	testCases := []struct {
+		name                  string
+		buildAnalyticsService func() service.AnalyticsService
+		request               *analytics.UrlBreakdownRequest
+		anonymous             bool
+		expectedCode          codes.Code
+	}{
+		{
+			name: "get url breakdown without error. 0 OK",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLBreakdown", mock.Anything, domain.URLBreakdownParams{
+					ShortURL:  "test",
+					OwnerID:   "owner",
+					Dimension: domain.DimensionCountry,
+					From:      testFrom,
+					To:        testTo,
+					Limit:     1,
+				}).
+					Return(testBreakdown, nil)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.OK,
+		},
+		{
+			name: "limit is too big. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				return mocks.NewAnalyticsService(t)
+			},
+			request: &analytics.UrlBreakdownRequest{
+				ShortUrl:  "test",
+				Dimension: analytics.Dimension_DIMENSION_COUNTRY,
+				From:      timestamppb.New(testFrom),
+				To:        timestamppb.New(testTo),
+				Limit:     1000,
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "from after to. 3 Invalid Argument",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLBreakdown", mock.Anything, mock.Anything).
+					Return(domain.URLBreakdown{}, errs.ErrInvalidTimeRange)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "anonymous caller. 16 Unauthenticated",
+			buildAnalyticsService: func() service.AnalyticsService {
+				return mocks.NewAnalyticsService(t)
+			},
+			request:      validRequest,
+			anonymous:    true,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name: "url of another owner. 7 Permission Denied",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLBreakdown", mock.Anything, mock.Anything).
+					Return(domain.URLBreakdown{}, errs.ErrNotURLOwner)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "url not found. 5 Not Found",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLBreakdown", mock.Anything, mock.Anything).
+					Return(domain.URLBreakdown{}, errs.ErrNoURL)
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "internal error when get url breakdown. 13 Internal",
+			buildAnalyticsService: func() service.AnalyticsService {
+				mockService := mocks.NewAnalyticsService(t)
+				mockService.On("GetURLBreakdown", mock.Anything, mock.Anything).
+					Return(domain.URLBreakdown{}, errors.New("test error"))
+
+				return mockService
+			},
+			request:      validRequest,
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			analyticsClient, cancel := initAnalyticsClient(
+				logger,
+				tc.buildAnalyticsService(),
+				mocks.NewPaginationService(t),
+			)
+			defer cancel()
+
+			ctx := context.Background()
+			if !tc.anonymous {
+				ctx = metadata.AppendToOutgoingContext(ctx, principal.OwnerIDMetadataKey, "owner")
+			}
+
+			resp, err := analyticsClient.GetUrlBreakdown(ctx, tc.request)
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode != codes.OK {
+				return
 			}
 
-			assert.Equal(t, tc.expectedResp.Pagination.TotalPage, resp.Pagination.TotalPage)
-			assert.Equal(t, tc.expectedResp.Pagination.Next, resp.Pagination.Next)
-			assert.Equal(t, tc.expectedResp.Pagination.CurrentPage, resp.Pagination.CurrentPage)
-			assert.Equal(t, tc.expectedResp.Pagination.RecordPerPage, resp.Pagination.RecordPerPage)
-			assert.Equal(t, tc.expectedResp.Pagination.Previous, resp.Pagination.Previous)
+			assert.Equal(t, analytics.Dimension_DIMENSION_COUNTRY, resp.Dimension)
+			assert.Len(t, resp.Values, 1)
+			assert.Equal(t, "DE", resp.Values[0].Value)
+			assert.Equal(t, int64(2), resp.OtherFollowCount)
+			assert.Equal(t, int64(5), resp.TotalFollowCount)
 		})
 	}
 }
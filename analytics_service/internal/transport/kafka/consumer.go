@@ -0,0 +1,240 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"analytics_service/internal/converter"
+	"analytics_service/internal/domain"
+	"analytics_service/internal/service"
+	"github.com/IBM/sarama"
+)
+
+// Headers of the url_shortener_service producer and of dead letters
+const (
+	headerContentType = "content-type"
+
+	headerDeadLetterError     = "dead-letter-error"
+	headerDeadLetterTopic     = "dead-letter-topic"
+	headerDeadLetterPartition = "dead-letter-partition"
+	headerDeadLetterOffset    = "dead-letter-offset"
+)
+
+const rejoinBackoff = time.Second
+
+type ConsumerConfig struct {
+	Topic string
+	Group string
+	// DeadLetterTopic receives messages that can not be decoded or are invalid
+	DeadLetterTopic string
+	// InitialOffset is sarama.OffsetOldest or sarama.OffsetNewest, it is used by a group
+	// without committed offsets only
+	InitialOffset int64
+	// BatchSize and FlushInterval trigger storing of a batch, whichever comes first
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// Consumer stores url events of its partitions in batches. Offsets are marked
+// after a batch is stored, so an event is stored at least once
+type Consumer struct {
+	logger         *slog.Logger
+	group          sarama.ConsumerGroup
+	deadLetters    sarama.SyncProducer
+	ingestService  service.IngestService
+	eventConverter converter.URLEventConverter
+	cfg            ConsumerConfig
+}
+
+func NewConsumer(
+	logger *slog.Logger,
+	addrs []string,
+	ingestService service.IngestService,
+	eventConverter converter.URLEventConverter,
+	cfg ConsumerConfig,
+) (*Consumer, error) {
+	kafkaCfg := sarama.NewConfig()
+	kafkaCfg.Consumer.Offsets.Initial = cfg.InitialOffset
+	kafkaCfg.Producer.Return.Successes = true
+	kafkaCfg.Producer.RequiredAcks = sarama.WaitForAll
+
+	group, err := sarama.NewConsumerGroup(addrs, cfg.Group, kafkaCfg)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := sarama.NewSyncProducer(addrs, kafkaCfg)
+	if err != nil {
+		_ = group.Close()
+		return nil, err
+	}
+
+	return newConsumer(logger, group, deadLetters, ingestService, eventConverter, cfg), nil
+}
+
+func newConsumer(
+	logger *slog.Logger,
+	group sarama.ConsumerGroup,
+	deadLetters sarama.SyncProducer,
+	ingestService service.IngestService,
+	eventConverter converter.URLEventConverter,
+	cfg ConsumerConfig,
+) *Consumer {
+	return &Consumer{
+		logger:         logger,
+		group:          group,
+		deadLetters:    deadLetters,
+		ingestService:  ingestService,
+		eventConverter: eventConverter,
+		cfg:            cfg,
+	}
+}
+
+// Run consumes until ctx is done, the group is joined again after every rebalance
+func (c *Consumer) Run(ctx context.Context) {
+	for {
+		err := c.group.Consume(ctx, []string{c.cfg.Topic}, c)
+		if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+			return
+		}
+		if err != nil {
+			c.logger.Error("consume url events", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rejoinBackoff):
+		}
+	}
+}
+
+func (c *Consumer) Close() error {
+	return errors.Join(c.group.Close(), c.deadLetters.Close())
+}
+
+func (c *Consumer) Setup(session sarama.ConsumerGroupSession) error {
+	c.logger.Info("url events partitions assigned", slog.Any("claims", session.Claims()))
+	return nil
+}
+
+func (c *Consumer) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim does not fetch while a batch is stored, a slow sink makes the claim lag
+// behind instead of buffering events in memory. An unstored batch is consumed again
+// by the owner of the partition after a rebalance
+func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	c.logger.Info(
+		"consume url events",
+		slog.Int("partition", int(claim.Partition())),
+		slog.Int64("offset", claim.InitialOffset()),
+	)
+
+	ctx := session.Context()
+	ticker := time.NewTicker(c.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.URLEvent, 0, c.cfg.BatchSize)
+	var lastMsg *sarama.ConsumerMessage
+	flush := func() error {
+		if lastMsg == nil {
+			return nil
+		}
+
+		err := c.ingestService.Store(ctx, batch)
+		if err != nil {
+			return err
+		}
+		session.MarkMessage(lastMsg, "")
+		batch = batch[:0]
+		lastMsg = nil
+		return nil
+	}
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return flush()
+			}
+
+			event, err := c.prepare(msg)
+			if err != nil {
+				err = c.sendDeadLetter(msg, err)
+				if err != nil {
+					return err
+				}
+			} else {
+				batch = append(batch, event)
+			}
+			lastMsg = msg
+
+			if len(batch) >= c.cfg.BatchSize {
+				err = flush()
+				if err != nil {
+					return err
+				}
+			}
+		case <-ticker.C:
+			err := flush()
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Consumer) prepare(msg *sarama.ConsumerMessage) (domain.URLEvent, error) {
+	event, err := c.eventConverter.Decode(headerValue(msg, headerContentType), msg.Value)
+	if err != nil {
+		return domain.URLEvent{}, err
+	}
+
+	return c.ingestService.Prepare(event)
+}
+
+// sendDeadLetter keeps the message as it was with the reason it was rejected and where it came from
+func (c *Consumer) sendDeadLetter(msg *sarama.ConsumerMessage, reason error) error {
+	c.logger.Warn(
+		"url event sent to dead letters",
+		slog.String("error", reason.Error()),
+		slog.Int("partition", int(msg.Partition)),
+		slog.Int64("offset", msg.Offset),
+	)
+
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+4)
+	for _, header := range msg.Headers {
+		headers = append(headers, *header)
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(headerDeadLetterError), Value: []byte(reason.Error())},
+		sarama.RecordHeader{Key: []byte(headerDeadLetterTopic), Value: []byte(msg.Topic)},
+		sarama.RecordHeader{Key: []byte(headerDeadLetterPartition), Value: []byte(strconv.Itoa(int(msg.Partition)))},
+		sarama.RecordHeader{Key: []byte(headerDeadLetterOffset), Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+	)
+
+	_, _, err := c.deadLetters.SendMessage(&sarama.ProducerMessage{
+		Topic:   c.cfg.DeadLetterTopic,
+		Key:     sarama.ByteEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	})
+
+	return err
+}
+
+func headerValue(msg *sarama.ConsumerMessage, key string) string {
+	for _, header := range msg.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,245 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"analytics_service/internal/converter"
+	"analytics_service/internal/domain"
+	"analytics_service/internal/enrich"
+	"analytics_service/internal/repository/memoryrepo"
+	"analytics_service/internal/service"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSession records marked messages, the rest of the session is not used by the consumer
+type stubSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *stubSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *stubSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, msg)
+}
+
+type stubClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *stubClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+func (c *stubClaim) Partition() int32 {
+	return 1
+}
+
+func (c *stubClaim) InitialOffset() int64 {
+	return sarama.OffsetOldest
+}
+
+type stubSyncProducer struct {
+	sarama.SyncProducer
+	sent []*sarama.ProducerMessage
+	err  error
+}
+
+func (p *stubSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if p.err != nil {
+		return 0, 0, p.err
+	}
+	p.sent = append(p.sent, msg)
+
+	return 0, int64(len(p.sent)), nil
+}
+
+func newTestConsumer(
+	t *testing.T,
+	deadLetters sarama.SyncProducer,
+	cfg ConsumerConfig,
+) (*Consumer, *memoryrepo.EventsSink) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	countryLocator, err := enrich.NewCountryLocator(strings.NewReader("8.8.8.0,8.8.8.255,US"))
+	require.NoError(t, err)
+
+	sink := memoryrepo.NewEventsSink()
+	ingestService := service.NewIngestService(logger, sink, countryLocator)
+
+	return newConsumer(logger, nil, deadLetters, ingestService, converter.NewURLEventConverter(), cfg), sink
+}
+
+func newTestMessage(offset int64, value string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte(headerContentType), Value: []byte(converter.ContentTypeJSON)},
+		},
+		Key:       []byte("abc"),
+		Value:     []byte(value),
+		Topic:     "events",
+		Partition: 1,
+		Offset:    offset,
+	}
+}
+
+func TestConsumeClaim(t *testing.T) {
+	followEvent := `{"long_url":"https://test.longurl","short_url":"abc","event_time":1700000000,"event_type":2,"client_ip":"8.8.8.8"}`
+	createEvent := `{"long_url":"https://test.longurl","short_url":"abc","event_time":1700000000,"event_type":1}`
+	expectedFollow := domain.URLEvent{
+		EventType: domain.EventTypeFollow,
+		ShortURL:  "abc",
+		LongURL:   "https://test.longurl",
+		EventTime: time.Unix(1700000000, 0).UTC(),
+		ClientIP:  "8.8.8.8",
+		Country:   "US",
+		Device:    enrich.UnknownUserAgent,
+		OS:        enrich.UnknownUserAgent,
+		Browser:   enrich.UnknownUserAgent,
+	}
+	expectedCreate := domain.URLEvent{
+		EventType: domain.EventTypeCreate,
+		ShortURL:  "abc",
+		LongURL:   "https://test.longurl",
+		EventTime: time.Unix(1700000000, 0).UTC(),
+	}
+	errDeadLetters := errors.New("dead letter topic is unavailable")
+
+	testCases := []struct {
+		name                string
+		messages            []*sarama.ConsumerMessage
+		deadLettersErr      error
+		expectedEvents      []domain.URLEvent
+		expectedMarked      []int64
+		expectedDeadLetters []string
+		expectedErr         error
+	}{
+		{
+			name: "Stored in batches",
+			messages: []*sarama.ConsumerMessage{
+				newTestMessage(10, followEvent),
+				newTestMessage(11, createEvent),
+				newTestMessage(12, followEvent),
+			},
+			expectedEvents: []domain.URLEvent{expectedFollow, expectedCreate, expectedFollow},
+			expectedMarked: []int64{11, 12},
+		},
+		{
+			name: "Invalid messages go to dead letters",
+			messages: []*sarama.ConsumerMessage{
+				newTestMessage(10, followEvent),
+				newTestMessage(11, `{"short_url":`),
+				newTestMessage(12, `{"short_url":"abc","event_time":1700000000,"event_type":9}`),
+			},
+			expectedEvents:      []domain.URLEvent{expectedFollow},
+			expectedMarked:      []int64{12},
+			expectedDeadLetters: []string{"unexpected end of JSON input", "invalid url event: unknown event type: 9"},
+		},
+		{
+			name: "Batch of dead letters only",
+			messages: []*sarama.ConsumerMessage{
+				newTestMessage(10, `{"short_url":"abc","event_type":2}`),
+			},
+			expectedEvents:      []domain.URLEvent{},
+			expectedMarked:      []int64{10},
+			expectedDeadLetters: []string{"invalid url event: event time is not set"},
+		},
+		{
+			name: "Dead letters are unavailable",
+			messages: []*sarama.ConsumerMessage{
+				newTestMessage(10, followEvent),
+				newTestMessage(11, `{"short_url":`),
+			},
+			deadLettersErr: errDeadLetters,
+			expectedErr:    errDeadLetters,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deadLetters := &stubSyncProducer{err: tc.deadLettersErr}
+			consumer, sink := newTestConsumer(t, deadLetters, ConsumerConfig{
+				DeadLetterTopic: "events.dlq",
+				BatchSize:       2,
+				FlushInterval:   time.Hour,
+			})
+
+			session := &stubSession{ctx: context.Background()}
+			claim := &stubClaim{messages: make(chan *sarama.ConsumerMessage, len(tc.messages))}
+			for _, msg := range tc.messages {
+				claim.messages <- msg
+			}
+			close(claim.messages)
+
+			err := consumer.ConsumeClaim(session, claim)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, session.marked)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedEvents, sink.Events())
+
+			marked := make([]int64, 0, len(session.marked))
+			for _, msg := range session.marked {
+				marked = append(marked, msg.Offset)
+			}
+			assert.Equal(t, tc.expectedMarked, marked)
+
+			require.Len(t, deadLetters.sent, len(tc.expectedDeadLetters))
+			for i, msg := range deadLetters.sent {
+				assert.Equal(t, "events.dlq", msg.Topic)
+				assert.Equal(t, tc.expectedDeadLetters[i], producedHeader(msg, headerDeadLetterError))
+				assert.Equal(t, "events", producedHeader(msg, headerDeadLetterTopic))
+				assert.Equal(t, "1", producedHeader(msg, headerDeadLetterPartition))
+				assert.Equal(t, converter.ContentTypeJSON, producedHeader(msg, headerContentType))
+			}
+		})
+	}
+}
+
+func TestConsumeClaimFlushInterval(t *testing.T) {
+	consumer, sink := newTestConsumer(t, &stubSyncProducer{}, ConsumerConfig{
+		BatchSize:     100,
+		FlushInterval: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &stubSession{ctx: ctx}
+	claim := &stubClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+	claim.messages <- newTestMessage(10, `{"short_url":"abc","event_time":1700000000,"event_type":1}`)
+
+	done := make(chan error)
+	go func() {
+		done <- consumer.ConsumeClaim(session, claim)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(sink.Events()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func producedHeader(msg *sarama.ProducerMessage, key string) string {
+	for _, header := range msg.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS url_events_counter_mv;
+DROP TABLE IF EXISTS url_events;
+
+CREATE TABLE IF NOT EXISTS url_events
+(
+    long_url   String,
+    short_url  String,
+    event_time TIMESTAMP,
+    event_type Enum8('create' = 1, 'follow' = 2, 'delete' = 3, 'update' = 4)
+)
+    ENGINE = Kafka SETTINGS
+        kafka_broker_list = 'kafka1:9092',
+        kafka_topic_list = 'events',
+        kafka_group_name = 'group1',
+        kafka_format = 'JSONEachRow';
+
+CREATE MATERIALIZED VIEW url_events_counter_mv TO url_events_counter AS
+SELECT long_url,
+       short_url,
+       SUM(if(event_type == 'follow', 1, 0)) as follow_count,
+       SUM(if(event_type == 'create', 1, 0)) as create_count
+FROM url_events
+WHERE event_type IN ('create', 'follow')
+GROUP BY long_url, short_url
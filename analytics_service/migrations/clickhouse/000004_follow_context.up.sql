@@ -0,0 +1,56 @@
+DROP TABLE IF EXISTS url_events_counter_mv;
+DROP TABLE IF EXISTS url_events;
+
+-- Request context is empty for events other than follow and for events produced before it was added.
+-- Unknown fields are skipped, so producers may add fields before the table gets their columns
+CREATE TABLE IF NOT EXISTS url_events
+(
+    long_url        String,
+    short_url       String,
+    event_time      TIMESTAMP,
+    event_type      Enum8('create' = 1, 'follow' = 2, 'delete' = 3, 'update' = 4),
+    referrer        String,
+    user_agent      String,
+    accept_language String,
+    client_ip       String
+)
+    ENGINE = Kafka SETTINGS
+        kafka_broker_list = 'kafka1:9092',
+        kafka_topic_list = 'events',
+        kafka_group_name = 'group1',
+        kafka_format = 'JSONEachRow',
+        input_format_skip_unknown_fields = 1;
+
+CREATE MATERIALIZED VIEW url_events_counter_mv TO url_events_counter AS
+SELECT long_url,
+       short_url,
+       SUM(if(event_type == 'follow', 1, 0)) as follow_count,
+       SUM(if(event_type == 'create', 1, 0)) as create_count
+FROM url_events
+WHERE event_type IN ('create', 'follow')
+GROUP BY long_url, short_url;
+
+CREATE TABLE IF NOT EXISTS url_events_log
+(
+    long_url        String,
+    short_url       String,
+    event_time      TIMESTAMP,
+    event_type      Enum8('create' = 1, 'follow' = 2, 'delete' = 3, 'update' = 4),
+    referrer        String,
+    user_agent      String,
+    accept_language String,
+    client_ip       String
+) ENGINE = MergeTree
+      PARTITION BY toYYYYMM(event_time)
+      ORDER BY (short_url, event_time);
+
+CREATE MATERIALIZED VIEW url_events_log_mv TO url_events_log AS
+SELECT long_url,
+       short_url,
+       event_time,
+       event_type,
+       referrer,
+       user_agent,
+       accept_language,
+       client_ip
+FROM url_events;
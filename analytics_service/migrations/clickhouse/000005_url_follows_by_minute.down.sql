@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS url_follows_by_minute_mv;
+DROP TABLE IF EXISTS url_follows_by_minute;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS url_follows_by_minute
+(
+    short_url    String,
+    bucket       DateTime('UTC'),
+    follow_count AggregateFunction(count)
+) ENGINE = AggregatingMergeTree
+      PARTITION BY toYYYYMM(bucket)
+      ORDER BY (short_url, bucket);
+
+CREATE MATERIALIZED VIEW url_follows_by_minute_mv TO url_follows_by_minute AS
+SELECT short_url,
+       toStartOfMinute(event_time) AS bucket,
+       countState()                AS follow_count
+FROM url_events
+WHERE event_type = 'follow'
+GROUP BY short_url, bucket;
+
+-- Follows stored before the view was created. Events consumed while the migration runs
+-- may be counted twice, which is negligible for statistics
+INSERT INTO url_follows_by_minute
+SELECT short_url,
+       toStartOfMinute(event_time) AS bucket,
+       countState()                AS follow_count
+FROM url_events_log
+WHERE event_type = 'follow'
+  AND event_time < (SELECT metadata_modification_time
+                    FROM system.tables
+                    WHERE database = currentDatabase()
+                      AND name = 'url_follows_by_minute_mv')
+GROUP BY short_url, bucket;
@@ -0,0 +1,14 @@
+ALTER TABLE url_follows_by_minute_mv
+    MODIFY QUERY
+    SELECT short_url,
+           toStartOfMinute(event_time) AS bucket,
+           countState()                AS follow_count
+    FROM url_events
+    WHERE event_type = 'follow'
+    GROUP BY short_url, bucket;
+
+ALTER TABLE url_follows_by_minute
+    DROP COLUMN IF EXISTS unique_visitors;
+
+DROP TABLE IF EXISTS url_unique_visitors_mv;
+DROP TABLE IF EXISTS url_unique_visitors;
@@ -0,0 +1,47 @@
+-- A visitor is a hash of client ip, user agent and accept language.
+-- Follows without request context share one visitor
+CREATE TABLE IF NOT EXISTS url_unique_visitors
+(
+    short_url       String,
+    unique_visitors AggregateFunction(uniqCombined, UInt64)
+) ENGINE = AggregatingMergeTree
+      ORDER BY short_url;
+
+CREATE MATERIALIZED VIEW url_unique_visitors_mv TO url_unique_visitors AS
+SELECT short_url,
+       uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+FROM url_events
+WHERE event_type = 'follow'
+GROUP BY short_url;
+
+-- Visitor states are idempotent, so follows counted both by the view and by the backfill
+-- are not counted twice and the backfill needs no cutoff
+INSERT INTO url_unique_visitors
+SELECT short_url,
+       uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+FROM url_events_log
+WHERE event_type = 'follow'
+GROUP BY short_url;
+
+ALTER TABLE url_follows_by_minute
+    ADD COLUMN IF NOT EXISTS unique_visitors AggregateFunction(uniqCombined, UInt64);
+
+-- The view is changed in place, so no follow passes by it
+ALTER TABLE url_follows_by_minute_mv
+    MODIFY QUERY
+    SELECT short_url,
+           toStartOfMinute(event_time)                                          AS bucket,
+           countState()                                                         AS follow_count,
+           uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+    FROM url_events
+    WHERE event_type = 'follow'
+    GROUP BY short_url, bucket;
+
+-- Rows without follow_count merge into existing buckets without changing their counts
+INSERT INTO url_follows_by_minute (short_url, bucket, unique_visitors)
+SELECT short_url,
+       toStartOfMinute(event_time)                                          AS bucket,
+       uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+FROM url_events_log
+WHERE event_type = 'follow'
+GROUP BY short_url, bucket;
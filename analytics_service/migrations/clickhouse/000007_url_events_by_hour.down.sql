@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS url_events_by_hour_mv;
+DROP TABLE IF EXISTS url_events_by_hour;
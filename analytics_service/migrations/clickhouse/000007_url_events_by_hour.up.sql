@@ -0,0 +1,42 @@
+-- Top urls of a time window are merged from hourly rows, windows are aligned to whole hours
+CREATE TABLE IF NOT EXISTS url_events_by_hour
+(
+    bucket          DateTime('UTC'),
+    long_url        String,
+    short_url       String,
+    follow_count    SimpleAggregateFunction(sum, Int64),
+    create_count    SimpleAggregateFunction(sum, Int64),
+    unique_visitors AggregateFunction(uniqCombined, UInt64)
+) ENGINE = AggregatingMergeTree
+      PARTITION BY toYYYYMM(bucket)
+      ORDER BY (bucket, long_url, short_url);
+
+CREATE MATERIALIZED VIEW url_events_by_hour_mv TO url_events_by_hour AS
+SELECT toStartOfHour(event_time)                   AS bucket,
+       long_url,
+       short_url,
+       toInt64(countIf(event_type = 'follow'))     AS follow_count,
+       toInt64(countIf(event_type = 'create'))     AS create_count,
+       uniqCombinedStateIf(sipHash64(client_ip, user_agent, accept_language),
+                           event_type = 'follow') AS unique_visitors
+FROM url_events
+WHERE event_type IN ('create', 'follow')
+GROUP BY bucket, long_url, short_url;
+
+-- Events stored before the view was created. Events consumed while the migration runs
+-- may be counted twice, which is negligible for rankings
+INSERT INTO url_events_by_hour
+SELECT toStartOfHour(event_time)                   AS bucket,
+       long_url,
+       short_url,
+       toInt64(countIf(event_type = 'follow'))     AS follow_count,
+       toInt64(countIf(event_type = 'create'))     AS create_count,
+       uniqCombinedStateIf(sipHash64(client_ip, user_agent, accept_language),
+                           event_type = 'follow') AS unique_visitors
+FROM url_events_log
+WHERE event_type IN ('create', 'follow')
+  AND event_time < (SELECT metadata_modification_time
+                    FROM system.tables
+                    WHERE database = currentDatabase()
+                      AND name = 'url_events_by_hour_mv')
+GROUP BY bucket, long_url, short_url;
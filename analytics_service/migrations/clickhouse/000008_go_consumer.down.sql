@@ -0,0 +1,62 @@
+DROP TABLE IF EXISTS url_events_counter_mv;
+DROP TABLE IF EXISTS url_events_log_mv;
+DROP TABLE IF EXISTS url_follows_by_minute_mv;
+DROP TABLE IF EXISTS url_unique_visitors_mv;
+DROP TABLE IF EXISTS url_events_by_hour_mv;
+DROP TABLE IF EXISTS url_events_ingest;
+
+ALTER TABLE url_events_log
+    DROP INDEX IF EXISTS url_events_log_event_id_idx,
+    DROP COLUMN IF EXISTS event_id,
+    DROP COLUMN IF EXISTS country;
+
+-- The kafka engine reads nothing until the views below are created
+ATTACH TABLE IF NOT EXISTS url_events;
+
+CREATE MATERIALIZED VIEW url_events_counter_mv TO url_events_counter AS
+SELECT long_url,
+       short_url,
+       SUM(if(event_type == 'follow', 1, 0)) as follow_count,
+       SUM(if(event_type == 'create', 1, 0)) as create_count
+FROM url_events
+WHERE event_type IN ('create', 'follow')
+GROUP BY long_url, short_url;
+
+CREATE MATERIALIZED VIEW url_events_log_mv TO url_events_log AS
+SELECT long_url,
+       short_url,
+       event_time,
+       event_type,
+       referrer,
+       user_agent,
+       accept_language,
+       client_ip
+FROM url_events;
+
+CREATE MATERIALIZED VIEW url_follows_by_minute_mv TO url_follows_by_minute AS
+SELECT short_url,
+       toStartOfMinute(event_time)                                          AS bucket,
+       countState()                                                         AS follow_count,
+       uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+FROM url_events
+WHERE event_type = 'follow'
+GROUP BY short_url, bucket;
+
+CREATE MATERIALIZED VIEW url_unique_visitors_mv TO url_unique_visitors AS
+SELECT short_url,
+       uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+FROM url_events
+WHERE event_type = 'follow'
+GROUP BY short_url;
+
+CREATE MATERIALIZED VIEW url_events_by_hour_mv TO url_events_by_hour AS
+SELECT toStartOfHour(event_time)                   AS bucket,
+       long_url,
+       short_url,
+       toInt64(countIf(event_type = 'follow'))     AS follow_count,
+       toInt64(countIf(event_type = 'create'))     AS create_count,
+       uniqCombinedStateIf(sipHash64(client_ip, user_agent, accept_language),
+                           event_type = 'follow') AS unique_visitors
+FROM url_events
+WHERE event_type IN ('create', 'follow')
+GROUP BY bucket, long_url, short_url;
@@ -0,0 +1,87 @@
+-- Events are consumed by analytics_service and inserted in batches. Deploy order:
+-- 1. url_shortener_service that writes event ids into json events.
+-- 2. This migration. The kafka engine is detached first, it stops after its last block
+--    and keeps the offsets of group1 committed. Check them with
+--    kafka-consumer-groups --describe --group group1 before the next step.
+-- 3. analytics_service. Its consumer joins group1 and continues from those offsets,
+--    events produced in between wait in kafka. A group without committed offsets starts
+--    from KAFKA_INITIAL_OFFSET, oldest reads the topic again and counts events without id twice.
+DETACH TABLE IF EXISTS url_events PERMANENTLY;
+
+DROP TABLE IF EXISTS url_events_counter_mv;
+DROP TABLE IF EXISTS url_events_log_mv;
+DROP TABLE IF EXISTS url_follows_by_minute_mv;
+DROP TABLE IF EXISTS url_unique_visitors_mv;
+DROP TABLE IF EXISTS url_events_by_hour_mv;
+
+-- Inserted batches only pass through the views
+CREATE TABLE IF NOT EXISTS url_events_ingest
+(
+    event_id        String,
+    long_url        String,
+    short_url       String,
+    event_time      TIMESTAMP,
+    event_type      Enum8('create' = 1, 'follow' = 2, 'delete' = 3, 'update' = 4),
+    referrer        String,
+    user_agent      String,
+    accept_language String,
+    client_ip       String,
+    country         LowCardinality(String)
+) ENGINE = Null;
+
+-- The consumer looks up event ids of a batch before inserting it, so redelivered events are skipped.
+-- Events consumed by the kafka engine have no id
+ALTER TABLE url_events_log
+    ADD COLUMN IF NOT EXISTS event_id String,
+    ADD COLUMN IF NOT EXISTS country LowCardinality(String),
+    ADD INDEX IF NOT EXISTS url_events_log_event_id_idx event_id TYPE bloom_filter GRANULARITY 1;
+
+CREATE MATERIALIZED VIEW url_events_counter_mv TO url_events_counter AS
+SELECT long_url,
+       short_url,
+       SUM(if(event_type == 'follow', 1, 0)) as follow_count,
+       SUM(if(event_type == 'create', 1, 0)) as create_count
+FROM url_events_ingest
+WHERE event_type IN ('create', 'follow')
+GROUP BY long_url, short_url;
+
+CREATE MATERIALIZED VIEW url_events_log_mv TO url_events_log AS
+SELECT event_id,
+       long_url,
+       short_url,
+       event_time,
+       event_type,
+       referrer,
+       user_agent,
+       accept_language,
+       client_ip,
+       country
+FROM url_events_ingest;
+
+CREATE MATERIALIZED VIEW url_follows_by_minute_mv TO url_follows_by_minute AS
+SELECT short_url,
+       toStartOfMinute(event_time)                                          AS bucket,
+       countState()                                                         AS follow_count,
+       uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+FROM url_events_ingest
+WHERE event_type = 'follow'
+GROUP BY short_url, bucket;
+
+CREATE MATERIALIZED VIEW url_unique_visitors_mv TO url_unique_visitors AS
+SELECT short_url,
+       uniqCombinedState(sipHash64(client_ip, user_agent, accept_language)) AS unique_visitors
+FROM url_events_ingest
+WHERE event_type = 'follow'
+GROUP BY short_url;
+
+CREATE MATERIALIZED VIEW url_events_by_hour_mv TO url_events_by_hour AS
+SELECT toStartOfHour(event_time)                   AS bucket,
+       long_url,
+       short_url,
+       toInt64(countIf(event_type = 'follow'))     AS follow_count,
+       toInt64(countIf(event_type = 'create'))     AS create_count,
+       uniqCombinedStateIf(sipHash64(client_ip, user_agent, accept_language),
+                           event_type = 'follow') AS unique_visitors
+FROM url_events_ingest
+WHERE event_type IN ('create', 'follow')
+GROUP BY bucket, long_url, short_url;
@@ -0,0 +1,19 @@
+ALTER TABLE url_events_log_mv
+    MODIFY QUERY
+    SELECT event_id,
+           long_url,
+           short_url,
+           event_time,
+           event_type,
+           referrer,
+           user_agent,
+           accept_language,
+           client_ip,
+           country
+    FROM url_events_ingest;
+
+ALTER TABLE url_events_log
+    DROP COLUMN IF EXISTS owner_id;
+
+ALTER TABLE url_events_ingest
+    DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,23 @@
+-- Create events carry the owner of the url, stats of a url are returned to its owner only.
+-- Urls created before owner id was added to events have no owner
+ALTER TABLE url_events_ingest
+    ADD COLUMN IF NOT EXISTS owner_id String;
+
+ALTER TABLE url_events_log
+    ADD COLUMN IF NOT EXISTS owner_id String;
+
+-- The view is changed in place, so no event passes by it
+ALTER TABLE url_events_log_mv
+    MODIFY QUERY
+    SELECT event_id,
+           long_url,
+           short_url,
+           event_time,
+           event_type,
+           referrer,
+           user_agent,
+           accept_language,
+           client_ip,
+           country,
+           owner_id
+    FROM url_events_ingest;
@@ -0,0 +1,29 @@
+-- Device, os and browser are derived from user agents of follows on ingest, breakdowns group
+-- follows by stored values. Follows stored before have empty values and are counted as unknown
+ALTER TABLE url_events_ingest
+    ADD COLUMN IF NOT EXISTS device LowCardinality(String),
+    ADD COLUMN IF NOT EXISTS os LowCardinality(String),
+    ADD COLUMN IF NOT EXISTS browser LowCardinality(String);
+
+ALTER TABLE url_events_log
+    ADD COLUMN IF NOT EXISTS device LowCardinality(String),
+    ADD COLUMN IF NOT EXISTS os LowCardinality(String),
+    ADD COLUMN IF NOT EXISTS browser LowCardinality(String);
+
+ALTER TABLE url_events_log_mv
+    MODIFY QUERY
+    SELECT event_id,
+           long_url,
+           short_url,
+           event_time,
+           event_type,
+           referrer,
+           user_agent,
+           accept_language,
+           client_ip,
+           country,
+           owner_id,
+           device,
+           os,
+           browser
+    FROM url_events_ingest;
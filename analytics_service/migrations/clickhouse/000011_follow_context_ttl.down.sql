@@ -0,0 +1,30 @@
+-- Expired values are not restored
+ALTER TABLE url_events_log
+    MODIFY COLUMN referrer REMOVE TTL,
+    MODIFY COLUMN user_agent REMOVE TTL,
+    MODIFY COLUMN accept_language REMOVE TTL,
+    MODIFY COLUMN client_ip REMOVE TTL;
+
+ALTER TABLE url_events_log_mv
+    MODIFY QUERY
+    SELECT event_id,
+           long_url,
+           short_url,
+           event_time,
+           event_type,
+           referrer,
+           user_agent,
+           accept_language,
+           client_ip,
+           country,
+           owner_id,
+           device,
+           os,
+           browser
+    FROM url_events_ingest;
+
+ALTER TABLE url_events_log
+    DROP COLUMN IF EXISTS referrer_host;
+
+ALTER TABLE url_events_ingest
+    DROP COLUMN IF EXISTS referrer_host;
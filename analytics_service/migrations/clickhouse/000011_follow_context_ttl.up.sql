@@ -0,0 +1,38 @@
+-- Client ip, user agent, accept language and referrer identify visitors, the log keeps them for 30 days.
+-- Rollups take visitors from events on insert and breakdowns group follows by values derived
+-- on ingest, so neither changes when the columns expire. Referrer hosts are derived from now on.
+ALTER TABLE url_events_ingest
+    ADD COLUMN IF NOT EXISTS referrer_host LowCardinality(String);
+
+ALTER TABLE url_events_log
+    ADD COLUMN IF NOT EXISTS referrer_host LowCardinality(String);
+
+ALTER TABLE url_events_log_mv
+    MODIFY QUERY
+    SELECT event_id,
+           long_url,
+           short_url,
+           event_time,
+           event_type,
+           referrer,
+           user_agent,
+           accept_language,
+           client_ip,
+           country,
+           owner_id,
+           device,
+           os,
+           browser,
+           referrer_host
+    FROM url_events_ingest;
+
+-- Mutations of a table run in order, so hosts of stored follows are derived before their referrers expire
+ALTER TABLE url_events_log
+    UPDATE referrer_host = domainWithoutWWW(referrer)
+    WHERE event_type = 'follow' AND referrer != '' AND referrer_host = '';
+
+ALTER TABLE url_events_log
+    MODIFY COLUMN referrer String TTL event_time + INTERVAL 30 DAY,
+    MODIFY COLUMN user_agent String TTL event_time + INTERVAL 30 DAY,
+    MODIFY COLUMN accept_language String TTL event_time + INTERVAL 30 DAY,
+    MODIFY COLUMN client_ip String TTL event_time + INTERVAL 30 DAY;
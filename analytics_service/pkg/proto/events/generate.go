@@ -0,0 +1,5 @@
+// Package events is generated from url_shortener_service/pkg/proto/events/url_event.proto,
+// the producer owns the schema of url events and it is kept in one place.
+package events
+
+//go:generate protoc --proto_path=../../../../url_shortener_service/pkg/proto/events --go_out=. --go_opt=paths=source_relative url_event.proto
@@ -57,10 +57,10 @@ func (m *TopUrlsRequest) validate(all bool) error {
 
 	var errors []error
 
-	if m.GetPage() < 1 {
+	if m.GetPage() < 0 {
 		err := TopUrlsRequestValidationError{
 			field:  "Page",
-			reason: "value must be greater than or equal to 1",
+			reason: "value must be greater than or equal to 0",
 		}
 		if !all {
 			return err
@@ -79,6 +79,88 @@ func (m *TopUrlsRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if _, ok := Ranking_name[int32(m.GetRanking())]; !ok {
+		err := TopUrlsRequestValidationError{
+			field:  "Ranking",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := Window_name[int32(m.GetWindow())]; !ok {
+		err := TopUrlsRequestValidationError{
+			field:  "Window",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetFrom()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TopUrlsRequestValidationError{
+					field:  "From",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TopUrlsRequestValidationError{
+					field:  "From",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFrom()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TopUrlsRequestValidationError{
+				field:  "From",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTo()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TopUrlsRequestValidationError{
+					field:  "To",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TopUrlsRequestValidationError{
+					field:  "To",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTo()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TopUrlsRequestValidationError{
+				field:  "To",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Cursor
+
 	if len(errors) > 0 {
 		return TopUrlsRequestMultiError(errors)
 	}
@@ -296,6 +378,8 @@ func (m *TopUrlData) validate(all bool) error {
 
 	// no validation rules for CreateCount
 
+	// no validation rules for UniqueFollowCount
+
 	if len(errors) > 0 {
 		return TopUrlDataMultiError(errors)
 	}
@@ -458,6 +542,8 @@ func (m *TopUrlsResponse) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for NextCursor
+
 	if len(errors) > 0 {
 		return TopUrlsResponseMultiError(errors)
 	}
@@ -535,3 +621,857 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = TopUrlsResponseValidationError{}
+
+// Validate checks the field values on UrlStatsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UrlStatsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UrlStatsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UrlStatsRequestMultiError, or nil if none found.
+func (m *UrlStatsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UrlStatsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetShortUrl()) < 1 {
+		err := UrlStatsRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetFrom() == nil {
+		err := UrlStatsRequestValidationError{
+			field:  "From",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTo() == nil {
+		err := UrlStatsRequestValidationError{
+			field:  "To",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _UrlStatsRequest_Granularity_NotInLookup[m.GetGranularity()]; ok {
+		err := UrlStatsRequestValidationError{
+			field:  "Granularity",
+			reason: "value must not be in list [GRANULARITY_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := Granularity_name[int32(m.GetGranularity())]; !ok {
+		err := UrlStatsRequestValidationError{
+			field:  "Granularity",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UrlStatsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UrlStatsRequestMultiError is an error wrapping multiple validation errors
+// returned by UrlStatsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UrlStatsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UrlStatsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UrlStatsRequestMultiError) AllErrors() []error { return m }
+
+// UrlStatsRequestValidationError is the validation error returned by
+// UrlStatsRequest.Validate if the designated constraints aren't met.
+type UrlStatsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UrlStatsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UrlStatsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UrlStatsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UrlStatsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UrlStatsRequestValidationError) ErrorName() string { return "UrlStatsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UrlStatsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUrlStatsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UrlStatsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UrlStatsRequestValidationError{}
+
+var _UrlStatsRequest_Granularity_NotInLookup = map[Granularity]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on UrlStatsBucket with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UrlStatsBucket) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UrlStatsBucket with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UrlStatsBucketMultiError,
+// or nil if none found.
+func (m *UrlStatsBucket) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UrlStatsBucket) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetStart()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UrlStatsBucketValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UrlStatsBucketValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStart()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UrlStatsBucketValidationError{
+				field:  "Start",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for FollowCount
+
+	// no validation rules for UniqueFollowCount
+
+	if len(errors) > 0 {
+		return UrlStatsBucketMultiError(errors)
+	}
+
+	return nil
+}
+
+// UrlStatsBucketMultiError is an error wrapping multiple validation errors
+// returned by UrlStatsBucket.ValidateAll() if the designated constraints
+// aren't met.
+type UrlStatsBucketMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UrlStatsBucketMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UrlStatsBucketMultiError) AllErrors() []error { return m }
+
+// UrlStatsBucketValidationError is the validation error returned by
+// UrlStatsBucket.Validate if the designated constraints aren't met.
+type UrlStatsBucketValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UrlStatsBucketValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UrlStatsBucketValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UrlStatsBucketValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UrlStatsBucketValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UrlStatsBucketValidationError) ErrorName() string { return "UrlStatsBucketValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UrlStatsBucketValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUrlStatsBucket.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UrlStatsBucketValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UrlStatsBucketValidationError{}
+
+// Validate checks the field values on UrlStatsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UrlStatsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UrlStatsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UrlStatsResponseMultiError, or nil if none found.
+func (m *UrlStatsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UrlStatsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ShortUrl
+
+	// no validation rules for Granularity
+
+	for idx, item := range m.GetBuckets() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UrlStatsResponseValidationError{
+						field:  fmt.Sprintf("Buckets[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UrlStatsResponseValidationError{
+						field:  fmt.Sprintf("Buckets[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UrlStatsResponseValidationError{
+					field:  fmt.Sprintf("Buckets[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for TotalFollowCount
+
+	// no validation rules for TotalUniqueFollowCount
+
+	if len(errors) > 0 {
+		return UrlStatsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UrlStatsResponseMultiError is an error wrapping multiple validation errors
+// returned by UrlStatsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UrlStatsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UrlStatsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UrlStatsResponseMultiError) AllErrors() []error { return m }
+
+// UrlStatsResponseValidationError is the validation error returned by
+// UrlStatsResponse.Validate if the designated constraints aren't met.
+type UrlStatsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UrlStatsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UrlStatsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UrlStatsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UrlStatsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UrlStatsResponseValidationError) ErrorName() string { return "UrlStatsResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UrlStatsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUrlStatsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UrlStatsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UrlStatsResponseValidationError{}
+
+// Validate checks the field values on UrlBreakdownRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UrlBreakdownRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UrlBreakdownRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UrlBreakdownRequestMultiError, or nil if none found.
+func (m *UrlBreakdownRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UrlBreakdownRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetShortUrl()) < 1 {
+		err := UrlBreakdownRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _UrlBreakdownRequest_Dimension_NotInLookup[m.GetDimension()]; ok {
+		err := UrlBreakdownRequestValidationError{
+			field:  "Dimension",
+			reason: "value must not be in list [DIMENSION_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := Dimension_name[int32(m.GetDimension())]; !ok {
+		err := UrlBreakdownRequestValidationError{
+			field:  "Dimension",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetFrom() == nil {
+		err := UrlBreakdownRequestValidationError{
+			field:  "From",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTo() == nil {
+		err := UrlBreakdownRequestValidationError{
+			field:  "To",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val < 1 || val > 100 {
+		err := UrlBreakdownRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [1, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UrlBreakdownRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UrlBreakdownRequestMultiError is an error wrapping multiple validation
+// errors returned by UrlBreakdownRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UrlBreakdownRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UrlBreakdownRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UrlBreakdownRequestMultiError) AllErrors() []error { return m }
+
+// UrlBreakdownRequestValidationError is the validation error returned by
+// UrlBreakdownRequest.Validate if the designated constraints aren't met.
+type UrlBreakdownRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UrlBreakdownRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UrlBreakdownRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UrlBreakdownRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UrlBreakdownRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UrlBreakdownRequestValidationError) ErrorName() string {
+	return "UrlBreakdownRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UrlBreakdownRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUrlBreakdownRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UrlBreakdownRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UrlBreakdownRequestValidationError{}
+
+var _UrlBreakdownRequest_Dimension_NotInLookup = map[Dimension]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on BreakdownValue with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BreakdownValue) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BreakdownValue with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BreakdownValueMultiError,
+// or nil if none found.
+func (m *BreakdownValue) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BreakdownValue) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Value
+
+	// no validation rules for FollowCount
+
+	if len(errors) > 0 {
+		return BreakdownValueMultiError(errors)
+	}
+
+	return nil
+}
+
+// BreakdownValueMultiError is an error wrapping multiple validation errors
+// returned by BreakdownValue.ValidateAll() if the designated constraints
+// aren't met.
+type BreakdownValueMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BreakdownValueMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BreakdownValueMultiError) AllErrors() []error { return m }
+
+// BreakdownValueValidationError is the validation error returned by
+// BreakdownValue.Validate if the designated constraints aren't met.
+type BreakdownValueValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BreakdownValueValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BreakdownValueValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BreakdownValueValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BreakdownValueValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BreakdownValueValidationError) ErrorName() string { return "BreakdownValueValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BreakdownValueValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBreakdownValue.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BreakdownValueValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BreakdownValueValidationError{}
+
+// Validate checks the field values on UrlBreakdownResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UrlBreakdownResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UrlBreakdownResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UrlBreakdownResponseMultiError, or nil if none found.
+func (m *UrlBreakdownResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UrlBreakdownResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ShortUrl
+
+	// no validation rules for Dimension
+
+	for idx, item := range m.GetValues() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UrlBreakdownResponseValidationError{
+						field:  fmt.Sprintf("Values[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UrlBreakdownResponseValidationError{
+						field:  fmt.Sprintf("Values[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UrlBreakdownResponseValidationError{
+					field:  fmt.Sprintf("Values[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for OtherFollowCount
+
+	// no validation rules for TotalFollowCount
+
+	if len(errors) > 0 {
+		return UrlBreakdownResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UrlBreakdownResponseMultiError is an error wrapping multiple validation
+// errors returned by UrlBreakdownResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UrlBreakdownResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UrlBreakdownResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UrlBreakdownResponseMultiError) AllErrors() []error { return m }
+
+// UrlBreakdownResponseValidationError is the validation error returned by
+// UrlBreakdownResponse.Validate if the designated constraints aren't met.
+type UrlBreakdownResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UrlBreakdownResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UrlBreakdownResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UrlBreakdownResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UrlBreakdownResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UrlBreakdownResponseValidationError) ErrorName() string {
+	return "UrlBreakdownResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UrlBreakdownResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUrlBreakdownResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UrlBreakdownResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UrlBreakdownResponseValidationError{}
@@ -15,9 +15,177 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/admin/api_keys": {
+            "post": {
+                "description": "Принимает id владельца и необязательное название ключа. Возвращает ключ, который больше нельзя получить повторно. Требует заголовок X-Admin-Token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Выпуск API ключа",
+                "operationId": "issue-api-key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Владелец ключа",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.IssueAPIKeyData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIKeyData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/api_keys/{id}": {
+            "delete": {
+                "description": "Принимает id ключа в path параметрах и отзывает его. Требует заголовок X-Admin-Token",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Отзыв API ключа",
+                "operationId": "revoke-api-key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен администратора",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id ключа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/urls": {
+            "get": {
+                "description": "Принимает page и limit. Возвращает ссылки, созданные текущим пользователем. Поддерживает пагинацию",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Получение списка ссылок текущего пользователя",
+                "operationId": "list-urls",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Страница",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное количество url на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.URLListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
         "/api/save_url": {
             "post": {
-                "description": "Принимает исходную ссылку, создает короткую ссылку и возвращает короткую ссылку",
+                "description": "Принимает исходную ссылку, необязательный alias и срок жизни ссылки (expires_in в секундах или expires_at), создает короткую ссылку и возвращает короткую ссылку",
                 "consumes": [
                     "application/json"
                 ],
@@ -31,20 +199,406 @@ const docTemplate = `{
                 "operationId": "save-url",
                 "parameters": [
                     {
-                        "description": "Длинная ссылка",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LongURLData"
-                        }
+                        "description": "Длинная ссылка",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LongURLData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.URlData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "Возвращает информацию по хедерам Access-Control-Request-Method, Access-Control-Request-Headers, Origin",
+                "tags": [
+                    "options"
+                ],
+                "summary": "Получение описания параметров соединения с сервером",
+                "operationId": "options-save-url",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/api/save_urls": {
+            "post": {
+                "description": "Принимает до 1000 исходных ссылок с теми же параметрами, что и /api/save_url. Возвращает результат для каждой ссылки в порядке запроса: короткую ссылку или ошибку и статус, с которым ответил бы /api/save_url",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Создание коротких ссылок для списка исходных ссылок",
+                "operationId": "save-urls",
+                "parameters": [
+                    {
+                        "description": "Список длинных ссылок",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkLongURLData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/top_urls": {
+            "get": {
+                "description": "Принимает limit, rank_by и window или from, to в формате RFC3339. Возвращает список популярных url постранично по курсору: next_cursor ответа передается в cursor для следующей страницы, страницы курсора за окно или диапазон не смещаются при новых переходах, за все время страницы читаются из текущих счетчиков и могут смещаться. Номер страницы page поддерживается для совместимости",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Получение списка популярных url",
+                "operationId": "get-top-urls",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Страница, вместо cursor",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor предыдущей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное количество url на странице",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: follows по переходам или unique_follows по уникальным посетителям, по умолчанию follows",
+                        "name": "rank_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Период: all за все время, hour, day или week за последний час, сутки или неделю, по умолчанию all",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало произвольного периода вместо window",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец произвольного периода, по умолчанию текущее время",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TopURLDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/urls/{short_url}": {
+            "put": {
+                "description": "Принимает короткую ссылку в path параметрах и новую исходную ссылку в теле запроса. Короткая ссылка начинает перенаправлять на новую исходную ссылку",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Изменение исходной ссылки для короткой ссылки",
+                "operationId": "update-url",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "короткая ссылка",
+                        "name": "short_url",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая исходная ссылка",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateURLData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.URlData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Принимает короткую ссылку в path параметрах и удаляет ее",
+                "tags": [
+                    "url"
+                ],
+                "summary": "Удаление короткой ссылки",
+                "operationId": "delete-url",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "короткая ссылка",
+                        "name": "short_url",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Принимает короткую ссылку в path параметрах и флаг active в теле запроса. Деактивированная ссылка перестает перенаправлять на исходную ссылку",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Активация и деактивация короткой ссылки",
+                "operationId": "set-url-active",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "короткая ссылка",
+                        "name": "short_url",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Флаг активности ссылки",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.URLActiveData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/urls/{short_url}/breakdown": {
+            "get": {
+                "description": "Принимает dimension, from, to в формате RFC3339 и limit. Возвращает значения измерения с наибольшим количеством переходов от from до to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Получение популярных значений измерения переходов по короткому url",
+                "operationId": "get-url-breakdown",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Короткий url",
+                        "name": "short_url",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Измерение: referrer_host, country, device, os или browser",
+                        "name": "dimension",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, по умолчанию за сутки до to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода, по умолчанию текущее время",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное количество значений, не больше 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.URlData"
+                            "$ref": "#/definitions/dto.URLBreakdownResponse"
                         }
                     },
                     "400": {
@@ -53,6 +607,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Body"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -60,24 +632,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "options": {
-                "description": "Возвращает информацию по хедерам Access-Control-Request-Method, Access-Control-Request-Headers, Origin",
-                "tags": [
-                    "options"
-                ],
-                "summary": "Получение описания параметров соединения с сервером",
-                "operationId": "options-save-url",
-                "responses": {
-                    "200": {
-                        "description": ""
-                    }
-                }
             }
         },
-        "/api/top_urls": {
+        "/api/urls/{short_url}/stats": {
             "get": {
-                "description": "Принимает page и limit. Возвращает список популярных url. Поддерживает пагинацию",
+                "description": "Принимает from, to в формате RFC3339 и granularity. Возвращает количество переходов в каждом интервале от from до to, интервалы без переходов имеют нулевое количество",
                 "consumes": [
                     "application/json"
                 ],
@@ -87,19 +646,32 @@ const docTemplate = `{
                 "tags": [
                     "url"
                 ],
-                "summary": "Получение списка популярных url",
-                "operationId": "get-top-urls",
+                "summary": "Получение статистики переходов по короткому url",
+                "operationId": "get-url-stats",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Страница",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Короткий url",
+                        "name": "short_url",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, по умолчанию за сутки до to",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Максимальное количество url на странице",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Конец периода, по умолчанию текущее время",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Размер интервала: minute, hour или day, по умолчанию hour",
+                        "name": "granularity",
                         "in": "query"
                     }
                 ],
@@ -107,7 +679,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.TopURLDataResponse"
+                            "$ref": "#/definitions/dto.URLStatsResponse"
                         }
                     },
                     "400": {
@@ -116,6 +688,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Body"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -140,6 +730,24 @@ const docTemplate = `{
                         "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "источник перехода, сохраняется в событии перехода",
+                        "name": "Referer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "клиент, сохраняется в событии перехода",
+                        "name": "User-Agent",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "языки клиента, сохраняются в событии перехода",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -158,6 +766,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Body"
                         }
                     },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -169,9 +783,105 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "dto.APIKeyData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "Key is shown only once",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BreakdownValue": {
+            "type": "object",
+            "properties": {
+                "follow_count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BulkLongURLData": {
+            "type": "object",
+            "properties": {
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LongURLData"
+                    }
+                }
+            }
+        },
+        "dto.BulkURLResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkURLResult"
+                    }
+                }
+            }
+        },
+        "dto.BulkURLResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "long_url": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the http status /api/save_url responds with for the same url",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.IssueAPIKeyData": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.LongURLData": {
             "type": "object",
             "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "ExpiresIn is a lifetime of the link in seconds",
+                    "type": "integer"
+                },
                 "long_url": {
                     "type": "string"
                 }
@@ -211,14 +921,27 @@ const docTemplate = `{
                 },
                 "short_url": {
                     "type": "string"
+                },
+                "unique_follow_count": {
+                    "description": "UniqueFollowCount is an estimate of distinct visitors",
+                    "type": "integer"
                 }
             }
         },
         "dto.TopURLDataResponse": {
             "type": "object",
             "properties": {
+                "next_cursor": {
+                    "description": "NextCursor is empty on the last page and for page numbers",
+                    "type": "string"
+                },
                 "pagination": {
-                    "$ref": "#/definitions/dto.Pagination"
+                    "description": "Pagination is only set for page numbers",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.Pagination"
+                        }
+                    ]
                 },
                 "top_url_data": {
                     "type": "array",
@@ -228,9 +951,117 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.URLActiveData": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.URLBreakdownResponse": {
+            "type": "object",
+            "properties": {
+                "dimension": {
+                    "type": "string"
+                },
+                "other_follow_count": {
+                    "description": "OtherFollowCount is the sum of values that did not make it to the top",
+                    "type": "integer"
+                },
+                "short_url": {
+                    "type": "string"
+                },
+                "total_follow_count": {
+                    "type": "integer"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BreakdownValue"
+                    }
+                }
+            }
+        },
+        "dto.URLInfo": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "long_url": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.URLListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.URLInfo"
+                    }
+                }
+            }
+        },
+        "dto.URLStatsBucket": {
+            "type": "object",
+            "properties": {
+                "follow_count": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "unique_follow_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.URLStatsResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "description": "Buckets cover the whole range, buckets without follows have zero count",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.URLStatsBucket"
+                    }
+                },
+                "granularity": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                },
+                "total_follow_count": {
+                    "type": "integer"
+                },
+                "total_unique_follow_count": {
+                    "description": "TotalUniqueFollowCount counts a visitor once for the whole range",
+                    "type": "integer"
+                }
+            }
+        },
         "dto.URlData": {
             "type": "object",
             "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
                 "long_url": {
                     "type": "string"
                 },
@@ -239,6 +1070,14 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpdateURLData": {
+            "type": "object",
+            "properties": {
+                "long_url": {
+                    "type": "string"
+                }
+            }
+        },
         "response.Body": {
             "type": "object",
             "properties": {
@@ -6,4 +6,8 @@ var (
 	ErrInternal        = errors.New("internal error")
 	ErrNotFound        = errors.New("not found")
 	ErrInvalidArgument = errors.New("invalid argument")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrGone            = errors.New("gone")
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
 )
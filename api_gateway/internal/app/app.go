@@ -5,16 +5,19 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
+	"api_gateway/internal/auth"
 	"api_gateway/internal/client"
 	"api_gateway/internal/config"
 	"api_gateway/internal/converter"
+	"api_gateway/internal/ratelimit"
 	"api_gateway/internal/transport/rest"
 	"api_gateway/internal/transport/rest/middlewares"
 	"api_gateway/pkg/proto/analytics"
 	"api_gateway/pkg/proto/url"
+	"github.com/redis/go-redis/v9"
 	httpSwagger "github.com/swaggo/http-swagger"
-	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -24,6 +27,21 @@ const (
 	envProd  = "prod"
 
 	httpServerPort = "8000"
+
+	// Limiter falls back to local limits rather than waiting for a slow redis
+	redisTimeout = 200 * time.Millisecond
+)
+
+// Rate limited routes, limits of anonymous clients can be overridden per route in config
+const (
+	routeTopURLs      = "top_urls"
+	routeSaveURL      = "save_url"
+	routeSaveURLs     = "save_urls"
+	routeManageURL    = "manage_url"
+	routeListURLs     = "list_urls"
+	routeFollowURL    = "follow_url"
+	routeURLStats     = "url_stats"
+	routeURLBreakdown = "url_breakdown"
 )
 
 func Run() {
@@ -62,54 +80,115 @@ func setupLogger(env string) (*slog.Logger, error) {
 
 func runHttpServer(logger *slog.Logger, cfg config.Config) {
 	topUrlConverter := converter.NewTopURLConverter()
+	urlInfoConverter := converter.NewURLInfoConverter()
 	paginationConverter := converter.NewPaginationConverter()
+	urlStatsConverter := converter.NewURLStatsConverter()
+	breakdownConverter := converter.NewBreakdownConverter()
 
 	urlTarget := fmt.Sprintf("%s:%s", cfg.UrlServiceConfig.Host, cfg.UrlServiceConfig.Port)
 	urlTransportOpt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	urlIdentityOpt := grpc.WithUnaryInterceptor(client.IdentityUnaryClientInterceptor)
 
-	urlConn, err := grpc.NewClient(urlTarget, urlTransportOpt)
+	urlConn, err := grpc.NewClient(urlTarget, urlTransportOpt, urlIdentityOpt)
 	if err != nil {
 		panic(err)
 	}
 	grpcUrlClient := url.NewUrlClient(urlConn)
+	grpcAPIKeyClient := url.NewApiKeyClient(urlConn)
 
 	analyticsTarget := fmt.Sprintf("%s:%s", cfg.AnalyticsServiceConfig.Host, cfg.AnalyticsServiceConfig.Port)
 	analyticsTransportOpt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	analyticsIdentityOpt := grpc.WithUnaryInterceptor(client.IdentityUnaryClientInterceptor)
 
-	analyticsConn, err := grpc.NewClient(analyticsTarget, analyticsTransportOpt)
+	analyticsConn, err := grpc.NewClient(analyticsTarget, analyticsTransportOpt, analyticsIdentityOpt)
 	if err != nil {
 		panic(err)
 	}
 
 	analyticsGrpcClient := analytics.NewAnalyticsClient(analyticsConn)
-	analyticsClient := client.NewGrpcAnalyticsClient(logger, analyticsGrpcClient, topUrlConverter, paginationConverter)
+	analyticsClient := client.NewGrpcAnalyticsClient(
+		logger,
+		analyticsGrpcClient,
+		topUrlConverter,
+		paginationConverter,
+		urlStatsConverter,
+		breakdownConverter,
+	)
 
-	limiter := rate.NewLimiter(rate.Limit(cfg.RateLimitConfig.TokensPerSecond), cfg.RateLimitConfig.BurstSize)
+	clientIPResolver := ratelimit.NewClientIPResolver(cfg.RateLimitConfig.TrustedProxies)
 	rateLimitMiddleware := middlewares.NewRateLimiterMiddleware(
-		logger, limiter,
+		logger,
+		setupRateLimiter(logger, cfg.RateLimitConfig),
+		rateLimitPolicy(cfg.RateLimitConfig),
+		clientIPResolver,
+		cfg.RateLimitConfig.FailOpen,
+	)
+
+	apiKeyClient := client.NewCachedAPIKeyClient(
+		client.NewGrpcAPIKeyClient(logger, grpcAPIKeyClient),
+		cfg.APIKeyCacheConfig.TTL,
+		cfg.APIKeyCacheConfig.NegativeTTL,
+		cfg.APIKeyCacheConfig.Size,
 	)
+	var jwtVerifier auth.TokenVerifier
+	if cfg.JWTConfig.Enabled() {
+		jwtVerifier, err = auth.NewJWTVerifier(cfg.JWTConfig)
+		if err != nil {
+			panic(err)
+		}
+	}
+	authMiddleware := middlewares.NewAuthMiddleware(logger, apiKeyClient, jwtVerifier)
+	adminMiddleware := middlewares.NewAdminMiddleware(cfg.AdminToken)
+	apiKeyHandler := rest.NewAPIKeyHandler(logger, apiKeyClient)
 
-	urlClient := client.NewGrpcUrlClient(logger, grpcUrlClient)
-	urlHandler := rest.NewURLHandler(logger, urlClient, cfg.ServerDomain)
+	urlClient := client.NewGrpcUrlClient(logger, grpcUrlClient, urlInfoConverter, paginationConverter)
+	urlHandler := rest.NewURLHandler(logger, urlClient, clientIPResolver, cfg.ServerDomain)
 	analyticsHandler := rest.NewAnalyticsHandler(logger, analyticsClient)
 
 	mux := http.NewServeMux()
 	mux.Handle("GET /api/top_urls", rateLimitMiddleware.RateLimit(
-		http.HandlerFunc(analyticsHandler.GetTopURLs),
+		routeTopURLs, http.HandlerFunc(analyticsHandler.GetTopURLs),
+	))
+	mux.Handle("GET /api/urls/{short_url}/stats", rateLimitMiddleware.RateLimit(
+		routeURLStats, http.HandlerFunc(analyticsHandler.GetURLStats),
+	))
+	mux.Handle("GET /api/urls/{short_url}/breakdown", rateLimitMiddleware.RateLimit(
+		routeURLBreakdown, http.HandlerFunc(analyticsHandler.GetURLBreakdown),
 	))
 	mux.Handle("POST /api/save_url", rateLimitMiddleware.RateLimit(
-		http.HandlerFunc(urlHandler.SaveURL),
+		routeSaveURL, http.HandlerFunc(urlHandler.SaveURL),
 	))
 	mux.HandleFunc("OPTIONS /api/save_url", urlHandler.SaveURLOptions)
+	mux.Handle("POST /api/save_urls", rateLimitMiddleware.RateLimit(
+		routeSaveURLs, http.HandlerFunc(urlHandler.SaveURLs),
+	))
+	mux.Handle("DELETE /api/urls/{short_url}", rateLimitMiddleware.RateLimit(
+		routeManageURL, http.HandlerFunc(urlHandler.DeleteURL),
+	))
+	mux.Handle("PATCH /api/urls/{short_url}", rateLimitMiddleware.RateLimit(
+		routeManageURL, http.HandlerFunc(urlHandler.SetURLActive),
+	))
+	mux.Handle("PUT /api/urls/{short_url}", rateLimitMiddleware.RateLimit(
+		routeManageURL, http.HandlerFunc(urlHandler.UpdateURL),
+	))
+	mux.Handle("GET /api/me/urls", rateLimitMiddleware.RateLimit(
+		routeListURLs, http.HandlerFunc(urlHandler.ListURLs),
+	))
 	mux.Handle("GET /{short_url}", rateLimitMiddleware.RateLimit(
-		http.HandlerFunc(urlHandler.FollowUrl),
+		routeFollowURL, http.HandlerFunc(urlHandler.FollowUrl),
+	))
+	mux.Handle("POST /api/admin/api_keys", adminMiddleware.RequireAdmin(
+		http.HandlerFunc(apiKeyHandler.IssueAPIKey),
+	))
+	mux.Handle("DELETE /api/admin/api_keys/{id}", adminMiddleware.RequireAdmin(
+		http.HandlerFunc(apiKeyHandler.RevokeAPIKey),
 	))
 	mux.Handle("GET /api/docs/", httpSwagger.WrapHandler)
 
 	addr := fmt.Sprintf(":%s", httpServerPort)
 	server := http.Server{
 		Addr:    addr,
-		Handler: mux,
+		Handler: authMiddleware.Authenticate(mux),
 	}
 
 	logger.Info(fmt.Sprintf("Run server on %s", addr))
@@ -118,3 +197,48 @@ func runHttpServer(logger *slog.Logger, cfg config.Config) {
 		logger.Info(err.Error())
 	}
 }
+
+func setupRateLimiter(logger *slog.Logger, cfg config.RateLimitConfig) ratelimit.Limiter {
+	localLimiter := ratelimit.NewLocalLimiter(cfg.MaxClients)
+	if cfg.Backend != ratelimit.BackendRedis {
+		return localLimiter
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:         fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
+		Password:     cfg.Redis.Password,
+		DB:           0,
+		DialTimeout:  redisTimeout,
+		ReadTimeout:  redisTimeout,
+		WriteTimeout: redisTimeout,
+	})
+
+	return ratelimit.NewRedisLimiter(logger, redisClient, localLimiter)
+}
+
+func rateLimitPolicy(cfg config.RateLimitConfig) ratelimit.Policy {
+	return ratelimit.Policy{
+		Anonymous: ratelimit.Limit{
+			TokensPerSecond: cfg.TokensPerSecond,
+			BurstSize:       cfg.BurstSize,
+		},
+		Authenticated: ratelimit.Limit{
+			TokensPerSecond: cfg.APIKeyTokensPerSecond,
+			BurstSize:       cfg.APIKeyBurstSize,
+		},
+		Routes:              routeLimits(cfg.Routes),
+		AuthenticatedRoutes: routeLimits(cfg.APIKeyRoutes),
+	}
+}
+
+func routeLimits(routes map[string]config.RouteLimit) map[string]ratelimit.Limit {
+	limits := make(map[string]ratelimit.Limit, len(routes))
+	for route, routeLimit := range routes {
+		limits[route] = ratelimit.Limit{
+			TokensPerSecond: routeLimit.TokensPerSecond,
+			BurstSize:       routeLimit.BurstSize,
+		}
+	}
+
+	return limits
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"api_gateway/errs"
+	"api_gateway/internal/config"
+	"api_gateway/internal/identity"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name TokenVerifier
+type TokenVerifier interface {
+	// Verify checks the signature and the claims of token and returns its subject as the caller
+	Verify(token string) (identity.Identity, error)
+}
+
+type jwtVerifier struct {
+	parser *jwt.Parser
+	key    any
+}
+
+// NewJWTVerifier builds a verifier for tokens signed with cfg.Algorithm.
+// Tokens must carry exp and sub claims, iss and aud are checked when configured.
+func NewJWTVerifier(cfg config.JWTConfig) (TokenVerifier, error) {
+	var key any
+	switch cfg.Algorithm {
+	case config.JWTAlgorithmHS256:
+		key = []byte(cfg.Secret)
+	case config.JWTAlgorithmRS256:
+		publicKeyPEM, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err = jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", cfg.Algorithm)
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{cfg.Algorithm}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &jwtVerifier{
+		parser: jwt.NewParser(parserOpts...),
+		key:    key,
+	}, nil
+}
+
+func (v *jwtVerifier) Verify(token string) (identity.Identity, error) {
+	claims := jwt.RegisteredClaims{}
+	_, err := v.parser.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return v.key, nil
+	})
+	if err != nil {
+		return identity.Identity{}, fmt.Errorf("%w: %w", errs.ErrUnauthenticated, err)
+	}
+	if claims.Subject == "" {
+		return identity.Identity{}, fmt.Errorf("%w: token has no subject", errs.ErrUnauthenticated)
+	}
+
+	return identity.Identity{OwnerID: claims.Subject}, nil
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"api_gateway/errs"
+	"api_gateway/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTVerifierHS256(t *testing.T) {
+	secret := []byte("test secret")
+	cfg := config.JWTConfig{
+		Algorithm: config.JWTAlgorithmHS256,
+		Secret:    string(secret),
+		Issuer:    "test-issuer",
+		Audience:  "test-audience",
+	}
+
+	validClaims := func() jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			Subject:   "user",
+			Issuer:    "test-issuer",
+			Audience:  jwt.ClaimStrings{"test-audience"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}
+	}
+	sign := func(method jwt.SigningMethod, claims jwt.RegisteredClaims, key any) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+
+	testCases := []struct {
+		name            string
+		buildToken      func() string
+		expectedOwnerID string
+		expectedErr     error
+	}{
+		{
+			name: "valid token",
+			buildToken: func() string {
+				return sign(jwt.SigningMethodHS256, validClaims(), secret)
+			},
+			expectedOwnerID: "user",
+		},
+		{
+			name: "wrong secret",
+			buildToken: func() string {
+				return sign(jwt.SigningMethodHS256, validClaims(), []byte("other secret"))
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+		{
+			name: "unexpected algorithm",
+			buildToken: func() string {
+				return sign(jwt.SigningMethodHS512, validClaims(), secret)
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+		{
+			name: "expired",
+			buildToken: func() string {
+				claims := validClaims()
+				claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+				return sign(jwt.SigningMethodHS256, claims, secret)
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+		{
+			name: "without expiration",
+			buildToken: func() string {
+				claims := validClaims()
+				claims.ExpiresAt = nil
+				return sign(jwt.SigningMethodHS256, claims, secret)
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+		{
+			name: "wrong audience",
+			buildToken: func() string {
+				claims := validClaims()
+				claims.Audience = jwt.ClaimStrings{"other"}
+				return sign(jwt.SigningMethodHS256, claims, secret)
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+		{
+			name: "wrong issuer",
+			buildToken: func() string {
+				claims := validClaims()
+				claims.Issuer = "other"
+				return sign(jwt.SigningMethodHS256, claims, secret)
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+		{
+			name: "without subject",
+			buildToken: func() string {
+				claims := validClaims()
+				claims.Subject = ""
+				return sign(jwt.SigningMethodHS256, claims, secret)
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+		{
+			name: "malformed",
+			buildToken: func() string {
+				return "not.a.token"
+			},
+			expectedErr: errs.ErrUnauthenticated,
+		},
+	}
+
+	verifier, err := NewJWTVerifier(cfg)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := verifier.Verify(tc.buildToken())
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOwnerID, id.OwnerID)
+		})
+	}
+}
+
+func TestJWTVerifierRS256(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	publicKeyFile := filepath.Join(t.TempDir(), "jwt.pub")
+	err = os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER}), 0o600)
+	require.NoError(t, err)
+
+	verifier, err := NewJWTVerifier(config.JWTConfig{
+		Algorithm:     config.JWTAlgorithmRS256,
+		PublicKeyFile: publicKeyFile,
+	})
+	require.NoError(t, err)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   "user",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	require.NoError(t, err)
+
+	id, err := verifier.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "user", id.OwnerID)
+
+	// HS256 token signed with the public key must not pass as RS256
+	forgedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(publicKeyDER)
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(forgedToken)
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	identity "api_gateway/internal/identity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenVerifier is an autogenerated mock type for the TokenVerifier type
+type TokenVerifier struct {
+	mock.Mock
+}
+
+// Verify provides a mock function with given fields: token
+func (_m *TokenVerifier) Verify(token string) (identity.Identity, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 identity.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (identity.Identity, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) identity.Identity); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(identity.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTokenVerifier creates a new instance of TokenVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTokenVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TokenVerifier {
+	mock := &TokenVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"api_gateway/errs"
@@ -10,11 +11,14 @@ import (
 	"api_gateway/pkg/proto/analytics"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name AnalyticsClient
 type AnalyticsClient interface {
-	GetTopUrls(ctx context.Context, page int64, limit int64) (dto.TopURLDataResponse, error)
+	GetTopUrls(ctx context.Context, params dto.TopURLsParams) (dto.TopURLDataResponse, error)
+	GetURLStats(ctx context.Context, params dto.URLStatsParams) (dto.URLStatsResponse, error)
+	GetURLBreakdown(ctx context.Context, params dto.URLBreakdownParams) (dto.URLBreakdownResponse, error)
 }
 
 type grpcAnalyticsClient struct {
@@ -22,6 +26,8 @@ type grpcAnalyticsClient struct {
 	grpcClient          analytics.AnalyticsClient
 	topUrlConverter     converter.TopURLConverter
 	paginationConverter converter.PaginationConverter
+	urlStatsConverter   converter.URLStatsConverter
+	breakdownConverter  converter.BreakdownConverter
 }
 
 func NewGrpcAnalyticsClient(
@@ -29,20 +35,35 @@ func NewGrpcAnalyticsClient(
 	grpcClient analytics.AnalyticsClient,
 	topUrlConverter converter.TopURLConverter,
 	paginationConverter converter.PaginationConverter,
+	urlStatsConverter converter.URLStatsConverter,
+	breakdownConverter converter.BreakdownConverter,
 ) AnalyticsClient {
 	return &grpcAnalyticsClient{
 		logger:              logger,
 		grpcClient:          grpcClient,
 		topUrlConverter:     topUrlConverter,
 		paginationConverter: paginationConverter,
+		urlStatsConverter:   urlStatsConverter,
+		breakdownConverter:  breakdownConverter,
 	}
 }
 
-func (g *grpcAnalyticsClient) GetTopUrls(ctx context.Context, page int64, limit int64) (dto.TopURLDataResponse, error) {
-	topUrlsGrpcResp, err := g.grpcClient.GetTopUrls(context.Background(), &analytics.TopUrlsRequest{
-		Page:  page,
-		Limit: limit,
-	})
+func (g *grpcAnalyticsClient) GetTopUrls(ctx context.Context, params dto.TopURLsParams) (dto.TopURLDataResponse, error) {
+	req := &analytics.TopUrlsRequest{
+		Page:    params.Page,
+		Cursor:  params.Cursor,
+		Limit:   params.Limit,
+		Ranking: g.topUrlConverter.MapRankingDtoToPb(params.Ranking),
+		Window:  g.topUrlConverter.MapWindowDtoToPb(params.Window),
+	}
+	if !params.From.IsZero() {
+		req.From = timestamppb.New(params.From)
+	}
+	if !params.To.IsZero() {
+		req.To = timestamppb.New(params.To)
+	}
+
+	topUrlsGrpcResp, err := g.grpcClient.GetTopUrls(context.Background(), req)
 
 	if err != nil {
 		g.logger.Error(err.Error())
@@ -61,8 +82,69 @@ func (g *grpcAnalyticsClient) GetTopUrls(ctx context.Context, page int64, limit
 
 	topUrlsResp := dto.TopURLDataResponse{
 		TopURLData: g.topUrlConverter.MapSlicePbToDto(topUrlsGrpcResp.TopUrlData),
-		Pagination: g.paginationConverter.MapPbToDto(topUrlsGrpcResp.Pagination),
+		NextCursor: topUrlsGrpcResp.NextCursor,
+	}
+	if topUrlsGrpcResp.Pagination != nil {
+		pagination := g.paginationConverter.MapPbToDto(topUrlsGrpcResp.Pagination)
+		topUrlsResp.Pagination = &pagination
 	}
 
 	return topUrlsResp, nil
 }
+
+func (g *grpcAnalyticsClient) GetURLStats(ctx context.Context, params dto.URLStatsParams) (dto.URLStatsResponse, error) {
+	urlStatsGrpcResp, err := g.grpcClient.GetUrlStats(ctx, &analytics.UrlStatsRequest{
+		ShortUrl:    params.ShortURL,
+		From:        timestamppb.New(params.From),
+		To:          timestamppb.New(params.To),
+		Granularity: g.urlStatsConverter.MapGranularityDtoToPb(params.Granularity),
+	})
+
+	if err != nil {
+		g.logger.Error(err.Error())
+		return dto.URLStatsResponse{}, mapURLAnalyticsError(err)
+	}
+
+	return g.urlStatsConverter.MapPbToDto(urlStatsGrpcResp), nil
+}
+
+func (g *grpcAnalyticsClient) GetURLBreakdown(
+	ctx context.Context,
+	params dto.URLBreakdownParams,
+) (dto.URLBreakdownResponse, error) {
+	urlBreakdownGrpcResp, err := g.grpcClient.GetUrlBreakdown(ctx, &analytics.UrlBreakdownRequest{
+		ShortUrl:  params.ShortURL,
+		Dimension: g.breakdownConverter.MapDimensionDtoToPb(params.Dimension),
+		From:      timestamppb.New(params.From),
+		To:        timestamppb.New(params.To),
+		Limit:     params.Limit,
+	})
+
+	if err != nil {
+		g.logger.Error(err.Error())
+		return dto.URLBreakdownResponse{}, mapURLAnalyticsError(err)
+	}
+
+	return g.breakdownConverter.MapPbToDto(urlBreakdownGrpcResp), nil
+}
+
+// mapURLAnalyticsError keeps the message of invalid arguments, it tells the client what to fix
+func mapURLAnalyticsError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return errs.ErrInternal
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", errs.ErrInvalidArgument, st.Message())
+	case codes.NotFound:
+		return errs.ErrNotFound
+	case codes.PermissionDenied:
+		return errs.ErrForbidden
+	case codes.Unauthenticated:
+		return errs.ErrUnauthenticated
+	default:
+		return errs.ErrInternal
+	}
+}
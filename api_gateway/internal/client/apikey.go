@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+
+	"api_gateway/errs"
+	"api_gateway/internal/identity"
+	"api_gateway/internal/transport/rest/dto"
+	"api_gateway/pkg/proto/url"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name APIKeyClient
+type APIKeyClient interface {
+	IssueAPIKey(ctx context.Context, issueData dto.IssueAPIKeyData) (dto.APIKeyData, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	// VerifyAPIKey returns the caller the key belongs to
+	VerifyAPIKey(ctx context.Context, key string) (identity.Identity, error)
+}
+
+type grpcAPIKeyClient struct {
+	logger     *slog.Logger
+	grpcClient url.ApiKeyClient
+}
+
+func NewGrpcAPIKeyClient(
+	logger *slog.Logger,
+	grpcClient url.ApiKeyClient,
+) APIKeyClient {
+	return &grpcAPIKeyClient{
+		logger:     logger,
+		grpcClient: grpcClient,
+	}
+}
+
+func (c *grpcAPIKeyClient) IssueAPIKey(ctx context.Context, issueData dto.IssueAPIKeyData) (dto.APIKeyData, error) {
+	resp, err := c.grpcClient.IssueApiKey(ctx, &url.IssueApiKeyRequest{
+		OwnerId: issueData.OwnerID,
+		Name:    issueData.Name,
+	})
+
+	if err != nil {
+		c.logger.Error(err.Error())
+		return dto.APIKeyData{}, mapUrlManagementError(err)
+	}
+
+	return dto.APIKeyData{
+		ID:        resp.Id,
+		Key:       resp.Key,
+		OwnerID:   resp.OwnerId,
+		Name:      resp.Name,
+		CreatedAt: resp.CreatedAt.AsTime(),
+	}, nil
+}
+
+func (c *grpcAPIKeyClient) RevokeAPIKey(ctx context.Context, id int64) error {
+	_, err := c.grpcClient.RevokeApiKey(ctx, &url.RevokeApiKeyRequest{
+		Id: id,
+	})
+
+	if err != nil {
+		c.logger.Error(err.Error())
+		return mapUrlManagementError(err)
+	}
+
+	return nil
+}
+
+func (c *grpcAPIKeyClient) VerifyAPIKey(ctx context.Context, key string) (identity.Identity, error) {
+	resp, err := c.grpcClient.VerifyApiKey(ctx, &url.VerifyApiKeyRequest{
+		Key: key,
+	})
+
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && (st.Code() == codes.Unauthenticated || st.Code() == codes.InvalidArgument) {
+			return identity.Identity{}, errs.ErrUnauthenticated
+		}
+
+		c.logger.Error(err.Error())
+		return identity.Identity{}, errs.ErrInternal
+	}
+
+	return identity.Identity{
+		OwnerID:  resp.OwnerId,
+		APIKeyID: resp.Id,
+	}, nil
+}
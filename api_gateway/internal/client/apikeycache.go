@@ -0,0 +1,143 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"api_gateway/errs"
+	"api_gateway/internal/identity"
+)
+
+// cachedAPIKeyClient keeps results of api key verification for a short time, so that
+// requests with the same key, valid or not, do not reach url service one by one.
+// A revoked key stays valid on other gateway replicas until its cache entry expires.
+// Failed verifications are not cached.
+type cachedAPIKeyClient struct {
+	APIKeyClient
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxKeys     int
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+}
+
+type apiKeyCacheEntry struct {
+	keyHash   [sha256.Size]byte
+	id        identity.Identity
+	err       error
+	expiresAt time.Time
+}
+
+// NewCachedAPIKeyClient caches valid keys for ttl and unknown or revoked keys for negativeTTL.
+// When maxKeys keys are cached the least recently used one is evicted.
+func NewCachedAPIKeyClient(apiKeyClient APIKeyClient, ttl, negativeTTL time.Duration, maxKeys int) APIKeyClient {
+	return newCachedAPIKeyClient(apiKeyClient, ttl, negativeTTL, maxKeys, time.Now)
+}
+
+func newCachedAPIKeyClient(
+	apiKeyClient APIKeyClient,
+	ttl time.Duration,
+	negativeTTL time.Duration,
+	maxKeys int,
+	now func() time.Time,
+) *cachedAPIKeyClient {
+	return &cachedAPIKeyClient{
+		APIKeyClient: apiKeyClient,
+		ttl:          ttl,
+		negativeTTL:  negativeTTL,
+		maxKeys:      maxKeys,
+		now:          now,
+		entries:      make(map[[sha256.Size]byte]*list.Element),
+		lru:          list.New(),
+	}
+}
+
+func (c *cachedAPIKeyClient) VerifyAPIKey(ctx context.Context, key string) (identity.Identity, error) {
+	// Keys are not kept in memory as is
+	keyHash := sha256.Sum256([]byte(key))
+	if entry, ok := c.get(keyHash); ok {
+		return entry.id, entry.err
+	}
+
+	id, err := c.APIKeyClient.VerifyAPIKey(ctx, key)
+	switch {
+	case err == nil:
+		c.put(apiKeyCacheEntry{keyHash: keyHash, id: id, expiresAt: c.now().Add(c.ttl)})
+	case errors.Is(err, errs.ErrUnauthenticated):
+		c.put(apiKeyCacheEntry{keyHash: keyHash, err: err, expiresAt: c.now().Add(c.negativeTTL)})
+	}
+
+	return id, err
+}
+
+// RevokeAPIKey drops the revoked key from the cache of this replica right away
+func (c *cachedAPIKeyClient) RevokeAPIKey(ctx context.Context, id int64) error {
+	err := c.APIKeyClient.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*apiKeyCacheEntry)
+		if entry.err == nil && entry.id.APIKeyID == id {
+			c.remove(elem)
+		}
+		elem = next
+	}
+
+	return nil
+}
+
+func (c *cachedAPIKeyClient) get(keyHash [sha256.Size]byte) (*apiKeyCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[keyHash]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*apiKeyCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry, true
+}
+
+func (c *cachedAPIKeyClient) put(entry apiKeyCacheEntry) {
+	if c.maxKeys <= 0 || !c.now().Before(entry.expiresAt) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[entry.keyHash]; ok {
+		elem.Value = &entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	for c.lru.Len() >= c.maxKeys {
+		c.remove(c.lru.Back())
+	}
+	c.entries[entry.keyHash] = c.lru.PushFront(&entry)
+}
+
+func (c *cachedAPIKeyClient) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*apiKeyCacheEntry).keyHash)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api_gateway/errs"
+	"api_gateway/internal/client/mocks"
+	"api_gateway/internal/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedAPIKeyClientVerifyAPIKey(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := identity.Identity{OwnerID: "owner", APIKeyID: 1}
+
+	apiKeyClient := mocks.NewAPIKeyClient(t)
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "valid").
+		Return(id, nil).
+		Twice()
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "unknown").
+		Return(identity.Identity{}, errs.ErrUnauthenticated).
+		Twice()
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "broken").
+		Return(identity.Identity{}, errs.ErrInternal).
+		Twice()
+
+	cachedClient := newCachedAPIKeyClient(apiKeyClient, time.Minute, time.Second, 10, func() time.Time { return now })
+
+	verify := func(key string) (identity.Identity, error) {
+		return cachedClient.VerifyAPIKey(context.Background(), key)
+	}
+	for range 2 {
+		got, err := verify("valid")
+		assert.NoError(t, err)
+		assert.Equal(t, id, got)
+
+		_, err = verify("unknown")
+		assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+
+		// Failures are not cached
+		_, err = verify("broken")
+		assert.ErrorIs(t, err, errs.ErrInternal)
+	}
+
+	// Unknown keys expire first
+	now = now.Add(time.Second)
+	_, err := verify("unknown")
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+	_, err = verify("valid")
+	assert.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	_, err = verify("valid")
+	assert.NoError(t, err)
+}
+
+func TestCachedAPIKeyClientRevokeAPIKey(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	apiKeyClient := mocks.NewAPIKeyClient(t)
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "valid").
+		Return(identity.Identity{OwnerID: "owner", APIKeyID: 1}, nil).
+		Once()
+	apiKeyClient.On("RevokeAPIKey", mock.Anything, int64(1)).
+		Return(nil).
+		Once()
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "valid").
+		Return(identity.Identity{}, errs.ErrUnauthenticated).
+		Once()
+
+	cachedClient := newCachedAPIKeyClient(apiKeyClient, time.Minute, time.Second, 10, func() time.Time { return now })
+
+	_, err := cachedClient.VerifyAPIKey(context.Background(), "valid")
+	assert.NoError(t, err)
+
+	err = cachedClient.RevokeAPIKey(context.Background(), 1)
+	assert.NoError(t, err)
+
+	_, err = cachedClient.VerifyAPIKey(context.Background(), "valid")
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+}
+
+func TestCachedAPIKeyClientEviction(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	apiKeyClient := mocks.NewAPIKeyClient(t)
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "first").
+		Return(identity.Identity{APIKeyID: 1}, nil).
+		Once()
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "second").
+		Return(identity.Identity{APIKeyID: 2}, nil).
+		Twice()
+	apiKeyClient.On("VerifyAPIKey", mock.Anything, "third").
+		Return(identity.Identity{APIKeyID: 3}, nil).
+		Once()
+
+	cachedClient := newCachedAPIKeyClient(apiKeyClient, time.Minute, time.Second, 2, func() time.Time { return now })
+
+	// second is the least recently used key when third comes
+	for _, key := range []string{"first", "second", "first", "third", "first", "second"} {
+		_, err := cachedClient.VerifyAPIKey(context.Background(), key)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"api_gateway/internal/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys the caller is forwarded with to backend services
+const (
+	OwnerIDMetadataKey  = "x-owner-id"
+	APIKeyIDMetadataKey = "x-api-key-id"
+)
+
+// IdentityUnaryClientInterceptor forwards the caller from the context as grpc metadata
+func IdentityUnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	id, ok := identity.FromContext(ctx)
+	if ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, OwnerIDMetadataKey, id.OwnerID)
+		if id.APIKeyID != 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, APIKeyIDMetadataKey, strconv.FormatInt(id.APIKeyID, 10))
+		}
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	identity "api_gateway/internal/identity"
+	dto "api_gateway/internal/transport/rest/dto"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyClient is an autogenerated mock type for the APIKeyClient type
+type APIKeyClient struct {
+	mock.Mock
+}
+
+// IssueAPIKey provides a mock function with given fields: ctx, issueData
+func (_m *APIKeyClient) IssueAPIKey(ctx context.Context, issueData dto.IssueAPIKeyData) (dto.APIKeyData, error) {
+	ret := _m.Called(ctx, issueData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueAPIKey")
+	}
+
+	var r0 dto.APIKeyData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.IssueAPIKeyData) (dto.APIKeyData, error)); ok {
+		return rf(ctx, issueData)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.IssueAPIKeyData) dto.APIKeyData); ok {
+		r0 = rf(ctx, issueData)
+	} else {
+		r0 = ret.Get(0).(dto.APIKeyData)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.IssueAPIKeyData) error); ok {
+		r1 = rf(ctx, issueData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, id
+func (_m *APIKeyClient) RevokeAPIKey(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyAPIKey provides a mock function with given fields: ctx, key
+func (_m *APIKeyClient) VerifyAPIKey(ctx context.Context, key string) (identity.Identity, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAPIKey")
+	}
+
+	var r0 identity.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (identity.Identity, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) identity.Identity); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(identity.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAPIKeyClient creates a new instance of APIKeyClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyClient {
+	mock := &APIKeyClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
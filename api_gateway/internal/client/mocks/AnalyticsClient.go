@@ -14,9 +14,9 @@ type AnalyticsClient struct {
 	mock.Mock
 }
 
-// GetTopUrls provides a mock function with given fields: ctx, page, limit
-func (_m *AnalyticsClient) GetTopUrls(ctx context.Context, page int64, limit int64) (dto.TopURLDataResponse, error) {
-	ret := _m.Called(ctx, page, limit)
+// GetTopUrls provides a mock function with given fields: ctx, params
+func (_m *AnalyticsClient) GetTopUrls(ctx context.Context, params dto.TopURLsParams) (dto.TopURLDataResponse, error) {
+	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTopUrls")
@@ -24,17 +24,73 @@ func (_m *AnalyticsClient) GetTopUrls(ctx context.Context, page int64, limit int
 
 	var r0 dto.TopURLDataResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (dto.TopURLDataResponse, error)); ok {
-		return rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TopURLsParams) (dto.TopURLDataResponse, error)); ok {
+		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) dto.TopURLDataResponse); ok {
-		r0 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.TopURLsParams) dto.TopURLDataResponse); ok {
+		r0 = rf(ctx, params)
 	} else {
 		r0 = ret.Get(0).(dto.TopURLDataResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
-		r1 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.TopURLsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLBreakdown provides a mock function with given fields: ctx, params
+func (_m *AnalyticsClient) GetURLBreakdown(ctx context.Context, params dto.URLBreakdownParams) (dto.URLBreakdownResponse, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLBreakdown")
+	}
+
+	var r0 dto.URLBreakdownResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.URLBreakdownParams) (dto.URLBreakdownResponse, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.URLBreakdownParams) dto.URLBreakdownResponse); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dto.URLBreakdownResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.URLBreakdownParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLStats provides a mock function with given fields: ctx, params
+func (_m *AnalyticsClient) GetURLStats(ctx context.Context, params dto.URLStatsParams) (dto.URLStatsResponse, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLStats")
+	}
+
+	var r0 dto.URLStatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.URLStatsParams) (dto.URLStatsResponse, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.URLStatsParams) dto.URLStatsResponse); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dto.URLStatsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.URLStatsParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
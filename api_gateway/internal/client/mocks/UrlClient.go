@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	dto "api_gateway/internal/transport/rest/dto"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,27 +14,45 @@ type UrlClient struct {
 	mock.Mock
 }
 
-// FollowUrl provides a mock function with given fields: ctx, shortUrl
-func (_m *UrlClient) FollowUrl(ctx context.Context, shortUrl string) (string, error) {
+// DeleteUrl provides a mock function with given fields: ctx, shortUrl
+func (_m *UrlClient) DeleteUrl(ctx context.Context, shortUrl string) error {
 	ret := _m.Called(ctx, shortUrl)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUrl")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortUrl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FollowUrl provides a mock function with given fields: ctx, shortUrl, clientInfo
+func (_m *UrlClient) FollowUrl(ctx context.Context, shortUrl string, clientInfo dto.ClientInfo) (string, error) {
+	ret := _m.Called(ctx, shortUrl, clientInfo)
+
 	if len(ret) == 0 {
 		panic("no return value specified for FollowUrl")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, shortUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, dto.ClientInfo) (string, error)); ok {
+		return rf(ctx, shortUrl, clientInfo)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, shortUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, dto.ClientInfo) string); ok {
+		r0 = rf(ctx, shortUrl, clientInfo)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shortUrl)
+	if rf, ok := ret.Get(1).(func(context.Context, string, dto.ClientInfo) error); ok {
+		r1 = rf(ctx, shortUrl, clientInfo)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -41,27 +60,131 @@ func (_m *UrlClient) FollowUrl(ctx context.Context, shortUrl string) (string, er
 	return r0, r1
 }
 
-// ShortenUrl provides a mock function with given fields: ctx, longUrl
-func (_m *UrlClient) ShortenUrl(ctx context.Context, longUrl string) (string, error) {
-	ret := _m.Called(ctx, longUrl)
+// ListUrls provides a mock function with given fields: ctx, page, limit
+func (_m *UrlClient) ListUrls(ctx context.Context, page int64, limit int64) (dto.URLListResponse, error) {
+	ret := _m.Called(ctx, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUrls")
+	}
+
+	var r0 dto.URLListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (dto.URLListResponse, error)); ok {
+		return rf(ctx, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) dto.URLListResponse); ok {
+		r0 = rf(ctx, page, limit)
+	} else {
+		r0 = ret.Get(0).(dto.URLListResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetUrlActive provides a mock function with given fields: ctx, shortUrl, active
+func (_m *UrlClient) SetUrlActive(ctx context.Context, shortUrl string, active bool) error {
+	ret := _m.Called(ctx, shortUrl, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUrlActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, shortUrl, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShortenUrl provides a mock function with given fields: ctx, longURLData
+func (_m *UrlClient) ShortenUrl(ctx context.Context, longURLData dto.LongURLData) (dto.URlData, error) {
+	ret := _m.Called(ctx, longURLData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ShortenUrl")
 	}
 
-	var r0 string
+	var r0 dto.URlData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, longUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.LongURLData) (dto.URlData, error)); ok {
+		return rf(ctx, longURLData)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, longUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.LongURLData) dto.URlData); ok {
+		r0 = rf(ctx, longURLData)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(dto.URlData)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.LongURLData) error); ok {
+		r1 = rf(ctx, longURLData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShortenUrls provides a mock function with given fields: ctx, longURLsData
+func (_m *UrlClient) ShortenUrls(ctx context.Context, longURLsData []dto.LongURLData) ([]dto.BulkURLResult, error) {
+	ret := _m.Called(ctx, longURLsData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShortenUrls")
+	}
+
+	var r0 []dto.BulkURLResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.LongURLData) ([]dto.BulkURLResult, error)); ok {
+		return rf(ctx, longURLsData)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.LongURLData) []dto.BulkURLResult); ok {
+		r0 = rf(ctx, longURLsData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.BulkURLResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []dto.LongURLData) error); ok {
+		r1 = rf(ctx, longURLsData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUrl provides a mock function with given fields: ctx, shortUrl, longURL
+func (_m *UrlClient) UpdateUrl(ctx context.Context, shortUrl string, longURL string) (dto.URlData, error) {
+	ret := _m.Called(ctx, shortUrl, longURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUrl")
+	}
+
+	var r0 dto.URlData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (dto.URlData, error)); ok {
+		return rf(ctx, shortUrl, longURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) dto.URlData); ok {
+		r0 = rf(ctx, shortUrl, longURL)
+	} else {
+		r0 = ret.Get(0).(dto.URlData)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, longUrl)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, shortUrl, longURL)
 	} else {
 		r1 = ret.Error(1)
 	}
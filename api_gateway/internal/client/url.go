@@ -2,39 +2,63 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"api_gateway/errs"
+	"api_gateway/internal/converter"
+	"api_gateway/internal/identity"
+	"api_gateway/internal/transport/rest/dto"
 	"api_gateway/pkg/proto/url"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name UrlClient
 type UrlClient interface {
-	FollowUrl(ctx context.Context, shortUrl string) (string, error)
-	ShortenUrl(ctx context.Context, longUrl string) (string, error)
+	// FollowUrl resolves a short url, clientInfo is attached to the follow event
+	FollowUrl(ctx context.Context, shortUrl string, clientInfo dto.ClientInfo) (string, error)
+	ShortenUrl(ctx context.Context, longURLData dto.LongURLData) (dto.URlData, error)
+	// ShortenUrls returns a result per url, errors of single urls are set in dto.BulkURLResult.Err
+	ShortenUrls(ctx context.Context, longURLsData []dto.LongURLData) ([]dto.BulkURLResult, error)
+	DeleteUrl(ctx context.Context, shortUrl string) error
+	SetUrlActive(ctx context.Context, shortUrl string, active bool) error
+	UpdateUrl(ctx context.Context, shortUrl string, longURL string) (dto.URlData, error)
+	ListUrls(ctx context.Context, page int64, limit int64) (dto.URLListResponse, error)
 }
 
+// grpcUrlClient sends the caller from identity.OwnerIDFromContext as the owner of the links
 type grpcUrlClient struct {
-	logger        *slog.Logger
-	urlGrpcClient url.UrlClient
+	logger              *slog.Logger
+	urlGrpcClient       url.UrlClient
+	urlInfoConverter    converter.URLInfoConverter
+	paginationConverter converter.PaginationConverter
 }
 
 func NewGrpcUrlClient(
 	logger *slog.Logger,
 	urlGrpcClient url.UrlClient,
-
+	urlInfoConverter converter.URLInfoConverter,
+	paginationConverter converter.PaginationConverter,
 ) UrlClient {
 	return &grpcUrlClient{
-		logger:        logger,
-		urlGrpcClient: urlGrpcClient,
+		logger:              logger,
+		urlGrpcClient:       urlGrpcClient,
+		urlInfoConverter:    urlInfoConverter,
+		paginationConverter: paginationConverter,
 	}
 }
 
-func (u *grpcUrlClient) FollowUrl(ctx context.Context, shortUrl string) (string, error) {
+func (u *grpcUrlClient) FollowUrl(ctx context.Context, shortUrl string, clientInfo dto.ClientInfo) (string, error) {
 	longURLResp, err := u.urlGrpcClient.FollowUrl(ctx, &url.ShortUrlRequest{
 		ShortUrl: shortUrl,
+		Client: &url.ClientInfo{
+			Referrer:       clientInfo.Referrer,
+			UserAgent:      clientInfo.UserAgent,
+			AcceptLanguage: clientInfo.AcceptLanguage,
+			Ip:             clientInfo.IP,
+		},
 	})
 
 	if err != nil {
@@ -47,6 +71,9 @@ func (u *grpcUrlClient) FollowUrl(ctx context.Context, shortUrl string) (string,
 		if st.Code() == codes.NotFound {
 			return "", errs.ErrNotFound
 		}
+		if st.Code() == codes.FailedPrecondition {
+			return "", errs.ErrGone
+		}
 		if st.Code() == codes.InvalidArgument {
 			return "", errs.ErrInvalidArgument
 		}
@@ -57,23 +84,191 @@ func (u *grpcUrlClient) FollowUrl(ctx context.Context, shortUrl string) (string,
 	return longURLResp.LongUrl, nil
 }
 
-func (u *grpcUrlClient) ShortenUrl(ctx context.Context, longUrl string) (string, error) {
-	shortURLResp, err := u.urlGrpcClient.ShortenUrl(context.Background(), &url.LongUrlRequest{
-		LongUrl: longUrl,
-	})
+func (u *grpcUrlClient) ShortenUrl(ctx context.Context, longURLData dto.LongURLData) (dto.URlData, error) {
+	req := longURLRequest(longURLData)
+	req.OwnerId = identity.OwnerIDFromContext(ctx)
+
+	shortURLResp, err := u.urlGrpcClient.ShortenUrl(ctx, req)
 
 	if err != nil {
 		u.logger.Error(err.Error())
 		st, ok := status.FromError(err)
 		if !ok || st.Code() == codes.Internal {
-			return "", errs.ErrInternal
+			return dto.URlData{}, errs.ErrInternal
 		}
 		if st.Code() == codes.InvalidArgument {
-			return "", errs.ErrInvalidArgument
+			return dto.URlData{}, errs.ErrInvalidArgument
+		}
+		if st.Code() == codes.AlreadyExists {
+			return dto.URlData{}, errs.ErrAlreadyExists
 		}
 
-		return "", errs.ErrInternal
+		return dto.URlData{}, errs.ErrInternal
+	}
+
+	urlData := dto.URlData{
+		LongURL:  shortURLResp.LongUrl,
+		ShortURL: shortURLResp.ShortUrl,
+	}
+	if shortURLResp.ExpiresAt != nil {
+		expiresAt := shortURLResp.ExpiresAt.AsTime()
+		urlData.ExpiresAt = &expiresAt
 	}
 
-	return shortURLResp.ShortUrl, nil
+	return urlData, nil
+}
+
+func (u *grpcUrlClient) ShortenUrls(ctx context.Context, longURLsData []dto.LongURLData) ([]dto.BulkURLResult, error) {
+	req := &url.ShortenUrlsRequest{
+		Urls:    make([]*url.LongUrlRequest, len(longURLsData)),
+		OwnerId: identity.OwnerIDFromContext(ctx),
+	}
+	for i, longURLData := range longURLsData {
+		req.Urls[i] = longURLRequest(longURLData)
+	}
+
+	shortenResp, err := u.urlGrpcClient.ShortenUrls(ctx, req)
+	if err != nil {
+		u.logger.Error(err.Error())
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.InvalidArgument {
+			return nil, errs.ErrInvalidArgument
+		}
+
+		return nil, errs.ErrInternal
+	}
+	if len(shortenResp.Results) != len(longURLsData) {
+		u.logger.Error("unexpected number of shorten results", slog.Int("count", len(shortenResp.Results)))
+		return nil, errs.ErrInternal
+	}
+
+	results := make([]dto.BulkURLResult, len(longURLsData))
+	for i, result := range shortenResp.Results {
+		results[i].LongURL = longURLsData[i].LongURL
+		if urlErr := result.GetError(); urlErr != nil {
+			results[i].Err = fmt.Errorf("%w: %s", shortenUrlError(codes.Code(urlErr.Code)), urlErr.Message)
+			continue
+		}
+
+		results[i].ShortURL = result.GetUrl().GetShortUrl()
+		if result.GetUrl().GetExpiresAt() != nil {
+			expiresAt := result.GetUrl().GetExpiresAt().AsTime()
+			results[i].ExpiresAt = &expiresAt
+		}
+	}
+
+	return results, nil
+}
+
+func shortenUrlError(code codes.Code) error {
+	switch code {
+	case codes.InvalidArgument:
+		return errs.ErrInvalidArgument
+	case codes.AlreadyExists:
+		return errs.ErrAlreadyExists
+	default:
+		return errs.ErrInternal
+	}
+}
+
+func longURLRequest(longURLData dto.LongURLData) *url.LongUrlRequest {
+	req := &url.LongUrlRequest{
+		LongUrl: longURLData.LongURL,
+		Alias:   longURLData.Alias,
+	}
+	if longURLData.ExpiresIn != 0 {
+		req.Expiry = &url.LongUrlRequest_ExpiresIn{ExpiresIn: longURLData.ExpiresIn}
+	}
+	if longURLData.ExpiresAt != nil {
+		req.Expiry = &url.LongUrlRequest_ExpiresAt{ExpiresAt: timestamppb.New(*longURLData.ExpiresAt)}
+	}
+
+	return req
+}
+
+func (u *grpcUrlClient) DeleteUrl(ctx context.Context, shortUrl string) error {
+	_, err := u.urlGrpcClient.DeleteUrl(ctx, &url.DeleteUrlRequest{
+		ShortUrl: shortUrl,
+		OwnerId:  identity.OwnerIDFromContext(ctx),
+	})
+
+	if err != nil {
+		u.logger.Error(err.Error())
+		return mapUrlManagementError(err)
+	}
+
+	return nil
+}
+
+func (u *grpcUrlClient) SetUrlActive(ctx context.Context, shortUrl string, active bool) error {
+	_, err := u.urlGrpcClient.SetUrlActive(ctx, &url.SetUrlActiveRequest{
+		ShortUrl: shortUrl,
+		Active:   active,
+		OwnerId:  identity.OwnerIDFromContext(ctx),
+	})
+
+	if err != nil {
+		u.logger.Error(err.Error())
+		return mapUrlManagementError(err)
+	}
+
+	return nil
+}
+
+func (u *grpcUrlClient) UpdateUrl(ctx context.Context, shortUrl string, longURL string) (dto.URlData, error) {
+	urlResp, err := u.urlGrpcClient.UpdateUrl(ctx, &url.UpdateUrlRequest{
+		ShortUrl: shortUrl,
+		LongUrl:  longURL,
+		OwnerId:  identity.OwnerIDFromContext(ctx),
+	})
+
+	if err != nil {
+		u.logger.Error(err.Error())
+		return dto.URlData{}, mapUrlManagementError(err)
+	}
+
+	urlData := dto.URlData{
+		LongURL:  urlResp.LongUrl,
+		ShortURL: urlResp.ShortUrl,
+	}
+	if urlResp.ExpiresAt != nil {
+		expiresAt := urlResp.ExpiresAt.AsTime()
+		urlData.ExpiresAt = &expiresAt
+	}
+
+	return urlData, nil
+}
+
+func (u *grpcUrlClient) ListUrls(ctx context.Context, page int64, limit int64) (dto.URLListResponse, error) {
+	listResp, err := u.urlGrpcClient.ListUrls(ctx, &url.ListUrlsRequest{
+		OwnerId: identity.OwnerIDFromContext(ctx),
+		Page:    page,
+		Limit:   limit,
+	})
+
+	if err != nil {
+		u.logger.Error(err.Error())
+		return dto.URLListResponse{}, mapUrlManagementError(err)
+	}
+
+	return dto.URLListResponse{
+		URLs:       u.urlInfoConverter.MapSlicePbToDto(listResp.Urls),
+		Pagination: u.paginationConverter.MapUrlPbToDto(listResp.Pagination),
+	}, nil
+}
+
+func mapUrlManagementError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return errs.ErrInternal
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		return errs.ErrNotFound
+	case codes.InvalidArgument:
+		return errs.ErrInvalidArgument
+	default:
+		return errs.ErrInternal
+	}
 }
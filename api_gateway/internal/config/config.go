@@ -2,8 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"api_gateway/internal/ratelimit"
 )
 
 const (
@@ -19,6 +24,55 @@ const (
 
 	rateLimitTokenPerSecondKey = "RATE_LIMIT_TOKEN_PER_SECOND"
 	rateLimitBurstSizeKey      = "RATE_LIMIT_BURST_SIZE"
+
+	// Optional, fall back to the anonymous limits
+	apiKeyRateLimitTokenPerSecondKey = "API_KEY_RATE_LIMIT_TOKEN_PER_SECOND"
+	apiKeyRateLimitBurstSizeKey      = "API_KEY_RATE_LIMIT_BURST_SIZE"
+
+	// Optional, comma separated list of name=tokens_per_second:burst_size
+	rateLimitRoutesKey = "RATE_LIMIT_ROUTES"
+	// Optional, same as RATE_LIMIT_ROUTES for authenticated callers
+	apiKeyRateLimitRoutesKey = "API_KEY_RATE_LIMIT_ROUTES"
+	// Optional, true by default: requests pass when the limiter fails
+	rateLimitFailOpenKey = "RATE_LIMIT_FAIL_OPEN"
+	// Optional, comma separated list of proxy addresses or CIDRs allowed to set X-Forwarded-For
+	rateLimitTrustedProxiesKey = "RATE_LIMIT_TRUSTED_PROXIES"
+	// Optional, number of clients whose limiters are kept in memory
+	rateLimitMaxClientsKey = "RATE_LIMIT_MAX_CLIENTS"
+
+	defaultRateLimitMaxClients = 10000
+
+	// Optional, one of ratelimit.Backend* values, local by default
+	rateLimitBackendKey = "RATE_LIMIT_BACKEND"
+	// Required for the redis backend
+	redisHostKey     = "REDIS_HOST"
+	redisPortKey     = "REDIS_PORT"
+	redisPasswordKey = "REDIS_PASSWORD"
+
+	// Optional, admin endpoints reject every request when it is empty
+	adminTokenKey = "ADMIN_TOKEN"
+
+	// Optional, how long results of api key verification are reused, caching is disabled with 0
+	apiKeyCacheTTLKey         = "API_KEY_CACHE_TTL"
+	apiKeyNegativeCacheTTLKey = "API_KEY_NEGATIVE_CACHE_TTL"
+	// Optional, number of api keys whose verification is kept in memory
+	apiKeyCacheSizeKey = "API_KEY_CACHE_SIZE"
+
+	defaultAPIKeyCacheTTL         = 30 * time.Second
+	defaultAPIKeyNegativeCacheTTL = 5 * time.Second
+	defaultAPIKeyCacheSize        = 10000
+
+	// Optional, jwt authentication is disabled when algorithm is empty
+	jwtAlgorithmKey     = "JWT_ALGORITHM"
+	jwtSecretKey        = "JWT_SECRET"
+	jwtPublicKeyFileKey = "JWT_PUBLIC_KEY_FILE"
+	jwtIssuerKey        = "JWT_ISSUER"
+	jwtAudienceKey      = "JWT_AUDIENCE"
+)
+
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
 )
 
 type Config struct {
@@ -27,6 +81,16 @@ type Config struct {
 	UrlServiceConfig       UrlServiceConfig
 	AnalyticsServiceConfig AnalyticsServiceConfig
 	RateLimitConfig        RateLimitConfig
+	AdminToken             string
+	APIKeyCacheConfig      APIKeyCacheConfig
+	JWTConfig              JWTConfig
+}
+
+// APIKeyCacheConfig bounds how long a revoked key keeps working and an issued one stays unknown
+type APIKeyCacheConfig struct {
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	Size        int
 }
 
 type AnalyticsServiceConfig struct {
@@ -39,9 +103,54 @@ type UrlServiceConfig struct {
 	Port string
 }
 
+// RateLimitConfig limits are applied to every client separately
 type RateLimitConfig struct {
 	TokensPerSecond float64
 	BurstSize       int
+	// APIKey limits are applied to authenticated callers
+	APIKeyTokensPerSecond float64
+	APIKeyBurstSize       int
+	// Routes override the limits of the named routes for every caller
+	Routes map[string]RouteLimit
+	// APIKeyRoutes override Routes for authenticated callers
+	APIKeyRoutes   map[string]RouteLimit
+	TrustedProxies []netip.Prefix
+	// FailOpen lets requests pass when the limiter fails, otherwise they are rejected
+	FailOpen bool
+	// MaxClients bounds the number of clients of the local limiter
+	MaxClients int
+	// Backend is one of ratelimit.Backend* values
+	Backend string
+	// Redis is used by the redis backend, the local limiter takes over while it is unreachable
+	Redis RedisConfig
+}
+
+type RedisConfig struct {
+	Host     string
+	Port     string
+	Password string
+}
+
+type RouteLimit struct {
+	TokensPerSecond float64
+	BurstSize       int
+}
+
+// JWTConfig describes how bearer tokens signed by the identity provider are verified
+type JWTConfig struct {
+	// Algorithm is HS256, RS256 or empty when jwt authentication is disabled
+	Algorithm string
+	// Secret is the HS256 signing key
+	Secret string
+	// PublicKeyFile is the path to the PEM encoded RS256 public key
+	PublicKeyFile string
+	// Issuer and Audience are checked only when set
+	Issuer   string
+	Audience string
+}
+
+func (c JWTConfig) Enabled() bool {
+	return c.Algorithm != ""
 }
 
 func ParseConfig() (Config, error) {
@@ -93,6 +202,68 @@ func ParseConfig() (Config, error) {
 		return Config{}, err
 	}
 
+	apiKeyRateLimitTokenPerSecond := rateLimitTokenPerSecond
+	if raw := os.Getenv(apiKeyRateLimitTokenPerSecondKey); raw != "" {
+		apiKeyRateLimitTokenPerSecond, err = strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	apiKeyRateLimitBurstSize := rateLimitBurstSize
+	if raw := os.Getenv(apiKeyRateLimitBurstSizeKey); raw != "" {
+		apiKeyRateLimitBurstSize, err = strconv.Atoi(raw)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	rateLimitRoutes, err := parseRouteLimits(rateLimitRoutesKey)
+	if err != nil {
+		return Config{}, err
+	}
+
+	apiKeyRateLimitRoutes, err := parseRouteLimits(apiKeyRateLimitRoutesKey)
+	if err != nil {
+		return Config{}, err
+	}
+
+	rateLimitFailOpen := true
+	if raw := os.Getenv(rateLimitFailOpenKey); raw != "" {
+		rateLimitFailOpen, err = strconv.ParseBool(raw)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	rateLimitTrustedProxies, err := parseTrustedProxies(os.Getenv(rateLimitTrustedProxiesKey))
+	if err != nil {
+		return Config{}, err
+	}
+
+	rateLimitMaxClients := defaultRateLimitMaxClients
+	if raw := os.Getenv(rateLimitMaxClientsKey); raw != "" {
+		rateLimitMaxClients, err = strconv.Atoi(raw)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	rateLimitBackend, redisConfig, err := parseRateLimitBackend()
+	if err != nil {
+		return Config{}, err
+	}
+
+	apiKeyCacheConfig, err := parseAPIKeyCacheConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	jwtConfig, err := parseJWTConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
 	return Config{
 		Env:          env,
 		ServerDomain: serverDomain,
@@ -105,8 +276,167 @@ func ParseConfig() (Config, error) {
 			Port: analyticsServicePort,
 		},
 		RateLimitConfig: RateLimitConfig{
-			TokensPerSecond: rateLimitTokenPerSecond,
-			BurstSize:       rateLimitBurstSize,
+			TokensPerSecond:       rateLimitTokenPerSecond,
+			BurstSize:             rateLimitBurstSize,
+			APIKeyTokensPerSecond: apiKeyRateLimitTokenPerSecond,
+			APIKeyBurstSize:       apiKeyRateLimitBurstSize,
+			Routes:                rateLimitRoutes,
+			APIKeyRoutes:          apiKeyRateLimitRoutes,
+			TrustedProxies:        rateLimitTrustedProxies,
+			FailOpen:              rateLimitFailOpen,
+			MaxClients:            rateLimitMaxClients,
+			Backend:               rateLimitBackend,
+			Redis:                 redisConfig,
 		},
+		AdminToken:        os.Getenv(adminTokenKey),
+		APIKeyCacheConfig: apiKeyCacheConfig,
+		JWTConfig:         jwtConfig,
 	}, nil
 }
+
+func parseAPIKeyCacheConfig() (APIKeyCacheConfig, error) {
+	cfg := APIKeyCacheConfig{
+		TTL:         defaultAPIKeyCacheTTL,
+		NegativeTTL: defaultAPIKeyNegativeCacheTTL,
+		Size:        defaultAPIKeyCacheSize,
+	}
+
+	var err error
+	if raw := os.Getenv(apiKeyCacheTTLKey); raw != "" {
+		cfg.TTL, err = time.ParseDuration(raw)
+		if err != nil {
+			return APIKeyCacheConfig{}, err
+		}
+	}
+	if raw := os.Getenv(apiKeyNegativeCacheTTLKey); raw != "" {
+		cfg.NegativeTTL, err = time.ParseDuration(raw)
+		if err != nil {
+			return APIKeyCacheConfig{}, err
+		}
+	}
+	if raw := os.Getenv(apiKeyCacheSizeKey); raw != "" {
+		cfg.Size, err = strconv.Atoi(raw)
+		if err != nil {
+			return APIKeyCacheConfig{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// parseRouteLimits parses save_url=5:10,follow=100:200 from env key
+func parseRouteLimits(key string) (map[string]RouteLimit, error) {
+	raw := os.Getenv(key)
+	routeLimits := make(map[string]RouteLimit)
+	if raw == "" {
+		return routeLimits, nil
+	}
+
+	for _, routeRaw := range strings.Split(raw, ",") {
+		name, limitRaw, ok := strings.Cut(strings.TrimSpace(routeRaw), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("bad %s entry: %q", key, routeRaw)
+		}
+		tokensRaw, burstRaw, ok := strings.Cut(limitRaw, ":")
+		if !ok {
+			return nil, fmt.Errorf("bad %s entry: %q", key, routeRaw)
+		}
+
+		tokensPerSecond, err := strconv.ParseFloat(tokensRaw, 64)
+		if err != nil {
+			return nil, err
+		}
+		burstSize, err := strconv.Atoi(burstRaw)
+		if err != nil {
+			return nil, err
+		}
+
+		routeLimits[name] = RouteLimit{
+			TokensPerSecond: tokensPerSecond,
+			BurstSize:       burstSize,
+		}
+	}
+
+	return routeLimits, nil
+}
+
+// parseTrustedProxies parses 10.0.0.0/8,192.168.1.1
+func parseTrustedProxies(raw string) ([]netip.Prefix, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var trustedProxies []netip.Prefix
+	for _, proxyRaw := range strings.Split(raw, ",") {
+		proxyRaw = strings.TrimSpace(proxyRaw)
+		if strings.Contains(proxyRaw, "/") {
+			prefix, err := netip.ParsePrefix(proxyRaw)
+			if err != nil {
+				return nil, err
+			}
+			trustedProxies = append(trustedProxies, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(proxyRaw)
+		if err != nil {
+			return nil, err
+		}
+		trustedProxies = append(trustedProxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return trustedProxies, nil
+}
+
+func parseRateLimitBackend() (string, RedisConfig, error) {
+	backend := os.Getenv(rateLimitBackendKey)
+	switch backend {
+	case "", ratelimit.BackendLocal:
+		return ratelimit.BackendLocal, RedisConfig{}, nil
+	case ratelimit.BackendRedis:
+	default:
+		return "", RedisConfig{}, fmt.Errorf("unknown %s: %s", rateLimitBackendKey, backend)
+	}
+
+	redisHost := os.Getenv(redisHostKey)
+	if redisHost == "" {
+		return "", RedisConfig{}, fmt.Errorf("you did not provide env: %s", redisHostKey)
+	}
+
+	redisPort := os.Getenv(redisPortKey)
+	if redisPort == "" {
+		return "", RedisConfig{}, fmt.Errorf("you did not provide env: %s", redisPortKey)
+	}
+
+	return backend, RedisConfig{
+		Host:     redisHost,
+		Port:     redisPort,
+		Password: os.Getenv(redisPasswordKey),
+	}, nil
+}
+
+func parseJWTConfig() (JWTConfig, error) {
+	jwtConfig := JWTConfig{
+		Algorithm:     os.Getenv(jwtAlgorithmKey),
+		Secret:        os.Getenv(jwtSecretKey),
+		PublicKeyFile: os.Getenv(jwtPublicKeyFileKey),
+		Issuer:        os.Getenv(jwtIssuerKey),
+		Audience:      os.Getenv(jwtAudienceKey),
+	}
+
+	switch jwtConfig.Algorithm {
+	case "":
+	case JWTAlgorithmHS256:
+		if jwtConfig.Secret == "" {
+			return JWTConfig{}, fmt.Errorf("you did not provide env: %s", jwtSecretKey)
+		}
+	case JWTAlgorithmRS256:
+		if jwtConfig.PublicKeyFile == "" {
+			return JWTConfig{}, fmt.Errorf("you did not provide env: %s", jwtPublicKeyFileKey)
+		}
+	default:
+		return JWTConfig{}, fmt.Errorf("unsupported %s: %s", jwtAlgorithmKey, jwtConfig.Algorithm)
+	}
+
+	return jwtConfig, nil
+}
@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"api_gateway/internal/transport/rest/dto"
+	"api_gateway/pkg/proto/analytics"
+)
+
+type BreakdownConverter struct {
+}
+
+func NewBreakdownConverter() BreakdownConverter {
+	return BreakdownConverter{}
+}
+
+func (c *BreakdownConverter) MapDimensionDtoToPb(dimension string) analytics.Dimension {
+	switch dimension {
+	case dto.DimensionReferrerHost:
+		return analytics.Dimension_DIMENSION_REFERRER_HOST
+	case dto.DimensionCountry:
+		return analytics.Dimension_DIMENSION_COUNTRY
+	case dto.DimensionDevice:
+		return analytics.Dimension_DIMENSION_DEVICE
+	case dto.DimensionOS:
+		return analytics.Dimension_DIMENSION_OS
+	case dto.DimensionBrowser:
+		return analytics.Dimension_DIMENSION_BROWSER
+	default:
+		return analytics.Dimension_DIMENSION_UNSPECIFIED
+	}
+}
+
+func (c *BreakdownConverter) MapDimensionPbToDto(dimension analytics.Dimension) string {
+	switch dimension {
+	case analytics.Dimension_DIMENSION_REFERRER_HOST:
+		return dto.DimensionReferrerHost
+	case analytics.Dimension_DIMENSION_COUNTRY:
+		return dto.DimensionCountry
+	case analytics.Dimension_DIMENSION_DEVICE:
+		return dto.DimensionDevice
+	case analytics.Dimension_DIMENSION_OS:
+		return dto.DimensionOS
+	case analytics.Dimension_DIMENSION_BROWSER:
+		return dto.DimensionBrowser
+	default:
+		return ""
+	}
+}
+
+func (c *BreakdownConverter) MapPbToDto(pb *analytics.UrlBreakdownResponse) dto.URLBreakdownResponse {
+	values := make([]dto.BreakdownValue, len(pb.GetValues()))
+	for i, value := range pb.GetValues() {
+		values[i] = dto.BreakdownValue{
+			Value:       value.GetValue(),
+			FollowCount: value.GetFollowCount(),
+		}
+	}
+
+	return dto.URLBreakdownResponse{
+		ShortURL:         pb.GetShortUrl(),
+		Dimension:        c.MapDimensionPbToDto(pb.GetDimension()),
+		Values:           values,
+		OtherFollowCount: pb.GetOtherFollowCount(),
+		TotalFollowCount: pb.GetTotalFollowCount(),
+	}
+}
@@ -3,6 +3,7 @@ package converter
 import (
 	"api_gateway/internal/transport/rest/dto"
 	"api_gateway/pkg/proto/analytics"
+	"api_gateway/pkg/proto/url"
 )
 
 type PaginationConverter struct {
@@ -21,3 +22,13 @@ func (c *PaginationConverter) MapPbToDto(pb *analytics.Pagination) dto.Paginatio
 		TotalPage:     int(pb.TotalPage),
 	}
 }
+
+func (c *PaginationConverter) MapUrlPbToDto(pb *url.Pagination) dto.Pagination {
+	return dto.Pagination{
+		Next:          int(pb.GetNext()),
+		Previous:      int(pb.GetPrevious()),
+		RecordPerPage: int(pb.GetRecordPerPage()),
+		CurrentPage:   int(pb.GetCurrentPage()),
+		TotalPage:     int(pb.GetTotalPage()),
+	}
+}
@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"api_gateway/internal/transport/rest/dto"
+	"api_gateway/pkg/proto/analytics"
+)
+
+type URLStatsConverter struct {
+}
+
+func NewURLStatsConverter() URLStatsConverter {
+	return URLStatsConverter{}
+}
+
+func (c *URLStatsConverter) MapGranularityDtoToPb(granularity string) analytics.Granularity {
+	switch granularity {
+	case dto.GranularityMinute:
+		return analytics.Granularity_GRANULARITY_MINUTE
+	case dto.GranularityHour:
+		return analytics.Granularity_GRANULARITY_HOUR
+	case dto.GranularityDay:
+		return analytics.Granularity_GRANULARITY_DAY
+	default:
+		return analytics.Granularity_GRANULARITY_UNSPECIFIED
+	}
+}
+
+func (c *URLStatsConverter) MapGranularityPbToDto(granularity analytics.Granularity) string {
+	switch granularity {
+	case analytics.Granularity_GRANULARITY_MINUTE:
+		return dto.GranularityMinute
+	case analytics.Granularity_GRANULARITY_HOUR:
+		return dto.GranularityHour
+	case analytics.Granularity_GRANULARITY_DAY:
+		return dto.GranularityDay
+	default:
+		return ""
+	}
+}
+
+func (c *URLStatsConverter) MapPbToDto(pb *analytics.UrlStatsResponse) dto.URLStatsResponse {
+	buckets := make([]dto.URLStatsBucket, len(pb.GetBuckets()))
+	for i, bucket := range pb.GetBuckets() {
+		buckets[i] = dto.URLStatsBucket{
+			Start:             bucket.GetStart().AsTime(),
+			FollowCount:       bucket.GetFollowCount(),
+			UniqueFollowCount: bucket.GetUniqueFollowCount(),
+		}
+	}
+
+	return dto.URLStatsResponse{
+		ShortURL:               pb.GetShortUrl(),
+		Granularity:            c.MapGranularityPbToDto(pb.GetGranularity()),
+		Buckets:                buckets,
+		TotalFollowCount:       pb.GetTotalFollowCount(),
+		TotalUniqueFollowCount: pb.GetTotalUniqueFollowCount(),
+	}
+}
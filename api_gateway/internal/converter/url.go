@@ -3,6 +3,7 @@ package converter
 import (
 	"api_gateway/internal/transport/rest/dto"
 	"api_gateway/pkg/proto/analytics"
+	"api_gateway/pkg/proto/url"
 )
 
 type TopURLConverter struct {
@@ -14,10 +15,33 @@ func NewTopURLConverter() TopURLConverter {
 
 func (c *TopURLConverter) MapPbToDto(pb *analytics.TopUrlData) dto.TopURLData {
 	return dto.TopURLData{
-		LongURL:     pb.LongUrl,
-		ShortURL:    pb.ShortUrl,
-		FollowCount: pb.FollowCount,
-		CreateCount: pb.CreateCount,
+		LongURL:           pb.LongUrl,
+		ShortURL:          pb.ShortUrl,
+		FollowCount:       pb.FollowCount,
+		CreateCount:       pb.CreateCount,
+		UniqueFollowCount: pb.UniqueFollowCount,
+	}
+}
+
+func (c *TopURLConverter) MapRankingDtoToPb(ranking string) analytics.Ranking {
+	switch ranking {
+	case dto.RankingUniqueFollows:
+		return analytics.Ranking_RANKING_UNIQUE_FOLLOWS
+	default:
+		return analytics.Ranking_RANKING_FOLLOWS
+	}
+}
+
+func (c *TopURLConverter) MapWindowDtoToPb(window string) analytics.Window {
+	switch window {
+	case dto.WindowHour:
+		return analytics.Window_WINDOW_LAST_HOUR
+	case dto.WindowDay:
+		return analytics.Window_WINDOW_LAST_DAY
+	case dto.WindowWeek:
+		return analytics.Window_WINDOW_LAST_WEEK
+	default:
+		return analytics.Window_WINDOW_ALL_TIME
 	}
 }
 
@@ -30,3 +54,35 @@ func (c *TopURLConverter) MapSlicePbToDto(pbs []*analytics.TopUrlData) []dto.Top
 
 	return dtos
 }
+
+type URLInfoConverter struct {
+}
+
+func NewURLInfoConverter() URLInfoConverter {
+	return URLInfoConverter{}
+}
+
+func (c *URLInfoConverter) MapPbToDto(pb *url.UrlInfo) dto.URLInfo {
+	urlInfo := dto.URLInfo{
+		LongURL:   pb.LongUrl,
+		ShortURL:  pb.ShortUrl,
+		CreatedAt: pb.CreatedAt.AsTime(),
+		Active:    pb.Active,
+	}
+	if pb.ExpiresAt != nil {
+		expiresAt := pb.ExpiresAt.AsTime()
+		urlInfo.ExpiresAt = &expiresAt
+	}
+
+	return urlInfo
+}
+
+func (c *URLInfoConverter) MapSlicePbToDto(pbs []*url.UrlInfo) []dto.URLInfo {
+	dtos := make([]dto.URLInfo, len(pbs))
+
+	for i := 0; i < len(pbs); i++ {
+		dtos[i] = c.MapPbToDto(pbs[i])
+	}
+
+	return dtos
+}
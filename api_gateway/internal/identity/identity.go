@@ -0,0 +1,29 @@
+package identity
+
+import "context"
+
+// Identity describes an authenticated caller
+type Identity struct {
+	OwnerID string
+	// APIKeyID is set when the caller authenticated with an api key
+	APIKeyID int64
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the caller
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the caller. ok is false for anonymous callers.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// OwnerIDFromContext returns the id of the caller or empty string for anonymous callers
+func OwnerIDFromContext(ctx context.Context) string {
+	id, _ := FromContext(ctx)
+	return id.OwnerID
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const forwardedForHeader = "X-Forwarded-For"
+
+// ClientIPResolver finds the address of the client behind the trusted proxies
+type ClientIPResolver struct {
+	trustedProxies []netip.Prefix
+}
+
+func NewClientIPResolver(trustedProxies []netip.Prefix) *ClientIPResolver {
+	return &ClientIPResolver{
+		trustedProxies: trustedProxies,
+	}
+}
+
+// ClientIP walks X-Forwarded-For from the right while the hops are trusted proxies.
+// The header is ignored for connections that do not come from a trusted proxy,
+// so clients can not pick an address to be limited by.
+func (c *ClientIPResolver) ClientIP(r *http.Request) string {
+	remoteAddr := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remoteAddr = host
+	}
+
+	clientAddr, err := netip.ParseAddr(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	clientAddr = clientAddr.Unmap()
+
+	forwardedFor := forwardedForChain(r)
+	for i := len(forwardedFor) - 1; i >= 0 && c.isTrusted(clientAddr); i-- {
+		hop, err := netip.ParseAddr(forwardedFor[i])
+		if err != nil {
+			break
+		}
+		clientAddr = hop.Unmap()
+	}
+
+	return clientAddr.String()
+}
+
+func (c *ClientIPResolver) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range c.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func forwardedForChain(r *http.Request) []string {
+	var chain []string
+	for _, header := range r.Header.Values(forwardedForHeader) {
+		for _, hop := range strings.Split(header, ",") {
+			chain = append(chain, strings.TrimSpace(hop))
+		}
+	}
+	return chain
+}
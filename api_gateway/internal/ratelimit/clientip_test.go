@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trustedProxies := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+	}
+
+	testCases := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expectedIP   string
+	}{
+		{
+			name:       "direct connection",
+			remoteAddr: "203.0.113.7:5000",
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:         "forwarded for ignored from untrusted peer",
+			remoteAddr:   "203.0.113.7:5000",
+			forwardedFor: []string{"198.51.100.1"},
+			expectedIP:   "203.0.113.7",
+		},
+		{
+			name:         "client behind trusted proxy",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"198.51.100.1"},
+			expectedIP:   "198.51.100.1",
+		},
+		{
+			name:         "spoofed hops left of the first untrusted address are ignored",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1, 192.168.1.1"},
+			expectedIP:   "198.51.100.1",
+		},
+		{
+			name:         "chain split over several headers",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"198.51.100.1", "10.1.1.1"},
+			expectedIP:   "198.51.100.1",
+		},
+		{
+			name:         "only trusted proxies",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"10.0.0.5, 10.0.0.3"},
+			expectedIP:   "10.0.0.5",
+		},
+		{
+			name:         "garbage hop stops the walk",
+			remoteAddr:   "10.0.0.2:5000",
+			forwardedFor: []string{"198.51.100.1, unknown"},
+			expectedIP:   "10.0.0.2",
+		},
+		{
+			name:       "ipv6 peer",
+			remoteAddr: "[2001:db8::1]:5000",
+			expectedIP: "2001:db8::1",
+		},
+	}
+
+	resolver := NewClientIPResolver(trustedProxies)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/short", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for _, forwardedFor := range tc.forwardedFor {
+				req.Header.Add(forwardedForHeader, forwardedFor)
+			}
+
+			assert.Equal(t, tc.expectedIP, resolver.ClientIP(req))
+		})
+	}
+}
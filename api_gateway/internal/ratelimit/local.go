@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// localLimiter keeps a token bucket per key in memory.
+// When maxKeys buckets exist the least recently used one is evicted,
+// a client coming back after eviction starts with a full bucket.
+type localLimiter struct {
+	mu      sync.Mutex
+	maxKeys int
+	buckets map[string]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+type localBucket struct {
+	key     string
+	limit   Limit
+	limiter *rate.Limiter
+}
+
+func NewLocalLimiter(maxKeys int) Limiter {
+	return newLocalLimiter(maxKeys, time.Now)
+}
+
+func newLocalLimiter(maxKeys int, now func() time.Time) *localLimiter {
+	return &localLimiter{
+		maxKeys: maxKeys,
+		buckets: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     now,
+	}
+}
+
+func (l *localLimiter) Allow(_ context.Context, key string, limit Limit) (Decision, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter := l.bucket(key, limit)
+	now := l.now()
+
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return Decision{Allowed: false, Limit: limit.BurstSize}, nil
+	}
+
+	decision := Decision{
+		Allowed: true,
+		Limit:   limit.BurstSize,
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		decision.Allowed = false
+		decision.RetryAfter = delay
+	}
+
+	tokens := limiter.TokensAt(now)
+	decision.Remaining = max(int(math.Floor(tokens)), 0)
+	if limit.TokensPerSecond > 0 {
+		missingTokens := float64(limit.BurstSize) - tokens
+		decision.ResetAfter = time.Duration(missingTokens / limit.TokensPerSecond * float64(time.Second))
+	}
+
+	return decision, nil
+}
+
+// bucket returns the limiter of key marking it as the most recently used
+func (l *localLimiter) bucket(key string, limit Limit) *rate.Limiter {
+	if elem, ok := l.buckets[key]; ok {
+		bucket := elem.Value.(*localBucket)
+		if bucket.limit == limit {
+			l.lru.MoveToFront(elem)
+			return bucket.limiter
+		}
+		l.lru.Remove(elem)
+		delete(l.buckets, key)
+	}
+
+	for l.maxKeys > 0 && l.lru.Len() >= l.maxKeys {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*localBucket).key)
+	}
+
+	bucket := &localBucket{
+		key:     key,
+		limit:   limit,
+		limiter: rate.NewLimiter(rate.Limit(limit.TokensPerSecond), limit.BurstSize),
+	}
+	l.buckets[key] = l.lru.PushFront(bucket)
+
+	return bucket.limiter
+}
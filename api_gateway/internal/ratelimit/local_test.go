@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalLimiterAllow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newLocalLimiter(10, func() time.Time { return now })
+	limit := Limit{TokensPerSecond: 1, BurstSize: 2}
+
+	decision, err := limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Second}, decision)
+
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allowed: true, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second}, decision)
+
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		Decision{Allowed: false, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second, RetryAfter: time.Second},
+		decision,
+	)
+
+	// Other clients have their own buckets
+	decision, err = limiter.Allow(context.Background(), "other", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	now = now.Add(time.Second)
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestLocalLimiterEviction(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newLocalLimiter(2, func() time.Time { return now })
+	limit := Limit{TokensPerSecond: 1, BurstSize: 1}
+
+	for _, key := range []string{"first", "second", "first", "third"} {
+		_, err := limiter.Allow(context.Background(), key, limit)
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, limiter.buckets, 2)
+	assert.Contains(t, limiter.buckets, "first")
+	assert.Contains(t, limiter.buckets, "third")
+	assert.NotContains(t, limiter.buckets, "second")
+
+	// Evicted client starts with a full bucket
+	decision, err := limiter.Allow(context.Background(), "second", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.NotContains(t, limiter.buckets, "first")
+}
+
+func TestLocalLimiterLimitChange(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newLocalLimiter(10, func() time.Time { return now })
+
+	decision, err := limiter.Allow(context.Background(), "client", Limit{TokensPerSecond: 1, BurstSize: 1})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	decision, err = limiter.Allow(context.Background(), "client", Limit{TokensPerSecond: 1, BurstSize: 5})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 5, decision.Limit)
+}
@@ -0,0 +1,26 @@
+package ratelimit
+
+// Policy picks the limit of a request
+type Policy struct {
+	Anonymous     Limit
+	Authenticated Limit
+	// Routes override Anonymous and Authenticated for the named routes
+	Routes map[string]Limit
+	// AuthenticatedRoutes override Routes for authenticated callers
+	AuthenticatedRoutes map[string]Limit
+}
+
+func (p Policy) LimitFor(route string, authenticated bool) Limit {
+	if authenticated {
+		if limit, ok := p.AuthenticatedRoutes[route]; ok {
+			return limit
+		}
+	}
+	if limit, ok := p.Routes[route]; ok {
+		return limit
+	}
+	if authenticated {
+		return p.Authenticated
+	}
+	return p.Anonymous
+}
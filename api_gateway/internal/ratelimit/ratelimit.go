@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Backends the limiter state is kept in
+const (
+	BackendLocal = "local"
+	BackendRedis = "redis"
+)
+
+// Limit is the token bucket every client gets
+type Limit struct {
+	TokensPerSecond float64
+	BurstSize       int
+}
+
+// Decision describes the state of the client bucket after a request
+type Decision struct {
+	Allowed bool
+	// Limit is the bucket size
+	Limit     int
+	Remaining int
+	// ResetAfter is the time until the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is the time until the next request is allowed, zero for allowed requests
+	RetryAfter time.Duration
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name Limiter
+type Limiter interface {
+	// Allow takes a token from the bucket of key
+	Allow(ctx context.Context, key string, limit Limit) (Decision, error)
+}
@@ -0,0 +1,153 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyPrefix = "ratelimit:"
+	// redisRetryInterval is how long the fallback limiter is used after redis failed
+	redisRetryInterval = 5 * time.Second
+)
+
+// gcraScript implements generic cell rate algorithm.
+// The key stores theoretical arrival time of the next request, the clock is taken from redis
+// so that replicas with skewed clocks share the same budget.
+// Fractions are returned as strings because redis truncates lua numbers to integers.
+var gcraScript = redis.NewScript(`
+redis.replicate_commands()
+
+local key = KEYS[1]
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+
+local emission_interval = 1 / rate
+local burst_offset = emission_interval * burst
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local tat = tonumber(redis.call("GET", key))
+if not tat or tat < now then
+	tat = now
+end
+
+local new_tat = tat + emission_interval
+local diff = now - (new_tat - burst_offset)
+if diff < 0 then
+	return {0, 0, tostring(tat - now), tostring(-diff)}
+end
+
+local reset_after = new_tat - now
+redis.call("SET", key, tostring(new_tat), "PX", math.ceil(reset_after * 1000))
+
+return {1, math.floor(diff / emission_interval), tostring(reset_after), "0"}
+`)
+
+// redisLimiter shares the budget of a client between all gateway replicas.
+// While redis is unreachable requests are limited by the fallback limiter of this replica.
+type redisLimiter struct {
+	logger   *slog.Logger
+	client   *redis.Client
+	fallback Limiter
+	now      func() time.Time
+
+	mu sync.Mutex
+	// unavailableUntil is set after a redis failure to skip redis for redisRetryInterval
+	unavailableUntil time.Time
+}
+
+func NewRedisLimiter(logger *slog.Logger, client *redis.Client, fallback Limiter) Limiter {
+	return &redisLimiter{
+		logger:   logger,
+		client:   client,
+		fallback: fallback,
+		now:      time.Now,
+	}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit Limit) (Decision, error) {
+	if limit.TokensPerSecond <= 0 || limit.BurstSize <= 0 {
+		return Decision{Allowed: false, Limit: limit.BurstSize}, nil
+	}
+	if !l.redisAvailable() {
+		return l.fallback.Allow(ctx, key, limit)
+	}
+
+	decision, err := l.allow(ctx, key, limit)
+	if err != nil {
+		// A request that was canceled or timed out says nothing about redis,
+		// other clients keep the shared budget
+		if ctx.Err() == nil {
+			l.logger.Warn("redis rate limiter unavailable, using local limits", slog.String("error", err.Error()))
+			l.markUnavailable()
+		}
+		return l.fallback.Allow(ctx, key, limit)
+	}
+
+	return decision, nil
+}
+
+func (l *redisLimiter) allow(ctx context.Context, key string, limit Limit) (Decision, error) {
+	result, err := gcraScript.Run(
+		ctx, l.client, []string{redisKeyPrefix + key}, limit.BurstSize, limit.TokensPerSecond,
+	).Slice()
+	if err != nil {
+		return Decision{}, err
+	}
+	if len(result) != 4 {
+		return Decision{}, fmt.Errorf("unexpected gcra script result: %v", result)
+	}
+
+	allowed, _ := result[0].(int64)
+	remaining, _ := result[1].(int64)
+	resetAfter, err := parseSeconds(result[2])
+	if err != nil {
+		return Decision{}, err
+	}
+	retryAfter, err := parseSeconds(result[3])
+	if err != nil {
+		return Decision{}, err
+	}
+
+	return Decision{
+		Allowed:    allowed == 1,
+		Limit:      limit.BurstSize,
+		Remaining:  int(remaining),
+		ResetAfter: resetAfter,
+		RetryAfter: retryAfter,
+	}, nil
+}
+
+func (l *redisLimiter) redisAvailable() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return !l.now().Before(l.unavailableUntil)
+}
+
+func (l *redisLimiter) markUnavailable() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.unavailableUntil = l.now().Add(redisRetryInterval)
+}
+
+func parseSeconds(value any) (time.Duration, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected gcra script value: %v", value)
+	}
+	seconds, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
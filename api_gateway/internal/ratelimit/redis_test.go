@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisLimiter(t *testing.T, mr *miniredis.Miniredis, fallback Limiter) *redisLimiter {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRedisLimiter(logger, client, fallback).(*redisLimiter)
+}
+
+func TestRedisLimiterAllow(t *testing.T) {
+	mr := miniredis.RunT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mr.SetTime(now)
+
+	limiter := newTestRedisLimiter(t, mr, NewLocalLimiter(10))
+	limit := Limit{TokensPerSecond: 1, BurstSize: 2}
+
+	decision, err := limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Second}, decision)
+
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allowed: true, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second}, decision)
+
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		Decision{Allowed: false, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second, RetryAfter: time.Second},
+		decision,
+	)
+
+	decision, err = limiter.Allow(context.Background(), "other", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	mr.SetTime(now.Add(time.Second))
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+}
+
+func TestRedisLimiterSharedBetweenReplicas(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	firstReplica := newTestRedisLimiter(t, mr, NewLocalLimiter(10))
+	secondReplica := newTestRedisLimiter(t, mr, NewLocalLimiter(10))
+	limit := Limit{TokensPerSecond: 1, BurstSize: 1}
+
+	decision, err := firstReplica.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	decision, err = secondReplica.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+}
+
+func TestRedisLimiterFallback(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fallback := newLocalLimiter(10, func() time.Time { return now })
+	limiter := newTestRedisLimiter(t, mr, fallback)
+	limiter.now = func() time.Time { return now }
+	limit := Limit{TokensPerSecond: 1, BurstSize: 1}
+
+	mr.Close()
+
+	decision, err := limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Contains(t, fallback.buckets, "client")
+
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.False(t, limiter.redisAvailable())
+
+	require.NoError(t, mr.Restart())
+	now = now.Add(redisRetryInterval)
+
+	// Redis budget is used again once the retry interval passed
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.True(t, limiter.redisAvailable())
+}
+
+func TestRedisLimiterCanceledRequest(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	limiter := newTestRedisLimiter(t, mr, NewLocalLimiter(10))
+	limit := Limit{TokensPerSecond: 1, BurstSize: 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := limiter.Allow(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.True(t, limiter.redisAvailable())
+
+	// Other clients are still limited by the shared budget
+	decision, err := limiter.Allow(context.Background(), "other", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	decision, err = limiter.Allow(context.Background(), "other", limit)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+}
@@ -7,17 +7,30 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"api_gateway/errs"
 	"api_gateway/internal/client"
+	"api_gateway/internal/identity"
+	"api_gateway/internal/transport/rest/dto"
 	"api_gateway/internal/transport/rest/response"
 )
 
 const (
-	limitQueryParam = "limit"
-	pageQueryParam  = "page"
-	defaultPage     = 1
-	defaultLimit    = 10
+	limitQueryParam  = "limit"
+	pageQueryParam   = "page"
+	rankByQueryParam = "rank_by"
+	windowQueryParam = "window"
+	cursorQueryParam = "cursor"
+	defaultPage      = 1
+	defaultLimit     = 10
+
+	fromQueryParam        = "from"
+	toQueryParam          = "to"
+	granularityQueryParam = "granularity"
+	dimensionQueryParam   = "dimension"
+	defaultStatsRange     = 24 * time.Hour
+	maxBreakdownLimit     = 100
 )
 
 type AnalyticsHandler struct {
@@ -39,12 +52,17 @@ func NewAnalyticsHandler(
 //
 //	@Summary		Получение списка популярных url
 //	@Tags			url
-//	@Description	Принимает page и limit. Возвращает список популярных url. Поддерживает пагинацию
+//	@Description	Принимает limit, rank_by и window или from, to в формате RFC3339. Возвращает список популярных url постранично по курсору: next_cursor ответа передается в cursor для следующей страницы, страницы курсора за окно или диапазон не смещаются при новых переходах, за все время страницы читаются из текущих счетчиков и могут смещаться. Номер страницы page поддерживается для совместимости
 //	@ID				get-top-urls
 //	@Accept			json
 //	@Produce		json
-//	@Param			page	query		int	false	"Страница"
+//	@Param			page	query		int	false	"Страница, вместо cursor"
+//	@Param			cursor	query		string	false	"next_cursor предыдущей страницы"
 //	@Param			limit	query		int	false	"Максимальное количество url на странице"
+//	@Param			rank_by	query		string	false	"Порядок: follows по переходам или unique_follows по уникальным посетителям, по умолчанию follows"
+//	@Param			window	query		string	false	"Период: all за все время, hour, day или week за последний час, сутки или неделю, по умолчанию all"
+//	@Param			from	query		string	false	"Начало произвольного периода вместо window"
+//	@Param			to		query		string	false	"Конец произвольного периода, по умолчанию текущее время"
 //	@Success		200		{object}	dto.TopURLDataResponse
 //	@Failure		400		{object}	response.Body
 //	@Failure		500		{object}	response.Body
@@ -58,22 +76,80 @@ func (h *AnalyticsHandler) GetTopURLs(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Access-Control-Allow-Origin", origin)
 	w.Header().Add("Access-Control-Allow-Credentials", "true")
 
-	page, err := h.parseQueryParam(r, pageQueryParam, defaultPage)
+	limit, err := parseQueryParam(r, limitQueryParam, defaultLimit)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+
+	// Page numbers are used only when they are asked for, cursor paging is the default
+	page := 0
+	cursor := r.URL.Query().Get(cursorQueryParam)
+	if r.URL.Query().Has(pageQueryParam) {
+		if cursor != "" {
+			response.BadRequest(w, "page can not be combined with cursor")
+			return
+		}
+		page, err = parseQueryParam(r, pageQueryParam, defaultPage)
+		if err != nil {
+			response.BadRequest(w, err.Error())
+			return
+		}
+	}
+
+	ranking := r.URL.Query().Get(rankByQueryParam)
+	switch ranking {
+	case "":
+		ranking = dto.RankingFollows
+	case dto.RankingFollows, dto.RankingUniqueFollows:
+	default:
+		response.BadRequest(w, "rank_by must be follows or unique_follows")
+		return
+	}
+
+	window := r.URL.Query().Get(windowQueryParam)
+	switch window {
+	case "":
+		window = dto.WindowAllTime
+	case dto.WindowAllTime, dto.WindowHour, dto.WindowDay, dto.WindowWeek:
+	default:
+		response.BadRequest(w, "window must be all, hour, day or week")
+		return
+	}
+
+	from, err := parseTimeQueryParam(r, fromQueryParam, time.Time{})
 	if err != nil {
 		response.BadRequest(w, err.Error())
 		return
 	}
-	limit, err := h.parseQueryParam(r, limitQueryParam, defaultLimit)
+	to, err := parseTimeQueryParam(r, toQueryParam, time.Time{})
 	if err != nil {
 		response.BadRequest(w, err.Error())
 		return
 	}
+	if r.URL.Query().Has(windowQueryParam) && (!from.IsZero() || !to.IsZero()) {
+		response.BadRequest(w, "window can not be combined with from and to")
+		return
+	}
+	if from.IsZero() && !to.IsZero() {
+		response.BadRequest(w, "from is required when to is set")
+		return
+	}
 
-	topUrlsResp, err := h.analyticsClient.GetTopUrls(context.Background(), int64(page), int64(limit))
+	topUrlsResp, err := h.analyticsClient.GetTopUrls(context.Background(), dto.TopURLsParams{
+		Page:    int64(page),
+		Cursor:  cursor,
+		Limit:   int64(limit),
+		Ranking: ranking,
+		Window:  window,
+		From:    from,
+		To:      to,
+	})
 
 	if err != nil {
 		if errors.Is(err, errs.ErrInvalidArgument) {
 			response.BadRequest(w, "bad params")
+			return
 		}
 		response.InternalServerError(w)
 		return
@@ -89,7 +165,183 @@ func (h *AnalyticsHandler) GetTopURLs(w http.ResponseWriter, r *http.Request) {
 	response.WriteResponse(w, http.StatusOK, respBytes)
 }
 
-func (h *AnalyticsHandler) parseQueryParam(r *http.Request, key string, defaultValue int) (int, error) {
+// GetURLStats docs
+//
+//	@Summary		Получение статистики переходов по короткому url
+//	@Tags			url
+//	@Description	Принимает from, to в формате RFC3339 и granularity. Возвращает количество переходов в каждом интервале от from до to, интервалы без переходов имеют нулевое количество
+//	@ID				get-url-stats
+//	@Accept			json
+//	@Produce		json
+//	@Param			short_url	path		string	true	"Короткий url"
+//	@Param			from		query		string	false	"Начало периода, по умолчанию за сутки до to"
+//	@Param			to			query		string	false	"Конец периода, по умолчанию текущее время"
+//	@Param			granularity	query		string	false	"Размер интервала: minute, hour или day, по умолчанию hour"
+//	@Success		200			{object}	dto.URLStatsResponse
+//	@Failure		400			{object}	response.Body
+//	@Failure		401			{object}	response.Body
+//	@Failure		403			{object}	response.Body
+//	@Failure		404			{object}	response.Body
+//	@Failure		500			{object}	response.Body
+//	@Router			/api/urls/{short_url}/stats [get]
+func (h *AnalyticsHandler) GetURLStats(w http.ResponseWriter, r *http.Request) {
+	if identity.OwnerIDFromContext(r.Context()) == "" {
+		response.Unauthorized(w, "user is not identified")
+		return
+	}
+
+	from, to, err := parseTimeRange(r)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+
+	granularity := r.URL.Query().Get(granularityQueryParam)
+	switch granularity {
+	case "":
+		granularity = dto.GranularityHour
+	case dto.GranularityMinute, dto.GranularityHour, dto.GranularityDay:
+	default:
+		response.BadRequest(w, "granularity must be minute, hour or day")
+		return
+	}
+
+	urlStatsResp, err := h.analyticsClient.GetURLStats(r.Context(), dto.URLStatsParams{
+		ShortURL:    r.PathValue(shortUrlPathValue),
+		From:        from,
+		To:          to,
+		Granularity: granularity,
+	})
+	if err != nil {
+		writeURLAnalyticsError(w, err)
+		return
+	}
+
+	respBytes, err := json.Marshal(urlStatsResp)
+	if err != nil {
+		h.logger.Error(err.Error())
+		response.InternalServerError(w)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, respBytes)
+}
+
+// GetURLBreakdown docs
+//
+//	@Summary		Получение популярных значений измерения переходов по короткому url
+//	@Tags			url
+//	@Description	Принимает dimension, from, to в формате RFC3339 и limit. Возвращает значения измерения с наибольшим количеством переходов от from до to
+//	@ID				get-url-breakdown
+//	@Accept			json
+//	@Produce		json
+//	@Param			short_url	path		string	true	"Короткий url"
+//	@Param			dimension	query		string	true	"Измерение: referrer_host, country, device, os или browser"
+//	@Param			from		query		string	false	"Начало периода, по умолчанию за сутки до to"
+//	@Param			to			query		string	false	"Конец периода, по умолчанию текущее время"
+//	@Param			limit		query		int		false	"Максимальное количество значений, не больше 100"
+//	@Success		200			{object}	dto.URLBreakdownResponse
+//	@Failure		400			{object}	response.Body
+//	@Failure		401			{object}	response.Body
+//	@Failure		403			{object}	response.Body
+//	@Failure		404			{object}	response.Body
+//	@Failure		500			{object}	response.Body
+//	@Router			/api/urls/{short_url}/breakdown [get]
+func (h *AnalyticsHandler) GetURLBreakdown(w http.ResponseWriter, r *http.Request) {
+	if identity.OwnerIDFromContext(r.Context()) == "" {
+		response.Unauthorized(w, "user is not identified")
+		return
+	}
+
+	from, to, err := parseTimeRange(r)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+
+	dimension := r.URL.Query().Get(dimensionQueryParam)
+	switch dimension {
+	case dto.DimensionReferrerHost, dto.DimensionCountry, dto.DimensionDevice, dto.DimensionOS, dto.DimensionBrowser:
+	default:
+		response.BadRequest(w, "dimension must be referrer_host, country, device, os or browser")
+		return
+	}
+
+	limit, err := parseQueryParam(r, limitQueryParam, defaultLimit)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+	if limit < 0 || limit > maxBreakdownLimit {
+		response.BadRequest(w, "limit must be in 1..100")
+		return
+	}
+
+	urlBreakdownResp, err := h.analyticsClient.GetURLBreakdown(r.Context(), dto.URLBreakdownParams{
+		ShortURL:  r.PathValue(shortUrlPathValue),
+		Dimension: dimension,
+		From:      from,
+		To:        to,
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		writeURLAnalyticsError(w, err)
+		return
+	}
+
+	respBytes, err := json.Marshal(urlBreakdownResp)
+	if err != nil {
+		h.logger.Error(err.Error())
+		response.InternalServerError(w)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, respBytes)
+}
+
+// writeURLAnalyticsError writes errors of analytics of a single url
+func writeURLAnalyticsError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidArgument):
+		response.BadRequest(w, err.Error())
+	case errors.Is(err, errs.ErrUnauthenticated):
+		response.Unauthorized(w, "user is not identified")
+	case errors.Is(err, errs.ErrForbidden):
+		response.Forbidden(w, "short url belongs to another user")
+	case errors.Is(err, errs.ErrNotFound):
+		response.NotFound(w, "short url not found")
+	default:
+		response.InternalServerError(w)
+	}
+}
+
+// parseTimeRange reads from and to query params, the range is the last day by default
+func parseTimeRange(r *http.Request) (time.Time, time.Time, error) {
+	to, err := parseTimeQueryParam(r, toQueryParam, time.Now())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	from, err := parseTimeQueryParam(r, fromQueryParam, to.Add(-defaultStatsRange))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must be before to")
+	}
+
+	return from, to, nil
+}
+
+func parseTimeQueryParam(r *http.Request, key string, defaultValue time.Time) (time.Time, error) {
+	queryParam := r.URL.Query().Get(key)
+	if queryParam == "" {
+		return defaultValue, nil
+	}
+
+	return time.Parse(time.RFC3339, queryParam)
+}
+
+func parseQueryParam(r *http.Request, key string, defaultValue int) (int, error) {
 	queryParam := r.URL.Query().Get(key)
 
 	if queryParam == "" {
@@ -2,14 +2,18 @@ package rest
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"api_gateway/errs"
 	"api_gateway/internal/client"
 	"api_gateway/internal/client/mocks"
+	"api_gateway/internal/identity"
 	"api_gateway/internal/transport/rest/dto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -27,7 +31,7 @@ func TestGetTopURLs(t *testing.T) {
 			{LongURL: "http://test.long2", ShortURL: "short2", FollowCount: 20, CreateCount: 2},
 			{LongURL: "http://test.long3", ShortURL: "short3", FollowCount: 30, CreateCount: 3},
 		},
-		Pagination: dto.Pagination{
+		Pagination: &dto.Pagination{
 			Next:          2,
 			Previous:      0,
 			RecordPerPage: 3,
@@ -42,18 +46,28 @@ func TestGetTopURLs(t *testing.T) {
 		buildAnalyticsClient func() client.AnalyticsClient
 		page                 string
 		limit                string
+		rankBy               string
+		cursor               string
+		window               string
+		from                 string
+		to                   string
 		expectedCode         int
 	}{
 		{
-			name: "Get top urls without error. 200 OK",
+			name: "Get top urls by page number. 200 OK",
 			buildAnalyticsClient: func() client.AnalyticsClient {
 				mockClient := mocks.NewAnalyticsClient(t)
-				mockClient.On("GetTopUrls", mock.Anything, mock.Anything, mock.Anything).
+				mockClient.On("GetTopUrls", mock.Anything, dto.TopURLsParams{
+					Page:    defaultPage,
+					Limit:   defaultLimit,
+					Ranking: dto.RankingFollows,
+					Window:  dto.WindowAllTime,
+				}).
 					Return(testTopUrlDataResp, nil)
 
 				return mockClient
 			},
-			page:         "",
+			page:         "1",
 			limit:        "",
 			expectedCode: http.StatusOK,
 		},
@@ -61,7 +75,12 @@ func TestGetTopURLs(t *testing.T) {
 			name: "Get top urls when internal error happened. 500 Internal Server Error",
 			buildAnalyticsClient: func() client.AnalyticsClient {
 				mockClient := mocks.NewAnalyticsClient(t)
-				mockClient.On("GetTopUrls", mock.Anything, mock.Anything, mock.Anything).
+				mockClient.On("GetTopUrls", mock.Anything, dto.TopURLsParams{
+					Page:    0,
+					Limit:   defaultLimit,
+					Ranking: dto.RankingFollows,
+					Window:  dto.WindowAllTime,
+				}).
 					Return(dto.TopURLDataResponse{}, testErr)
 
 				return mockClient
@@ -70,6 +89,147 @@ func TestGetTopURLs(t *testing.T) {
 			limit:        "",
 			expectedCode: http.StatusInternalServerError,
 		},
+		{
+			name: "Get next page of top urls by cursor. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetTopUrls", mock.Anything, dto.TopURLsParams{
+					Page:    0,
+					Cursor:  "test-cursor",
+					Limit:   defaultLimit,
+					Ranking: dto.RankingFollows,
+					Window:  dto.WindowAllTime,
+				}).
+					Return(dto.TopURLDataResponse{TopURLData: testTopUrlDataResp.TopURLData, NextCursor: "next-cursor"}, nil)
+
+				return mockClient
+			},
+			cursor:       "test-cursor",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Page with cursor. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+
+				return mockClient
+			},
+			page:         "2",
+			cursor:       "test-cursor",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Get top urls ranked by unique follows. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetTopUrls", mock.Anything, mock.MatchedBy(func(params dto.TopURLsParams) bool {
+					return params.Ranking == dto.RankingUniqueFollows
+				})).
+					Return(testTopUrlDataResp, nil)
+
+				return mockClient
+			},
+			rankBy:       "unique_follows",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Invalid rank by. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+
+				return mockClient
+			},
+			rankBy:       "creates",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Get top urls of the last day. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetTopUrls", mock.Anything, mock.MatchedBy(func(params dto.TopURLsParams) bool {
+					return params.Window == dto.WindowDay && params.From.IsZero() && params.To.IsZero()
+				})).
+					Return(testTopUrlDataResp, nil)
+
+				return mockClient
+			},
+			window:       "day",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Get top urls of custom range. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetTopUrls", mock.Anything, dto.TopURLsParams{
+					Page:    0,
+					Limit:   defaultLimit,
+					Ranking: dto.RankingFollows,
+					Window:  dto.WindowAllTime,
+					From:    time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
+					To:      time.Date(2024, time.May, 2, 10, 0, 0, 0, time.UTC),
+				}).
+					Return(testTopUrlDataResp, nil)
+
+				return mockClient
+			},
+			from:         "2024-05-01T10:00:00Z",
+			to:           "2024-05-02T10:00:00Z",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Invalid range rejected by analytics. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetTopUrls", mock.Anything, mock.Anything).
+					Return(dto.TopURLDataResponse{}, errs.ErrInvalidArgument)
+
+				return mockClient
+			},
+			from:         "2024-05-02T10:00:00Z",
+			to:           "2024-05-01T10:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid window. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+
+				return mockClient
+			},
+			window:       "month",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Window with custom range. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+
+				return mockClient
+			},
+			window:       "week",
+			from:         "2024-05-01T10:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "To without from. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+
+				return mockClient
+			},
+			to:           "2024-05-01T10:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid from. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+
+				return mockClient
+			},
+			from:         "yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
 		{
 			name: "Invalid page. 400 Bad Request",
 			buildAnalyticsClient: func() client.AnalyticsClient {
@@ -109,6 +269,21 @@ func TestGetTopURLs(t *testing.T) {
 			if tc.limit != "" {
 				q.Add("limit", tc.limit)
 			}
+			if tc.rankBy != "" {
+				q.Add("rank_by", tc.rankBy)
+			}
+			if tc.cursor != "" {
+				q.Add("cursor", tc.cursor)
+			}
+			if tc.window != "" {
+				q.Add("window", tc.window)
+			}
+			if tc.from != "" {
+				q.Add("from", tc.from)
+			}
+			if tc.to != "" {
+				q.Add("to", tc.to)
+			}
 			req.URL.RawQuery = q.Encode()
 
 			rec := httptest.NewRecorder()
@@ -119,3 +294,322 @@ func TestGetTopURLs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetURLStats(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	basePath := "/api/urls/{short_url}/stats"
+
+	testFrom := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	testTo := testFrom.Add(2 * time.Hour)
+	testURLStatsResp := dto.URLStatsResponse{
+		ShortURL:    "short",
+		Granularity: dto.GranularityHour,
+		Buckets: []dto.URLStatsBucket{
+			{Start: testFrom, FollowCount: 3},
+			{Start: testFrom.Add(time.Hour), FollowCount: 0},
+		},
+		TotalFollowCount: 3,
+	}
+
+	testCases := []struct {
+		name                 string
+		buildAnalyticsClient func() client.AnalyticsClient
+		query                string
+		anonymous            bool
+		expectedCode         int
+	}{
+		{
+			name: "Anonymous user. 401 Unauthorized",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			anonymous:    true,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Get url stats without error. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLStats", mock.Anything, dto.URLStatsParams{
+					ShortURL:    "short",
+					From:        testFrom,
+					To:          testTo,
+					Granularity: dto.GranularityHour,
+				}).
+					Return(testURLStatsResp, nil)
+
+				return mockClient
+			},
+			query:        "from=2024-05-01T10:00:00Z&to=2024-05-01T12:00:00Z&granularity=hour",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Get url stats with default range and granularity. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLStats", mock.Anything, mock.MatchedBy(func(params dto.URLStatsParams) bool {
+					return params.Granularity == dto.GranularityHour && params.To.Sub(params.From) == 24*time.Hour
+				})).
+					Return(testURLStatsResp, nil)
+
+				return mockClient
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Invalid from. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			query:        "from=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "From after to. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			query:        "from=2024-05-01T12:00:00Z&to=2024-05-01T10:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown granularity. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			query:        "granularity=week",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Too many buckets. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(dto.URLStatsResponse{}, fmt.Errorf("%w: too many buckets", errs.ErrInvalidArgument))
+
+				return mockClient
+			},
+			query:        "from=2024-01-01T00:00:00Z&to=2024-05-01T00:00:00Z&granularity=minute",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Url of another user. 403 Forbidden",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(dto.URLStatsResponse{}, errs.ErrForbidden)
+
+				return mockClient
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "Url not found. 404 Not Found",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(dto.URLStatsResponse{}, errs.ErrNotFound)
+
+				return mockClient
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Get url stats when internal error happened. 500 Internal Server Error",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLStats", mock.Anything, mock.Anything).
+					Return(dto.URLStatsResponse{}, errs.ErrInternal)
+
+				return mockClient
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAnalyticsHandler(
+				logger,
+				tc.buildAnalyticsClient(),
+			)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc(basePath, handler.GetURLStats)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/urls/short/stats?"+tc.query, nil)
+			if !tc.anonymous {
+				req = req.WithContext(
+					identity.WithIdentity(req.Context(), identity.Identity{OwnerID: "owner"}),
+				)
+			}
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestGetURLBreakdown(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	basePath := "/api/urls/{short_url}/breakdown"
+
+	testFrom := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	testTo := testFrom.Add(24 * time.Hour)
+	testURLBreakdownResp := dto.URLBreakdownResponse{
+		ShortURL:  "short",
+		Dimension: dto.DimensionCountry,
+		Values: []dto.BreakdownValue{
+			{Value: "DE", FollowCount: 3},
+		},
+		OtherFollowCount: 2,
+		TotalFollowCount: 5,
+	}
+
+	testCases := []struct {
+		name                 string
+		buildAnalyticsClient func() client.AnalyticsClient
+		query                string
+		anonymous            bool
+		expectedCode         int
+	}{
+		{
+			name: "Anonymous user. 401 Unauthorized",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			query:        "dimension=country",
+			anonymous:    true,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Get url breakdown without error. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLBreakdown", mock.Anything, dto.URLBreakdownParams{
+					ShortURL:  "short",
+					Dimension: dto.DimensionCountry,
+					From:      testFrom,
+					To:        testTo,
+					Limit:     1,
+				}).
+					Return(testURLBreakdownResp, nil)
+
+				return mockClient
+			},
+			query:        "dimension=country&from=2024-05-01T00:00:00Z&to=2024-05-02T00:00:00Z&limit=1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Get url breakdown with default range and limit. 200 OK",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLBreakdown", mock.Anything, mock.MatchedBy(func(params dto.URLBreakdownParams) bool {
+					return params.Limit == defaultLimit && params.To.Sub(params.From) == 24*time.Hour
+				})).
+					Return(testURLBreakdownResp, nil)
+
+				return mockClient
+			},
+			query:        "dimension=browser",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Missing dimension. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown dimension. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			query:        "dimension=city",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Limit is too big. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			query:        "dimension=os&limit=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "From after to. 400 Bad Request",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				return mocks.NewAnalyticsClient(t)
+			},
+			query:        "dimension=device&from=2024-05-02T00:00:00Z&to=2024-05-01T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Url of another user. 403 Forbidden",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLBreakdown", mock.Anything, mock.Anything).
+					Return(dto.URLBreakdownResponse{}, errs.ErrForbidden)
+
+				return mockClient
+			},
+			query:        "dimension=country",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "Url not found. 404 Not Found",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLBreakdown", mock.Anything, mock.Anything).
+					Return(dto.URLBreakdownResponse{}, errs.ErrNotFound)
+
+				return mockClient
+			},
+			query:        "dimension=country",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Get url breakdown when internal error happened. 500 Internal Server Error",
+			buildAnalyticsClient: func() client.AnalyticsClient {
+				mockClient := mocks.NewAnalyticsClient(t)
+				mockClient.On("GetURLBreakdown", mock.Anything, mock.Anything).
+					Return(dto.URLBreakdownResponse{}, errs.ErrInternal)
+
+				return mockClient
+			},
+			query:        "dimension=referrer_host",
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAnalyticsHandler(
+				logger,
+				tc.buildAnalyticsClient(),
+			)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc(basePath, handler.GetURLBreakdown)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/urls/short/breakdown?"+tc.query, nil)
+			if !tc.anonymous {
+				req = req.WithContext(
+					identity.WithIdentity(req.Context(), identity.Identity{OwnerID: "owner"}),
+				)
+			}
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
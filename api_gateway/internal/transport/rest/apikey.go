@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"api_gateway/errs"
+	"api_gateway/internal/client"
+	"api_gateway/internal/transport/rest/dto"
+	"api_gateway/internal/transport/rest/response"
+)
+
+const apiKeyIDPathValue = "id"
+
+type APIKeyHandler struct {
+	logger       *slog.Logger
+	apiKeyClient client.APIKeyClient
+}
+
+func NewAPIKeyHandler(
+	logger *slog.Logger,
+	apiKeyClient client.APIKeyClient,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		logger:       logger,
+		apiKeyClient: apiKeyClient,
+	}
+}
+
+// IssueAPIKey docs
+//
+//	@Summary		Выпуск API ключа
+//	@Tags			admin
+//	@Description	Принимает id владельца и необязательное название ключа. Возвращает ключ, который больше нельзя получить повторно. Требует заголовок X-Admin-Token
+//	@ID				issue-api-key
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Admin-Token	header		string				true	"Токен администратора"
+//	@Param			input			body		dto.IssueAPIKeyData	true	"Владелец ключа"
+//	@Success		201				{object}	dto.APIKeyData
+//	@Failure		400,401			{object}	response.Body
+//	@Failure		500				{object}	response.Body
+//	@Router			/api/admin/api_keys [post]
+func (h *APIKeyHandler) IssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	var issueData dto.IssueAPIKeyData
+	err := json.NewDecoder(r.Body).Decode(&issueData)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+
+	apiKeyData, err := h.apiKeyClient.IssueAPIKey(r.Context(), issueData)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidArgument) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+
+		response.InternalServerError(w)
+		return
+	}
+
+	body, err := json.Marshal(apiKeyData)
+	if err != nil {
+		h.logger.Error(err.Error())
+		response.InternalServerError(w)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusCreated, body)
+}
+
+// RevokeAPIKey docs
+//
+//	@Summary		Отзыв API ключа
+//	@Tags			admin
+//	@Description	Принимает id ключа в path параметрах и отзывает его. Требует заголовок X-Admin-Token
+//	@ID				revoke-api-key
+//	@Param			X-Admin-Token	header	string	true	"Токен администратора"
+//	@Param			id				path	int		true	"id ключа"
+//	@Success		204
+//	@Failure		400,401,404	{object}	response.Body
+//	@Failure		500			{object}	response.Body
+//	@Router			/api/admin/api_keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue(apiKeyIDPathValue), 10, 64)
+	if err != nil {
+		response.BadRequest(w, "bad api key id")
+		return
+	}
+
+	err = h.apiKeyClient.RevokeAPIKey(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			response.NotFound(w, "api key not found")
+			return
+		}
+		if errors.Is(err, errs.ErrInvalidArgument) {
+			response.BadRequest(w, "bad api key id")
+			return
+		}
+
+		response.InternalServerError(w)
+		return
+	}
+
+	response.NoContent(w)
+}
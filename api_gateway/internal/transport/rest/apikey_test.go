@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"api_gateway/errs"
+	"api_gateway/internal/client"
+	"api_gateway/internal/client/mocks"
+	"api_gateway/internal/transport/rest/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIssueAPIKey(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	basePath := "/api/admin/api_keys"
+
+	testAPIKeyData := dto.APIKeyData{
+		ID:        1,
+		Key:       "cus_test",
+		OwnerID:   "tool",
+		Name:      "ci",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name              string
+		buildAPIKeyClient func() client.APIKeyClient
+		body              string
+		expectedCode      int
+	}{
+		{
+			name: "issue api key. 201 Created",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("IssueAPIKey", mock.Anything, dto.IssueAPIKeyData{OwnerID: "tool", Name: "ci"}).
+					Return(testAPIKeyData, nil)
+
+				return mockClient
+			},
+			body:         `{"owner_id": "tool", "name": "ci"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "bad json. 400 Bad Request",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				return mocks.NewAPIKeyClient(t)
+			},
+			body:         `{"owner_id": `,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "empty owner id. 400 Bad Request",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("IssueAPIKey", mock.Anything, dto.IssueAPIKeyData{}).
+					Return(dto.APIKeyData{}, errs.ErrInvalidArgument)
+
+				return mockClient
+			},
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("IssueAPIKey", mock.Anything, mock.Anything).
+					Return(dto.APIKeyData{}, testErr)
+
+				return mockClient
+			},
+			body:         `{"owner_id": "tool"}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAPIKeyHandler(logger, tc.buildAPIKeyClient())
+
+			req := httptest.NewRequest(http.MethodPost, basePath, bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			handler.IssueAPIKey(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			if rec.Code == http.StatusCreated {
+				apiKeyData := dto.APIKeyData{}
+				err := json.NewDecoder(rec.Body).Decode(&apiKeyData)
+				assert.NoError(t, err)
+				assert.Equal(t, testAPIKeyData.Key, apiKeyData.Key)
+				assert.Equal(t, testAPIKeyData.ID, apiKeyData.ID)
+			}
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	basePath := "/api/admin/api_keys"
+
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name              string
+		buildAPIKeyClient func() client.APIKeyClient
+		id                string
+		expectedCode      int
+	}{
+		{
+			name: "revoke api key. 204 No Content",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("RevokeAPIKey", mock.Anything, int64(1)).
+					Return(nil)
+
+				return mockClient
+			},
+			id:           "1",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "bad id. 400 Bad Request",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				return mocks.NewAPIKeyClient(t)
+			},
+			id:           "first",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "api key not found. 404 Not Found",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("RevokeAPIKey", mock.Anything, int64(2)).
+					Return(errs.ErrNotFound)
+
+				return mockClient
+			},
+			id:           "2",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("RevokeAPIKey", mock.Anything, int64(3)).
+					Return(testErr)
+
+				return mockClient
+			},
+			id:           "3",
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewAPIKeyHandler(logger, tc.buildAPIKeyClient())
+
+			path := fmt.Sprintf("%s/%s", basePath, tc.id)
+			req := httptest.NewRequest(http.MethodDelete, path, nil)
+			rec := httptest.NewRecorder()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /api/admin/api_keys/{id}", handler.RevokeAPIKey)
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type IssueAPIKeyData struct {
+	OwnerID string `json:"owner_id"`
+	Name    string `json:"name,omitempty"`
+}
+
+type APIKeyData struct {
+	ID int64 `json:"id"`
+	// Key is shown only once
+	Key       string    `json:"key"`
+	OwnerID   string    `json:"owner_id"`
+	Name      string    `json:"name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,22 +1,170 @@
 package dto
 
+import "time"
+
+// Rankings of top urls
+const (
+	RankingFollows       = "follows"
+	RankingUniqueFollows = "unique_follows"
+)
+
+// Windows of top urls, the counts are taken over the window ending now
+const (
+	WindowAllTime = "all"
+	WindowHour    = "hour"
+	WindowDay     = "day"
+	WindowWeek    = "week"
+)
+
+type TopURLsParams struct {
+	// Page 0 selects cursor paging, page numbers are kept for compatibility
+	Page    int64
+	Cursor  string
+	Limit   int64
+	Ranking string
+	Window  string
+	// From and To select a custom range instead of a window, they are zero when not set
+	From time.Time
+	To   time.Time
+}
+
 type TopURLData struct {
 	LongURL     string `json:"long_url"`
 	ShortURL    string `json:"short_url"`
 	FollowCount int64  `json:"follow_count"`
 	CreateCount int64  `json:"create_count"`
+	// UniqueFollowCount is an estimate of distinct visitors
+	UniqueFollowCount int64 `json:"unique_follow_count"`
 }
 
 type TopURLDataResponse struct {
 	TopURLData []TopURLData `json:"top_url_data"`
-	Pagination Pagination   `json:"pagination"`
+	// Pagination is only set for page numbers
+	Pagination *Pagination `json:"pagination,omitempty"`
+	// NextCursor is empty on the last page and for page numbers
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type LongURLData struct {
 	LongURL string `json:"long_url"`
+	Alias   string `json:"alias,omitempty"`
+	// ExpiresIn is a lifetime of the link in seconds
+	ExpiresIn int64      `json:"expires_in,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ClientInfo is the request of the client that follows a short url
+type ClientInfo struct {
+	Referrer       string
+	UserAgent      string
+	AcceptLanguage string
+	IP             string
 }
 
 type URlData struct {
-	LongURL  string `json:"long_url"`
-	ShortURL string `json:"short_url"`
+	LongURL   string     `json:"long_url"`
+	ShortURL  string     `json:"short_url"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type BulkLongURLData struct {
+	URLs []LongURLData `json:"urls"`
+}
+
+// BulkURLResult is either a short url or an error of a single url
+type BulkURLResult struct {
+	LongURL   string     `json:"long_url"`
+	ShortURL  string     `json:"short_url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Status is the http status /api/save_url responds with for the same url
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Err is the error of the url returned by UrlClient
+	Err error `json:"-"`
+}
+
+type BulkURLResponse struct {
+	Results []BulkURLResult `json:"results"`
+}
+
+type URLActiveData struct {
+	Active *bool `json:"active"`
+}
+
+type UpdateURLData struct {
+	LongURL string `json:"long_url"`
+}
+
+type URLInfo struct {
+	LongURL   string     `json:"long_url"`
+	ShortURL  string     `json:"short_url"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Active    bool       `json:"active"`
+}
+
+type URLListResponse struct {
+	URLs       []URLInfo  `json:"urls"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Granularities of url stats buckets
+const (
+	GranularityMinute = "minute"
+	GranularityHour   = "hour"
+	GranularityDay    = "day"
+)
+
+type URLStatsParams struct {
+	ShortURL    string
+	From        time.Time
+	To          time.Time
+	Granularity string
+}
+
+type URLStatsBucket struct {
+	Start             time.Time `json:"start"`
+	FollowCount       int64     `json:"follow_count"`
+	UniqueFollowCount int64     `json:"unique_follow_count"`
+}
+
+type URLStatsResponse struct {
+	ShortURL    string `json:"short_url"`
+	Granularity string `json:"granularity"`
+	// Buckets cover the whole range, buckets without follows have zero count
+	Buckets          []URLStatsBucket `json:"buckets"`
+	TotalFollowCount int64            `json:"total_follow_count"`
+	// TotalUniqueFollowCount counts a visitor once for the whole range
+	TotalUniqueFollowCount int64 `json:"total_unique_follow_count"`
+}
+
+// Dimensions of url breakdowns
+const (
+	DimensionReferrerHost = "referrer_host"
+	DimensionCountry      = "country"
+	DimensionDevice       = "device"
+	DimensionOS           = "os"
+	DimensionBrowser      = "browser"
+)
+
+type URLBreakdownParams struct {
+	ShortURL  string
+	Dimension string
+	From      time.Time
+	To        time.Time
+	Limit     int64
+}
+
+type BreakdownValue struct {
+	Value       string `json:"value"`
+	FollowCount int64  `json:"follow_count"`
+}
+
+type URLBreakdownResponse struct {
+	ShortURL  string           `json:"short_url"`
+	Dimension string           `json:"dimension"`
+	Values    []BreakdownValue `json:"values"`
+	// OtherFollowCount is the sum of values that did not make it to the top
+	OtherFollowCount int64 `json:"other_follow_count"`
+	TotalFollowCount int64 `json:"total_follow_count"`
 }
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"api_gateway/internal/transport/rest/response"
+)
+
+// AdminTokenHeader carries the admin token. It is separate from Authorization
+// so that admin calls are never mistaken for api key calls.
+const AdminTokenHeader = "X-Admin-Token"
+
+type AdminMiddleware struct {
+	adminToken string
+}
+
+func NewAdminMiddleware(adminToken string) *AdminMiddleware {
+	return &AdminMiddleware{
+		adminToken: adminToken,
+	}
+}
+
+func (m *AdminMiddleware) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(AdminTokenHeader)
+		if m.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.adminToken)) != 1 {
+			response.Unauthorized(w, "invalid admin token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
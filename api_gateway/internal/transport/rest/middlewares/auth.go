@@ -0,0 +1,104 @@
+package middlewares
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"api_gateway/errs"
+	"api_gateway/internal/auth"
+	"api_gateway/internal/client"
+	"api_gateway/internal/identity"
+	"api_gateway/internal/transport/rest/response"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+type AuthMiddleware struct {
+	logger       *slog.Logger
+	apiKeyClient client.APIKeyClient
+	// jwtVerifier is nil when jwt authentication is disabled
+	jwtVerifier auth.TokenVerifier
+}
+
+func NewAuthMiddleware(
+	logger *slog.Logger,
+	apiKeyClient client.APIKeyClient,
+	jwtVerifier auth.TokenVerifier,
+) *AuthMiddleware {
+	return &AuthMiddleware{
+		logger:       logger,
+		apiKeyClient: apiKeyClient,
+		jwtVerifier:  jwtVerifier,
+	}
+}
+
+// Authenticate attaches the caller of a valid api key or jwt to the request context.
+// Requests without Authorization header pass through as anonymous.
+func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get(authorizationHeader)
+		if authHeader == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key, ok := bearerToken(authHeader)
+		if !ok {
+			response.Unauthorized(w, "authorization header must be a bearer token")
+			return
+		}
+
+		if isJWT(key) {
+			m.authenticateJWT(w, r, next, key)
+			return
+		}
+
+		id, err := m.apiKeyClient.VerifyAPIKey(r.Context(), key)
+		if err != nil {
+			if errors.Is(err, errs.ErrUnauthenticated) {
+				response.Unauthorized(w, "invalid api key")
+				return
+			}
+
+			response.InternalServerError(w)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(identity.WithIdentity(r.Context(), id)))
+	})
+}
+
+func (m *AuthMiddleware) authenticateJWT(w http.ResponseWriter, r *http.Request, next http.Handler, token string) {
+	if m.jwtVerifier == nil {
+		response.Unauthorized(w, "jwt authentication is disabled")
+		return
+	}
+
+	id, err := m.jwtVerifier.Verify(token)
+	if err != nil {
+		m.logger.Debug(err.Error())
+		response.Unauthorized(w, "invalid token")
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(identity.WithIdentity(r.Context(), id)))
+}
+
+// isJWT tells compact jwt serialization apart from api keys which never contain dots
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+func bearerToken(authHeader string) (string, bool) {
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(authHeader[len(bearerPrefix):])
+	return token, token != ""
+}
@@ -0,0 +1,155 @@
+package middlewares
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"api_gateway/errs"
+	"api_gateway/internal/auth"
+	authmocks "api_gateway/internal/auth/mocks"
+	"api_gateway/internal/client"
+	"api_gateway/internal/client/mocks"
+	"api_gateway/internal/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticate(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+
+	testIdentity := identity.Identity{OwnerID: "tool", APIKeyID: 1}
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name              string
+		buildAPIKeyClient func() client.APIKeyClient
+		buildJWTVerifier  func() auth.TokenVerifier
+		authHeader        string
+		expectedCode      int
+		expectedOwnerID   string
+	}{
+		{
+			name: "no authorization header. anonymous",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				return mocks.NewAPIKeyClient(t)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "valid api key",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("VerifyAPIKey", mock.Anything, "cus_valid").
+					Return(testIdentity, nil)
+
+				return mockClient
+			},
+			authHeader:      "Bearer cus_valid",
+			expectedCode:    http.StatusOK,
+			expectedOwnerID: testIdentity.OwnerID,
+		},
+		{
+			name: "not a bearer token. 401 Unauthorized",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				return mocks.NewAPIKeyClient(t)
+			},
+			authHeader:   "Basic dXNlcjpwYXNz",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid api key. 401 Unauthorized",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("VerifyAPIKey", mock.Anything, "cus_revoked").
+					Return(identity.Identity{}, errs.ErrUnauthenticated)
+
+				return mockClient
+			},
+			authHeader:   "Bearer cus_revoked",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				mockClient := mocks.NewAPIKeyClient(t)
+				mockClient.On("VerifyAPIKey", mock.Anything, "cus_valid").
+					Return(identity.Identity{}, testErr)
+
+				return mockClient
+			},
+			authHeader:   "Bearer cus_valid",
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "valid jwt",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				return mocks.NewAPIKeyClient(t)
+			},
+			buildJWTVerifier: func() auth.TokenVerifier {
+				mockVerifier := authmocks.NewTokenVerifier(t)
+				mockVerifier.On("Verify", "header.claims.signature").
+					Return(identity.Identity{OwnerID: "user"}, nil)
+
+				return mockVerifier
+			},
+			authHeader:      "Bearer header.claims.signature",
+			expectedCode:    http.StatusOK,
+			expectedOwnerID: "user",
+		},
+		{
+			name: "invalid jwt. 401 Unauthorized",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				return mocks.NewAPIKeyClient(t)
+			},
+			buildJWTVerifier: func() auth.TokenVerifier {
+				mockVerifier := authmocks.NewTokenVerifier(t)
+				mockVerifier.On("Verify", "header.claims.signature").
+					Return(identity.Identity{}, errs.ErrUnauthenticated)
+
+				return mockVerifier
+			},
+			authHeader:   "Bearer header.claims.signature",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "jwt authentication disabled. 401 Unauthorized",
+			buildAPIKeyClient: func() client.APIKeyClient {
+				return mocks.NewAPIKeyClient(t)
+			},
+			authHeader:   "Bearer header.claims.signature",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var jwtVerifier auth.TokenVerifier
+			if tc.buildJWTVerifier != nil {
+				jwtVerifier = tc.buildJWTVerifier()
+			}
+			middleware := NewAuthMiddleware(logger, tc.buildAPIKeyClient(), jwtVerifier)
+
+			var gotOwnerID string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotOwnerID = identity.OwnerIDFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/me/urls", nil)
+			if tc.authHeader != "" {
+				req.Header.Set(authorizationHeader, tc.authHeader)
+			}
+			rec := httptest.NewRecorder()
+
+			middleware.Authenticate(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedOwnerID, gotOwnerID)
+		})
+	}
+}
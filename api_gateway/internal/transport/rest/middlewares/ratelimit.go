@@ -1,35 +1,98 @@
 package middlewares
 
 import (
+	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"api_gateway/internal/identity"
+	"api_gateway/internal/ratelimit"
 	"api_gateway/internal/transport/rest/response"
-	"golang.org/x/time/rate"
 )
 
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+)
+
+// RateLimiterMiddleware gives every client its own bucket on every route.
+// Authenticated callers are limited by their api key or subject, anonymous ones by ip.
 type RateLimiterMiddleware struct {
-	logger  *slog.Logger
-	limiter *rate.Limiter
+	logger           *slog.Logger
+	limiter          ratelimit.Limiter
+	policy           ratelimit.Policy
+	clientIPResolver *ratelimit.ClientIPResolver
+	// failOpen lets requests pass when the limiter fails instead of rejecting them
+	failOpen bool
 }
 
 func NewRateLimiterMiddleware(
 	logger *slog.Logger,
-	limiter *rate.Limiter,
+	limiter ratelimit.Limiter,
+	policy ratelimit.Policy,
+	clientIPResolver *ratelimit.ClientIPResolver,
+	failOpen bool,
 ) *RateLimiterMiddleware {
 	return &RateLimiterMiddleware{
-		logger:  logger,
-		limiter: limiter,
+		logger:           logger,
+		limiter:          limiter,
+		policy:           policy,
+		clientIPResolver: clientIPResolver,
+		failOpen:         failOpen,
 	}
 }
 
-func (m *RateLimiterMiddleware) RateLimit(next http.Handler) http.Handler {
+// RateLimit limits requests to the route. When the limiter fails requests
+// pass if the middleware fails open and get 503 otherwise.
+func (m *RateLimiterMiddleware) RateLimit(route string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if !m.limiter.Allow() {
-			response.TooManyRequests(w)
+		clientKey, authenticated := m.clientKey(r)
+		limit := m.policy.LimitFor(route, authenticated)
+
+		decision, err := m.limiter.Allow(r.Context(), fmt.Sprintf("%s:%s", route, clientKey), limit)
+		if err != nil {
+			m.logger.Error(
+				"rate limiter failed",
+				slog.String("route", route),
+				slog.Bool("fail_open", m.failOpen),
+				slog.String("error", err.Error()),
+			)
+			if !m.failOpen {
+				response.ServiceUnavailable(w)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set(rateLimitLimitHeader, strconv.Itoa(decision.Limit))
+		w.Header().Set(rateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+		w.Header().Set(rateLimitResetHeader, strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+
+		if !decision.Allowed {
+			response.TooManyRequests(w, ceilSeconds(decision.RetryAfter))
 			return
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+func (m *RateLimiterMiddleware) clientKey(r *http.Request) (string, bool) {
+	id, ok := identity.FromContext(r.Context())
+	if !ok {
+		return "ip:" + m.clientIPResolver.ClientIP(r), false
+	}
+	if id.APIKeyID != 0 {
+		return "api_key:" + strconv.FormatInt(id.APIKeyID, 10), true
+	}
+	return "owner:" + id.OwnerID, true
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
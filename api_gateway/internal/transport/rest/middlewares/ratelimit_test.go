@@ -0,0 +1,204 @@
+package middlewares
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"api_gateway/internal/identity"
+	"api_gateway/internal/ratelimit"
+	ratelimitmocks "api_gateway/internal/ratelimit/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimit(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+
+	policy := ratelimit.Policy{
+		Anonymous:     ratelimit.Limit{TokensPerSecond: 1, BurstSize: 10},
+		Authenticated: ratelimit.Limit{TokensPerSecond: 5, BurstSize: 50},
+		Routes: map[string]ratelimit.Limit{
+			"save_url":  {TokensPerSecond: 1, BurstSize: 2},
+			"save_urls": {TokensPerSecond: 1, BurstSize: 1},
+		},
+		AuthenticatedRoutes: map[string]ratelimit.Limit{
+			"save_url": {TokensPerSecond: 2, BurstSize: 20},
+		},
+	}
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name            string
+		buildLimiter    func() ratelimit.Limiter
+		route           string
+		identity        *identity.Identity
+		failClosed      bool
+		expectedCode    int
+		expectedHeaders map[string]string
+	}{
+		{
+			name: "anonymous client limited by ip",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, "follow_url:ip:203.0.113.7", policy.Anonymous).
+					Return(ratelimit.Decision{Allowed: true, Limit: 10, Remaining: 9, ResetAfter: time.Second}, nil)
+
+				return mockLimiter
+			},
+			route:        "follow_url",
+			expectedCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				rateLimitLimitHeader:     "10",
+				rateLimitRemainingHeader: "9",
+				rateLimitResetHeader:     "1",
+			},
+		},
+		{
+			name: "route limit overrides anonymous limit",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, "save_url:ip:203.0.113.7", policy.Routes["save_url"]).
+					Return(ratelimit.Decision{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Second}, nil)
+
+				return mockLimiter
+			},
+			route:        "save_url",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "caller limited by api key",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, "follow_url:api_key:7", policy.Authenticated).
+					Return(ratelimit.Decision{Allowed: true, Limit: 50, Remaining: 49}, nil)
+
+				return mockLimiter
+			},
+			route:        "follow_url",
+			identity:     &identity.Identity{OwnerID: "tool", APIKeyID: 7},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "route limit overrides api key limit",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, "save_urls:api_key:7", policy.Routes["save_urls"]).
+					Return(ratelimit.Decision{Allowed: true, Limit: 1, Remaining: 0}, nil)
+
+				return mockLimiter
+			},
+			route:        "save_urls",
+			identity:     &identity.Identity{OwnerID: "tool", APIKeyID: 7},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "authenticated route limit overrides route limit",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, "save_url:api_key:7", policy.AuthenticatedRoutes["save_url"]).
+					Return(ratelimit.Decision{Allowed: true, Limit: 20, Remaining: 19}, nil)
+
+				return mockLimiter
+			},
+			route:        "save_url",
+			identity:     &identity.Identity{OwnerID: "tool", APIKeyID: 7},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "caller limited by jwt subject",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, "list_urls:owner:user", policy.Authenticated).
+					Return(ratelimit.Decision{Allowed: true, Limit: 50, Remaining: 49}, nil)
+
+				return mockLimiter
+			},
+			route:        "list_urls",
+			identity:     &identity.Identity{OwnerID: "user"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "bucket is empty. 429 Too Many Requests",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).
+					Return(ratelimit.Decision{
+						Allowed:    false,
+						Limit:      10,
+						Remaining:  0,
+						ResetAfter: 10 * time.Second,
+						RetryAfter: 1500 * time.Millisecond,
+					}, nil)
+
+				return mockLimiter
+			},
+			route:        "follow_url",
+			expectedCode: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				rateLimitLimitHeader:     "10",
+				rateLimitRemainingHeader: "0",
+				rateLimitResetHeader:     "10",
+				"Retry-After":            "2",
+			},
+		},
+		{
+			name: "limiter failed. request passes",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).
+					Return(ratelimit.Decision{}, testErr)
+
+				return mockLimiter
+			},
+			route:        "follow_url",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "limiter failed. 503 Service Unavailable",
+			buildLimiter: func() ratelimit.Limiter {
+				mockLimiter := ratelimitmocks.NewLimiter(t)
+				mockLimiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).
+					Return(ratelimit.Decision{}, testErr)
+
+				return mockLimiter
+			},
+			route:        "follow_url",
+			failClosed:   true,
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			middleware := NewRateLimiterMiddleware(
+				logger,
+				tc.buildLimiter(),
+				policy,
+				ratelimit.NewClientIPResolver(nil),
+				!tc.failClosed,
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/short", nil)
+			req.RemoteAddr = "203.0.113.7:5000"
+			if tc.identity != nil {
+				req = req.WithContext(identity.WithIdentity(req.Context(), *tc.identity))
+			}
+			rec := httptest.NewRecorder()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			middleware.RateLimit(tc.route, next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			for header, value := range tc.expectedHeaders {
+				assert.Equal(t, value, rec.Header().Get(header), header)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 )
 
 type Body struct {
@@ -41,10 +42,30 @@ func BadRequest(w http.ResponseWriter, text string) {
 	WriteMessage(w, http.StatusBadRequest, text)
 }
 
+func Unauthorized(w http.ResponseWriter, text string) {
+	WriteMessage(w, http.StatusUnauthorized, text)
+}
+
+func Forbidden(w http.ResponseWriter, text string) {
+	WriteMessage(w, http.StatusForbidden, text)
+}
+
 func NotFound(w http.ResponseWriter, text string) {
 	WriteMessage(w, http.StatusNotFound, text)
 }
 
+func Conflict(w http.ResponseWriter, text string) {
+	WriteMessage(w, http.StatusConflict, text)
+}
+
+func Gone(w http.ResponseWriter, text string) {
+	WriteMessage(w, http.StatusGone, text)
+}
+
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func OKMessage(w http.ResponseWriter, text string) {
 	WriteMessage(w, http.StatusOK, text)
 }
@@ -53,6 +74,12 @@ func InternalServerError(w http.ResponseWriter) {
 	WriteMessage(w, http.StatusInternalServerError, "Internal server error")
 }
 
-func TooManyRequests(w http.ResponseWriter) {
+func ServiceUnavailable(w http.ResponseWriter) {
+	WriteMessage(w, http.StatusServiceUnavailable, "Service unavailable, try again later.")
+}
+
+// TooManyRequests asks the client to come back in retryAfter seconds
+func TooManyRequests(w http.ResponseWriter, retryAfter int) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
 	WriteMessage(w, http.StatusTooManyRequests, "The API is at capacity, try again later.")
 }
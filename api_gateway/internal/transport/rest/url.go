@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +9,8 @@ import (
 
 	"api_gateway/errs"
 	"api_gateway/internal/client"
+	"api_gateway/internal/identity"
+	"api_gateway/internal/ratelimit"
 	"api_gateway/internal/transport/rest/dto"
 	"api_gateway/internal/transport/rest/response"
 )
@@ -17,23 +18,29 @@ import (
 const (
 	shortUrlPathValue = "short_url"
 	serverProtocol    = "http"
+
+	// maxBulkURLs is the largest batch accepted by /api/save_urls
+	maxBulkURLs = 1000
 )
 
 type URLHandler struct {
-	logger       *slog.Logger
-	urlClient    client.UrlClient
-	serverDomain string
+	logger           *slog.Logger
+	urlClient        client.UrlClient
+	clientIPResolver *ratelimit.ClientIPResolver
+	serverDomain     string
 }
 
 func NewURLHandler(
 	logger *slog.Logger,
 	urlClient client.UrlClient,
+	clientIPResolver *ratelimit.ClientIPResolver,
 	serverDomain string,
 ) *URLHandler {
 	return &URLHandler{
-		logger:       logger,
-		urlClient:    urlClient,
-		serverDomain: serverDomain,
+		logger:           logger,
+		urlClient:        urlClient,
+		clientIPResolver: clientIPResolver,
+		serverDomain:     serverDomain,
 	}
 }
 
@@ -43,9 +50,13 @@ func NewURLHandler(
 //	@Tags			url
 //	@Description	Принимает короткую ссылку в path параметрах и производит редирект на исходную ссылку
 //	@ID				follow-url
-//	@Param			id	query	string	true	"короткая ссылка"
+//	@Param			id				query	string	true	"короткая ссылка"
+//	@Param			Referer			header	string	false	"источник перехода, сохраняется в событии перехода"
+//	@Param			User-Agent		header	string	false	"клиент, сохраняется в событии перехода"
+//	@Param			Accept-Language	header	string	false	"языки клиента, сохраняются в событии перехода"
 //	@Success		302
 //	@Failure		400,404	{object}	response.Body
+//	@Failure		410		{object}	response.Body
 //	@Failure		500		{object}	response.Body
 //	@Router			/{short_url} [get]
 func (h *URLHandler) FollowUrl(w http.ResponseWriter, r *http.Request) {
@@ -54,12 +65,23 @@ func (h *URLHandler) FollowUrl(w http.ResponseWriter, r *http.Request) {
 
 	shortUrl := r.PathValue(shortUrlPathValue)
 
-	longUrl, err := h.urlClient.FollowUrl(context.Background(), shortUrl)
+	clientInfo := dto.ClientInfo{
+		Referrer:       r.Referer(),
+		UserAgent:      r.UserAgent(),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+		IP:             h.clientIPResolver.ClientIP(r),
+	}
+
+	longUrl, err := h.urlClient.FollowUrl(r.Context(), shortUrl, clientInfo)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
 			response.NotFound(w, "short url not found")
 			return
 		}
+		if errors.Is(err, errs.ErrGone) {
+			response.Gone(w, "short url is no longer available")
+			return
+		}
 		if errors.Is(err, errs.ErrInvalidArgument) {
 			response.BadRequest(w, "bad short url")
 			return
@@ -76,13 +98,14 @@ func (h *URLHandler) FollowUrl(w http.ResponseWriter, r *http.Request) {
 //
 //	@Summary		Создание и сохранение короткой ссылки по исходной ссылки
 //	@Tags			url
-//	@Description	Принимает исходную ссылку, создает короткую ссылку и возвращает короткую ссылку
+//	@Description	Принимает исходную ссылку, необязательный alias и срок жизни ссылки (expires_in в секундах или expires_at), создает короткую ссылку и возвращает короткую ссылку
 //	@ID				save-url
 //	@Accept			json
 //	@Produce		json
 //	@Param			input	body		dto.LongURLData	true	"Длинная ссылка"
 //	@Success		200		{object}	dto.URlData
 //	@Failure		400		{object}	response.Body
+//	@Failure		409		{object}	response.Body
 //	@Failure		500		{object}	response.Body
 //	@Router			/api/save_url [post]
 func (h *URLHandler) SaveURL(w http.ResponseWriter, r *http.Request) {
@@ -101,21 +124,260 @@ func (h *URLHandler) SaveURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shortURLRaw, err := h.urlClient.ShortenUrl(context.Background(), longURLData.LongURL)
+	if longURLData.ExpiresIn != 0 && longURLData.ExpiresAt != nil {
+		response.BadRequest(w, "only one of expires_in and expires_at can be set")
+		return
+	}
+
+	urlData, err := h.urlClient.ShortenUrl(r.Context(), longURLData)
 	if err != nil {
 		if errors.Is(err, errs.ErrInvalidArgument) {
 			response.BadRequest(w, err.Error())
 			return
 		}
+		if errors.Is(err, errs.ErrAlreadyExists) {
+			response.Conflict(w, "alias already exists")
+			return
+		}
+		response.InternalServerError(w)
+		return
+	}
+
+	urlData.LongURL = longURLData.LongURL
+	urlData.ShortURL = h.fullShortURL(urlData.ShortURL)
+	urlBody, err := json.Marshal(urlData)
+	if err != nil {
+		h.logger.Error(err.Error())
+		response.InternalServerError(w)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, urlBody)
+}
+
+// SaveURLs docs
+//
+//	@Summary		Создание коротких ссылок для списка исходных ссылок
+//	@Tags			url
+//	@Description	Принимает до 1000 исходных ссылок с теми же параметрами, что и /api/save_url. Возвращает результат для каждой ссылки в порядке запроса: короткую ссылку или ошибку и статус, с которым ответил бы /api/save_url
+//	@ID				save-urls
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		dto.BulkLongURLData	true	"Список длинных ссылок"
+//	@Success		200		{object}	dto.BulkURLResponse
+//	@Failure		400		{object}	response.Body
+//	@Failure		500		{object}	response.Body
+//	@Router			/api/save_urls [post]
+func (h *URLHandler) SaveURLs(w http.ResponseWriter, r *http.Request) {
+	var bulkData dto.BulkLongURLData
+	err := json.NewDecoder(r.Body).Decode(&bulkData)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+
+	if len(bulkData.URLs) == 0 || len(bulkData.URLs) > maxBulkURLs {
+		response.BadRequest(w, fmt.Sprintf("urls must contain from 1 to %d items", maxBulkURLs))
+		return
+	}
+
+	results := make([]dto.BulkURLResult, len(bulkData.URLs))
+	longURLsData := make([]dto.LongURLData, 0, len(bulkData.URLs))
+	longURLsIndexes := make([]int, 0, len(bulkData.URLs))
+	for i, longURLData := range bulkData.URLs {
+		if longURLData.ExpiresIn != 0 && longURLData.ExpiresAt != nil {
+			results[i] = dto.BulkURLResult{
+				LongURL: longURLData.LongURL,
+				Err:     fmt.Errorf("%w: only one of expires_in and expires_at can be set", errs.ErrInvalidArgument),
+			}
+			continue
+		}
+		longURLsData = append(longURLsData, longURLData)
+		longURLsIndexes = append(longURLsIndexes, i)
+	}
+
+	if len(longURLsData) > 0 {
+		clientResults, err := h.urlClient.ShortenUrls(r.Context(), longURLsData)
+		if err != nil {
+			if errors.Is(err, errs.ErrInvalidArgument) {
+				response.BadRequest(w, err.Error())
+				return
+			}
+			response.InternalServerError(w)
+			return
+		}
+		for k, clientResult := range clientResults {
+			results[longURLsIndexes[k]] = clientResult
+		}
+	}
+
+	for i := range results {
+		h.fillBulkURLResult(&results[i])
+	}
+
+	body, err := json.Marshal(dto.BulkURLResponse{Results: results})
+	if err != nil {
+		h.logger.Error(err.Error())
+		response.InternalServerError(w)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, body)
+}
+
+// fillBulkURLResult sets the status and the message the way SaveURL reports them
+func (h *URLHandler) fillBulkURLResult(result *dto.BulkURLResult) {
+	switch {
+	case result.Err == nil:
+		result.Status = http.StatusOK
+		result.ShortURL = h.fullShortURL(result.ShortURL)
+	case errors.Is(result.Err, errs.ErrInvalidArgument):
+		result.Status = http.StatusBadRequest
+		result.Error = result.Err.Error()
+	case errors.Is(result.Err, errs.ErrAlreadyExists):
+		result.Status = http.StatusConflict
+		result.Error = "alias already exists"
+	default:
+		result.Status = http.StatusInternalServerError
+		result.Error = "Internal server error"
+	}
+}
+
+// DeleteURL docs
+//
+//	@Summary		Удаление короткой ссылки
+//	@Tags			url
+//	@Description	Принимает короткую ссылку в path параметрах и удаляет ее
+//	@ID				delete-url
+//	@Param			short_url	path	string	true	"короткая ссылка"
+//	@Success		204
+//	@Failure		400,404	{object}	response.Body
+//	@Failure		401		{object}	response.Body
+//	@Failure		500		{object}	response.Body
+//	@Router			/api/urls/{short_url} [delete]
+func (h *URLHandler) DeleteURL(w http.ResponseWriter, r *http.Request) {
+	if identity.OwnerIDFromContext(r.Context()) == "" {
+		response.Unauthorized(w, "user is not identified")
+		return
+	}
+
+	shortUrl := r.PathValue(shortUrlPathValue)
+
+	err := h.urlClient.DeleteUrl(r.Context(), shortUrl)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			response.NotFound(w, "short url not found")
+			return
+		}
+		if errors.Is(err, errs.ErrInvalidArgument) {
+			response.BadRequest(w, "bad short url")
+			return
+		}
+
+		response.InternalServerError(w)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// SetURLActive docs
+//
+//	@Summary		Активация и деактивация короткой ссылки
+//	@Tags			url
+//	@Description	Принимает короткую ссылку в path параметрах и флаг active в теле запроса. Деактивированная ссылка перестает перенаправлять на исходную ссылку
+//	@ID				set-url-active
+//	@Accept			json
+//	@Param			short_url	path	string				true	"короткая ссылка"
+//	@Param			input		body	dto.URLActiveData	true	"Флаг активности ссылки"
+//	@Success		204
+//	@Failure		400,404	{object}	response.Body
+//	@Failure		401		{object}	response.Body
+//	@Failure		500		{object}	response.Body
+//	@Router			/api/urls/{short_url} [patch]
+func (h *URLHandler) SetURLActive(w http.ResponseWriter, r *http.Request) {
+	if identity.OwnerIDFromContext(r.Context()) == "" {
+		response.Unauthorized(w, "user is not identified")
+		return
+	}
+
+	shortUrl := r.PathValue(shortUrlPathValue)
+
+	var activeData dto.URLActiveData
+	err := json.NewDecoder(r.Body).Decode(&activeData)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+	if activeData.Active == nil {
+		response.BadRequest(w, "active is required")
+		return
+	}
+
+	err = h.urlClient.SetUrlActive(r.Context(), shortUrl, *activeData.Active)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			response.NotFound(w, "short url not found")
+			return
+		}
+		if errors.Is(err, errs.ErrInvalidArgument) {
+			response.BadRequest(w, "bad short url")
+			return
+		}
+
 		response.InternalServerError(w)
 		return
 	}
 
-	shortURL := fmt.Sprintf("%s://%s/%s", serverProtocol, h.serverDomain, shortURLRaw)
-	urlData := dto.URlData{
-		LongURL:  longURLData.LongURL,
-		ShortURL: shortURL,
+	response.NoContent(w)
+}
+
+// UpdateURL docs
+//
+//	@Summary		Изменение исходной ссылки для короткой ссылки
+//	@Tags			url
+//	@Description	Принимает короткую ссылку в path параметрах и новую исходную ссылку в теле запроса. Короткая ссылка начинает перенаправлять на новую исходную ссылку
+//	@ID				update-url
+//	@Accept			json
+//	@Produce		json
+//	@Param			short_url	path		string				true	"короткая ссылка"
+//	@Param			input		body		dto.UpdateURLData	true	"Новая исходная ссылка"
+//	@Success		200			{object}	dto.URlData
+//	@Failure		400,404		{object}	response.Body
+//	@Failure		401			{object}	response.Body
+//	@Failure		500			{object}	response.Body
+//	@Router			/api/urls/{short_url} [put]
+func (h *URLHandler) UpdateURL(w http.ResponseWriter, r *http.Request) {
+	if identity.OwnerIDFromContext(r.Context()) == "" {
+		response.Unauthorized(w, "user is not identified")
+		return
+	}
+
+	shortUrl := r.PathValue(shortUrlPathValue)
+
+	var updateData dto.UpdateURLData
+	err := json.NewDecoder(r.Body).Decode(&updateData)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
 	}
+
+	urlData, err := h.urlClient.UpdateUrl(r.Context(), shortUrl, updateData.LongURL)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			response.NotFound(w, "short url not found")
+			return
+		}
+		if errors.Is(err, errs.ErrInvalidArgument) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+
+		response.InternalServerError(w)
+		return
+	}
+
+	urlData.ShortURL = h.fullShortURL(urlData.ShortURL)
 	urlBody, err := json.Marshal(urlData)
 	if err != nil {
 		h.logger.Error(err.Error())
@@ -126,6 +388,66 @@ func (h *URLHandler) SaveURL(w http.ResponseWriter, r *http.Request) {
 	response.WriteResponse(w, http.StatusOK, urlBody)
 }
 
+// ListURLs docs
+//
+//	@Summary		Получение списка ссылок текущего пользователя
+//	@Tags			url
+//	@Description	Принимает page и limit. Возвращает ссылки, созданные текущим пользователем. Поддерживает пагинацию
+//	@ID				list-urls
+//	@Produce		json
+//	@Param			page	query		int	false	"Страница"
+//	@Param			limit	query		int	false	"Максимальное количество url на странице"
+//	@Success		200		{object}	dto.URLListResponse
+//	@Failure		400		{object}	response.Body
+//	@Failure		401		{object}	response.Body
+//	@Failure		500		{object}	response.Body
+//	@Router			/api/me/urls [get]
+func (h *URLHandler) ListURLs(w http.ResponseWriter, r *http.Request) {
+	if identity.OwnerIDFromContext(r.Context()) == "" {
+		response.Unauthorized(w, "user is not identified")
+		return
+	}
+
+	page, err := parseQueryParam(r, pageQueryParam, defaultPage)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+	limit, err := parseQueryParam(r, limitQueryParam, defaultLimit)
+	if err != nil {
+		response.BadRequest(w, err.Error())
+		return
+	}
+
+	listResp, err := h.urlClient.ListUrls(r.Context(), int64(page), int64(limit))
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidArgument) {
+			response.BadRequest(w, "bad params")
+			return
+		}
+
+		response.InternalServerError(w)
+		return
+	}
+
+	for i := range listResp.URLs {
+		listResp.URLs[i].ShortURL = h.fullShortURL(listResp.URLs[i].ShortURL)
+	}
+
+	respBytes, err := json.Marshal(listResp)
+	if err != nil {
+		h.logger.Error(err.Error())
+		response.InternalServerError(w)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, respBytes)
+}
+
+func (h *URLHandler) fullShortURL(shortURL string) string {
+	return fmt.Sprintf("%s://%s/%s", serverProtocol, h.serverDomain, shortURL)
+}
+
 // SaveURLOptions docs
 //
 //	@Summary		Получение описания параметров соединения с сервером
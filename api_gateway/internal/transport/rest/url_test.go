@@ -9,11 +9,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"api_gateway/errs"
 	"api_gateway/internal/client"
 	"api_gateway/internal/client/mocks"
+	"api_gateway/internal/identity"
+	"api_gateway/internal/ratelimit"
 	"api_gateway/internal/transport/rest/dto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -38,7 +42,12 @@ func TestFollowUrl(t *testing.T) {
 			name: "redirect by short url. 302 Status found",
 			buildUrlClient: func() client.UrlClient {
 				mockClient := mocks.NewUrlClient(t)
-				mockClient.On("FollowUrl", mock.Anything, mock.Anything).
+				mockClient.On("FollowUrl", mock.Anything, "short", dto.ClientInfo{
+					Referrer:       "https://referrer.test",
+					UserAgent:      "test-agent",
+					AcceptLanguage: "en-US",
+					IP:             "192.0.2.1",
+				}).
 					Return("http://test.long", nil)
 
 				return mockClient
@@ -59,7 +68,7 @@ func TestFollowUrl(t *testing.T) {
 			name: "short url not found. 404 Not found",
 			buildUrlClient: func() client.UrlClient {
 				mockClient := mocks.NewUrlClient(t)
-				mockClient.On("FollowUrl", mock.Anything, mock.Anything).
+				mockClient.On("FollowUrl", mock.Anything, mock.Anything, mock.Anything).
 					Return("", errs.ErrNotFound)
 
 				return mockClient
@@ -67,11 +76,23 @@ func TestFollowUrl(t *testing.T) {
 			shortURL:     "test",
 			expectedCode: http.StatusNotFound,
 		},
+		{
+			name: "short url expired. 410 Gone",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("FollowUrl", mock.Anything, mock.Anything, mock.Anything).
+					Return("", errs.ErrGone)
+
+				return mockClient
+			},
+			shortURL:     "test",
+			expectedCode: http.StatusGone,
+		},
 		{
 			name: "unexpected error. 500 Internal Server Error",
 			buildUrlClient: func() client.UrlClient {
 				mockClient := mocks.NewUrlClient(t)
-				mockClient.On("FollowUrl", mock.Anything, mock.Anything).
+				mockClient.On("FollowUrl", mock.Anything, mock.Anything, mock.Anything).
 					Return("", testErr)
 
 				return mockClient
@@ -86,11 +107,15 @@ func TestFollowUrl(t *testing.T) {
 			handler := NewURLHandler(
 				logger,
 				tc.buildUrlClient(),
+				ratelimit.NewClientIPResolver(nil),
 				serverDomain,
 			)
 
 			path := fmt.Sprintf("%s/%s", basePath, tc.shortURL)
 			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Referer", "https://referrer.test")
+			req.Header.Set("User-Agent", "test-agent")
+			req.Header.Set("Accept-Language", "en-US")
 			rec := httptest.NewRecorder()
 
 			mux := http.NewServeMux()
@@ -112,21 +137,23 @@ func TestSaveURL(t *testing.T) {
 
 	testErr := errors.New("test error")
 	serverDomain := "test:8000"
+	testExpiresAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
 
 	testCases := []struct {
-		name             string
-		buildUrlClient   func() client.UrlClient
-		longUrlRequest   dto.LongURLData
-		expectedCode     int
-		expectedLongURL  string
-		expectedShortURL string
+		name              string
+		buildUrlClient    func() client.UrlClient
+		longUrlRequest    dto.LongURLData
+		expectedCode      int
+		expectedLongURL   string
+		expectedShortURL  string
+		expectedExpiresAt *time.Time
 	}{
 		{
 			name: "Empty long url. 400 Bad Request",
 			buildUrlClient: func() client.UrlClient {
 				mockClient := mocks.NewUrlClient(t)
 				mockClient.On("ShortenUrl", mock.Anything, mock.Anything).
-					Return("", errs.ErrInvalidArgument)
+					Return(dto.URlData{}, errs.ErrInvalidArgument)
 
 				return mockClient
 			},
@@ -142,7 +169,7 @@ func TestSaveURL(t *testing.T) {
 			buildUrlClient: func() client.UrlClient {
 				mockClient := mocks.NewUrlClient(t)
 				mockClient.On("ShortenUrl", mock.Anything, mock.Anything).
-					Return("short", nil)
+					Return(dto.URlData{ShortURL: "short"}, nil)
 
 				return mockClient
 			},
@@ -158,7 +185,7 @@ func TestSaveURL(t *testing.T) {
 			buildUrlClient: func() client.UrlClient {
 				mockClient := mocks.NewUrlClient(t)
 				mockClient.On("ShortenUrl", mock.Anything, mock.Anything).
-					Return("", testErr)
+					Return(dto.URlData{}, testErr)
 
 				return mockClient
 			},
@@ -169,6 +196,80 @@ func TestSaveURL(t *testing.T) {
 			expectedLongURL:  "",
 			expectedShortURL: "",
 		},
+		{
+			name: "Create short url with alias. 200 Status OK",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ShortenUrl", mock.Anything, dto.LongURLData{
+					LongURL: "http://test.long",
+					Alias:   "spring-sale",
+				}).
+					Return(dto.URlData{ShortURL: "spring-sale"}, nil)
+
+				return mockClient
+			},
+			longUrlRequest: dto.LongURLData{
+				LongURL: "http://test.long",
+				Alias:   "spring-sale",
+			},
+			expectedCode:     http.StatusOK,
+			expectedLongURL:  "http://test.long",
+			expectedShortURL: fmt.Sprintf("%s://%s/%s", serverProtocol, serverDomain, "spring-sale"),
+		},
+		{
+			name: "Alias already exists. 409 Conflict",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ShortenUrl", mock.Anything, mock.Anything).
+					Return(dto.URlData{}, errs.ErrAlreadyExists)
+
+				return mockClient
+			},
+			longUrlRequest: dto.LongURLData{
+				LongURL: "http://test.long",
+				Alias:   "spring-sale",
+			},
+			expectedCode:     http.StatusConflict,
+			expectedLongURL:  "",
+			expectedShortURL: "",
+		},
+		{
+			name: "Create short url with expiry. 200 Status OK",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ShortenUrl", mock.Anything, dto.LongURLData{
+					LongURL:   "http://test.long",
+					ExpiresIn: 3600,
+				}).
+					Return(dto.URlData{ShortURL: "short", ExpiresAt: &testExpiresAt}, nil)
+
+				return mockClient
+			},
+			longUrlRequest: dto.LongURLData{
+				LongURL:   "http://test.long",
+				ExpiresIn: 3600,
+			},
+			expectedCode:      http.StatusOK,
+			expectedLongURL:   "http://test.long",
+			expectedShortURL:  fmt.Sprintf("%s://%s/%s", serverProtocol, serverDomain, "short"),
+			expectedExpiresAt: &testExpiresAt,
+		},
+		{
+			name: "Both expires in and expires at. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+
+				return mockClient
+			},
+			longUrlRequest: dto.LongURLData{
+				LongURL:   "http://test.long",
+				ExpiresIn: 3600,
+				ExpiresAt: &testExpiresAt,
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedLongURL:  "",
+			expectedShortURL: "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -176,6 +277,7 @@ func TestSaveURL(t *testing.T) {
 			handler := NewURLHandler(
 				logger,
 				tc.buildUrlClient(),
+				ratelimit.NewClientIPResolver(nil),
 				serverDomain,
 			)
 
@@ -196,6 +298,592 @@ func TestSaveURL(t *testing.T) {
 
 				assert.Equal(t, tc.expectedLongURL, urlData.LongURL)
 				assert.Equal(t, tc.expectedShortURL, urlData.ShortURL)
+				assert.Equal(t, tc.expectedExpiresAt, urlData.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestSaveURLs(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	serverDomain := "test"
+	basePath := "/api/save_urls"
+
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name             string
+		buildUrlClient   func() client.UrlClient
+		body             string
+		expectedCode     int
+		expectedStatuses []int
+		expectedShortURL string
+	}{
+		{
+			name: "save urls with per item errors. 200 OK",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ShortenUrls", mock.Anything, []dto.LongURLData{
+					{LongURL: "http://first"},
+					{LongURL: "http://second", Alias: "taken"},
+					{LongURL: ""},
+				}).
+					Return([]dto.BulkURLResult{
+						{LongURL: "http://first", ShortURL: "short"},
+						{LongURL: "http://second", Err: errs.ErrAlreadyExists},
+						{LongURL: "", Err: fmt.Errorf("%w: empty long url", errs.ErrInvalidArgument)},
+					}, nil)
+
+				return mockClient
+			},
+			body: `{"urls": [
+				{"long_url": "http://first"},
+				{"long_url": "http://second", "alias": "taken"},
+				{"long_url": "http://third", "expires_in": 10, "expires_at": "2030-01-01T00:00:00Z"},
+				{"long_url": ""}
+			]}`,
+			expectedCode: http.StatusOK,
+			expectedStatuses: []int{
+				http.StatusOK, http.StatusConflict, http.StatusBadRequest, http.StatusBadRequest,
+			},
+			expectedShortURL: "http://test/short",
+		},
+		{
+			name: "empty batch. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			body:         `{"urls": []}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "too large batch. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			body:         fmt.Sprintf(`{"urls": [%s{"long_url": "http://last"}]}`, strings.Repeat(`{"long_url": "http://a"},`, maxBulkURLs)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "bad json. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			body:         `{"urls": [`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ShortenUrls", mock.Anything, mock.Anything).
+					Return(nil, testErr)
+
+				return mockClient
+			},
+			body:         `{"urls": [{"long_url": "http://first"}]}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewURLHandler(
+				logger,
+				tc.buildUrlClient(),
+				ratelimit.NewClientIPResolver(nil),
+				serverDomain,
+			)
+
+			req := httptest.NewRequest(http.MethodPost, basePath, bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			handler.SaveURLs(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			if rec.Code == http.StatusOK {
+				bulkResp := dto.BulkURLResponse{}
+				err := json.NewDecoder(rec.Body).Decode(&bulkResp)
+				assert.NoError(t, err)
+
+				assert.Len(t, bulkResp.Results, len(tc.expectedStatuses))
+				for i, expectedStatus := range tc.expectedStatuses {
+					assert.Equal(t, expectedStatus, bulkResp.Results[i].Status)
+					if expectedStatus != http.StatusOK {
+						assert.NotEmpty(t, bulkResp.Results[i].Error)
+					}
+				}
+				assert.Equal(t, tc.expectedShortURL, bulkResp.Results[0].ShortURL)
+				assert.Equal(t, "http://third", bulkResp.Results[2].LongURL)
+			}
+		})
+	}
+}
+
+func TestDeleteURL(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	serverDomain := "test"
+	basePath := "/api/urls"
+
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name           string
+		buildUrlClient func() client.UrlClient
+		anonymous      bool
+		shortURL       string
+		expectedCode   int
+	}{
+		{
+			name: "anonymous user. 401 Unauthorized",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			anonymous:    true,
+			shortURL:     "short",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "delete short url. 204 No Content",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("DeleteUrl", mock.Anything, "short").
+					Return(nil)
+
+				return mockClient
+			},
+			shortURL:     "short",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "short url not found. 404 Not found",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("DeleteUrl", mock.Anything, "test").
+					Return(errs.ErrNotFound)
+
+				return mockClient
+			},
+			shortURL:     "test",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("DeleteUrl", mock.Anything, "test").
+					Return(testErr)
+
+				return mockClient
+			},
+			shortURL:     "test",
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewURLHandler(
+				logger,
+				tc.buildUrlClient(),
+				ratelimit.NewClientIPResolver(nil),
+				serverDomain,
+			)
+
+			path := fmt.Sprintf("%s/%s", basePath, tc.shortURL)
+			req := httptest.NewRequest(http.MethodDelete, path, nil)
+			if !tc.anonymous {
+				req = req.WithContext(
+					identity.WithIdentity(req.Context(), identity.Identity{OwnerID: "owner"}),
+				)
+			}
+			rec := httptest.NewRecorder()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /api/urls/{short_url}", handler.DeleteURL)
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestSetURLActive(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	serverDomain := "test"
+	basePath := "/api/urls"
+
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name           string
+		buildUrlClient func() client.UrlClient
+		anonymous      bool
+		shortURL       string
+		body           string
+		expectedCode   int
+	}{
+		{
+			name: "anonymous user. 401 Unauthorized",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			anonymous:    true,
+			shortURL:     "short",
+			body:         `{"active":false}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "deactivate short url. 204 No Content",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("SetUrlActive", mock.Anything, "short", false).
+					Return(nil)
+
+				return mockClient
+			},
+			shortURL:     "short",
+			body:         `{"active": false}`,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "activate short url. 204 No Content",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("SetUrlActive", mock.Anything, "short", true).
+					Return(nil)
+
+				return mockClient
+			},
+			shortURL:     "short",
+			body:         `{"active": true}`,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "active is missing. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			shortURL:     "short",
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid body. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			shortURL:     "short",
+			body:         `{"active": "yes"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "short url not found. 404 Not found",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("SetUrlActive", mock.Anything, "test", false).
+					Return(errs.ErrNotFound)
+
+				return mockClient
+			},
+			shortURL:     "test",
+			body:         `{"active": false}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("SetUrlActive", mock.Anything, "test", false).
+					Return(testErr)
+
+				return mockClient
+			},
+			shortURL:     "test",
+			body:         `{"active": false}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewURLHandler(
+				logger,
+				tc.buildUrlClient(),
+				ratelimit.NewClientIPResolver(nil),
+				serverDomain,
+			)
+
+			path := fmt.Sprintf("%s/%s", basePath, tc.shortURL)
+			req := httptest.NewRequest(http.MethodPatch, path, bytes.NewBufferString(tc.body))
+			if !tc.anonymous {
+				req = req.WithContext(
+					identity.WithIdentity(req.Context(), identity.Identity{OwnerID: "owner"}),
+				)
+			}
+			rec := httptest.NewRecorder()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PATCH /api/urls/{short_url}", handler.SetURLActive)
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestUpdateURL(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	serverDomain := "test"
+	basePath := "/api/urls"
+
+	testLongURL := "http://test.new"
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name             string
+		buildUrlClient   func() client.UrlClient
+		anonymous        bool
+		shortURL         string
+		body             string
+		expectedCode     int
+		expectedShortURL string
+	}{
+		{
+			name: "anonymous user. 401 Unauthorized",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			anonymous:    true,
+			shortURL:     "short",
+			body:         `{"long_url":"https://new.longurl"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "update long url. 200 Status OK",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("UpdateUrl", mock.Anything, "short", testLongURL).
+					Return(dto.URlData{LongURL: testLongURL, ShortURL: "short"}, nil)
+
+				return mockClient
+			},
+			shortURL:         "short",
+			body:             `{"long_url": "http://test.new"}`,
+			expectedCode:     http.StatusOK,
+			expectedShortURL: "http://test/short",
+		},
+		{
+			name: "invalid body. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			shortURL:     "short",
+			body:         `{"long_url": 1}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "empty long url. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("UpdateUrl", mock.Anything, "short", "").
+					Return(dto.URlData{}, errs.ErrInvalidArgument)
+
+				return mockClient
+			},
+			shortURL:     "short",
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "short url not found. 404 Not found",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("UpdateUrl", mock.Anything, "test", testLongURL).
+					Return(dto.URlData{}, errs.ErrNotFound)
+
+				return mockClient
+			},
+			shortURL:     "test",
+			body:         `{"long_url": "http://test.new"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("UpdateUrl", mock.Anything, "test", testLongURL).
+					Return(dto.URlData{}, testErr)
+
+				return mockClient
+			},
+			shortURL:     "test",
+			body:         `{"long_url": "http://test.new"}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewURLHandler(
+				logger,
+				tc.buildUrlClient(),
+				ratelimit.NewClientIPResolver(nil),
+				serverDomain,
+			)
+
+			path := fmt.Sprintf("%s/%s", basePath, tc.shortURL)
+			req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(tc.body))
+			if !tc.anonymous {
+				req = req.WithContext(
+					identity.WithIdentity(req.Context(), identity.Identity{OwnerID: "owner"}),
+				)
+			}
+			rec := httptest.NewRecorder()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /api/urls/{short_url}", handler.UpdateURL)
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			if rec.Code == http.StatusOK {
+				urlData := dto.URlData{}
+				err := json.NewDecoder(rec.Body).Decode(&urlData)
+				assert.NoError(t, err)
+
+				assert.Equal(t, testLongURL, urlData.LongURL)
+				assert.Equal(t, tc.expectedShortURL, urlData.ShortURL)
+			}
+		})
+	}
+}
+
+func TestListURLs(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	serverDomain := "test"
+	basePath := "/api/me/urls"
+
+	testOwnerID := "owner"
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name             string
+		buildUrlClient   func() client.UrlClient
+		ownerID          string
+		query            string
+		expectedCode     int
+		expectedShortURL string
+	}{
+		{
+			name: "list urls of identified user. 200 Status OK",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ListUrls", mock.Anything, int64(2), int64(5)).
+					Return(dto.URLListResponse{
+						URLs: []dto.URLInfo{{LongURL: "http://test.long", ShortURL: "short", Active: true}},
+						Pagination: dto.Pagination{
+							Previous:      1,
+							RecordPerPage: 5,
+							CurrentPage:   2,
+							TotalPage:     2,
+						},
+					}, nil)
+
+				return mockClient
+			},
+			ownerID:          testOwnerID,
+			query:            "?page=2&limit=5",
+			expectedCode:     http.StatusOK,
+			expectedShortURL: "http://test/short",
+		},
+		{
+			name: "anonymous user. 401 Unauthorized",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			ownerID:      "",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "bad page. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				return mocks.NewUrlClient(t)
+			},
+			ownerID:      testOwnerID,
+			query:        "?page=first",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "limit out of range. 400 Bad Request",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ListUrls", mock.Anything, int64(1), int64(1000)).
+					Return(dto.URLListResponse{}, errs.ErrInvalidArgument)
+
+				return mockClient
+			},
+			ownerID:      testOwnerID,
+			query:        "?limit=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "unexpected error. 500 Internal Server Error",
+			buildUrlClient: func() client.UrlClient {
+				mockClient := mocks.NewUrlClient(t)
+				mockClient.On("ListUrls", mock.Anything, int64(1), int64(10)).
+					Return(dto.URLListResponse{}, testErr)
+
+				return mockClient
+			},
+			ownerID:      testOwnerID,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewURLHandler(
+				logger,
+				tc.buildUrlClient(),
+				ratelimit.NewClientIPResolver(nil),
+				serverDomain,
+			)
+
+			req := httptest.NewRequest(http.MethodGet, basePath+tc.query, nil)
+			if tc.ownerID != "" {
+				req = req.WithContext(
+					identity.WithIdentity(req.Context(), identity.Identity{OwnerID: tc.ownerID}),
+				)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ListURLs(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+
+			if rec.Code == http.StatusOK {
+				listResp := dto.URLListResponse{}
+				err := json.NewDecoder(rec.Body).Decode(&listResp)
+				assert.NoError(t, err)
+
+				assert.Len(t, listResp.URLs, 1)
+				assert.Equal(t, tc.expectedShortURL, listResp.URLs[0].ShortURL)
+				assert.Equal(t, 2, listResp.Pagination.CurrentPage)
 			}
 		})
 	}
@@ -215,6 +903,7 @@ func FuzzSaveURL(f *testing.F) {
 	handler := NewURLHandler(
 		logger,
 		mockClient,
+		ratelimit.NewClientIPResolver(nil),
 		serverDomain,
 	)
 
@@ -230,8 +919,8 @@ func FuzzSaveURL(f *testing.F) {
 	}
 
 	f.Fuzz(func(t *testing.T, data []byte) {
-		mockClient.On("ShortenUrl", mock.Anything, mock.AnythingOfType("string")).
-			Return(testShortURL, nil)
+		mockClient.On("ShortenUrl", mock.Anything, mock.AnythingOfType("dto.LongURLData")).
+			Return(dto.URlData{ShortURL: testShortURL}, nil)
 
 		req := httptest.NewRequest(http.MethodPost, basePath, bytes.NewBuffer(data))
 		rec := httptest.NewRecorder()
@@ -24,6 +24,8 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AnalyticsClient interface {
 	GetTopUrls(ctx context.Context, in *TopUrlsRequest, opts ...grpc.CallOption) (*TopUrlsResponse, error)
+	GetUrlStats(ctx context.Context, in *UrlStatsRequest, opts ...grpc.CallOption) (*UrlStatsResponse, error)
+	GetUrlBreakdown(ctx context.Context, in *UrlBreakdownRequest, opts ...grpc.CallOption) (*UrlBreakdownResponse, error)
 }
 
 type analyticsClient struct {
@@ -43,11 +45,31 @@ func (c *analyticsClient) GetTopUrls(ctx context.Context, in *TopUrlsRequest, op
 	return out, nil
 }
 
+func (c *analyticsClient) GetUrlStats(ctx context.Context, in *UrlStatsRequest, opts ...grpc.CallOption) (*UrlStatsResponse, error) {
+	out := new(UrlStatsResponse)
+	err := c.cc.Invoke(ctx, "/analytics.Analytics/GetUrlStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsClient) GetUrlBreakdown(ctx context.Context, in *UrlBreakdownRequest, opts ...grpc.CallOption) (*UrlBreakdownResponse, error) {
+	out := new(UrlBreakdownResponse)
+	err := c.cc.Invoke(ctx, "/analytics.Analytics/GetUrlBreakdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AnalyticsServer is the server API for Analytics service.
 // All implementations must embed UnimplementedAnalyticsServer
 // for forward compatibility
 type AnalyticsServer interface {
 	GetTopUrls(context.Context, *TopUrlsRequest) (*TopUrlsResponse, error)
+	GetUrlStats(context.Context, *UrlStatsRequest) (*UrlStatsResponse, error)
+	GetUrlBreakdown(context.Context, *UrlBreakdownRequest) (*UrlBreakdownResponse, error)
 	mustEmbedUnimplementedAnalyticsServer()
 }
 
@@ -58,6 +80,12 @@ type UnimplementedAnalyticsServer struct {
 func (UnimplementedAnalyticsServer) GetTopUrls(context.Context, *TopUrlsRequest) (*TopUrlsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTopUrls not implemented")
 }
+func (UnimplementedAnalyticsServer) GetUrlStats(context.Context, *UrlStatsRequest) (*UrlStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUrlStats not implemented")
+}
+func (UnimplementedAnalyticsServer) GetUrlBreakdown(context.Context, *UrlBreakdownRequest) (*UrlBreakdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUrlBreakdown not implemented")
+}
 func (UnimplementedAnalyticsServer) mustEmbedUnimplementedAnalyticsServer() {}
 
 // UnsafeAnalyticsServer may be embedded to opt out of forward compatibility for this service.
@@ -89,6 +117,42 @@ func _Analytics_GetTopUrls_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analytics_GetUrlStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UrlStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServer).GetUrlStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/analytics.Analytics/GetUrlStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServer).GetUrlStats(ctx, req.(*UrlStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Analytics_GetUrlBreakdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UrlBreakdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServer).GetUrlBreakdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/analytics.Analytics/GetUrlBreakdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServer).GetUrlBreakdown(ctx, req.(*UrlBreakdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Analytics_ServiceDesc is the grpc.ServiceDesc for Analytics service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -100,6 +164,14 @@ var Analytics_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTopUrls",
 			Handler:    _Analytics_GetTopUrls_Handler,
 		},
+		{
+			MethodName: "GetUrlStats",
+			Handler:    _Analytics_GetUrlStats_Handler,
+		},
+		{
+			MethodName: "GetUrlBreakdown",
+			Handler:    _Analytics_GetUrlBreakdown_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/proto/topurls.proto",
@@ -12,6 +12,8 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -27,6 +29,13 @@ type LongUrlRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	LongUrl string `protobuf:"bytes,1,opt,name=longUrl,proto3" json:"longUrl,omitempty"`
+	Alias   string `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Types that are assignable to Expiry:
+	//	*LongUrlRequest_ExpiresIn
+	//	*LongUrlRequest_ExpiresAt
+	Expiry isLongUrlRequest_Expiry `protobuf_oneof:"expiry"`
+	// ownerId is empty for anonymous links
+	OwnerId string `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
 }
 
 func (x *LongUrlRequest) Reset() {
@@ -68,32 +77,745 @@ func (x *LongUrlRequest) GetLongUrl() string {
 	return ""
 }
 
+func (x *LongUrlRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (m *LongUrlRequest) GetExpiry() isLongUrlRequest_Expiry {
+	if m != nil {
+		return m.Expiry
+	}
+	return nil
+}
+
+func (x *LongUrlRequest) GetExpiresIn() int64 {
+	if x, ok := x.GetExpiry().(*LongUrlRequest_ExpiresIn); ok {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *LongUrlRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x, ok := x.GetExpiry().(*LongUrlRequest_ExpiresAt); ok {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LongUrlRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type isLongUrlRequest_Expiry interface {
+	isLongUrlRequest_Expiry()
+}
+
+type LongUrlRequest_ExpiresIn struct {
+	// expiresIn is a lifetime of the link in seconds
+	ExpiresIn int64 `protobuf:"varint,3,opt,name=expiresIn,proto3,oneof"`
+}
+
+type LongUrlRequest_ExpiresAt struct {
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiresAt,proto3,oneof"`
+}
+
+func (*LongUrlRequest_ExpiresIn) isLongUrlRequest_Expiry() {}
+
+func (*LongUrlRequest_ExpiresAt) isLongUrlRequest_Expiry() {}
+
 type UrlDataResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LongUrl  string `protobuf:"bytes,1,opt,name=longUrl,proto3" json:"longUrl,omitempty"`
-	ShortUrl string `protobuf:"bytes,2,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	LongUrl   string                 `protobuf:"bytes,1,opt,name=longUrl,proto3" json:"longUrl,omitempty"`
+	ShortUrl  string                 `protobuf:"bytes,2,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+}
+
+func (x *UrlDataResponse) Reset() {
+	*x = UrlDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UrlDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UrlDataResponse) ProtoMessage() {}
+
+func (x *UrlDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UrlDataResponse.ProtoReflect.Descriptor instead.
+func (*UrlDataResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UrlDataResponse) GetLongUrl() string {
+	if x != nil {
+		return x.LongUrl
+	}
+	return ""
+}
+
+func (x *UrlDataResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UrlDataResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ShortenUrlsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// urls are validated one by one, an invalid url fails only its own result.
+	// ownerId of the urls is ignored, all of them belong to ownerId of the request
+	Urls []*LongUrlRequest `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	// ownerId is empty for anonymous links
+	OwnerId string `protobuf:"bytes,2,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+}
+
+func (x *ShortenUrlsRequest) Reset() {
+	*x = ShortenUrlsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShortenUrlsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenUrlsRequest) ProtoMessage() {}
+
+func (x *ShortenUrlsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortenUrlsRequest.ProtoReflect.Descriptor instead.
+func (*ShortenUrlsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ShortenUrlsRequest) GetUrls() []*LongUrlRequest {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *ShortenUrlsRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type ShortenUrlError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code is the grpc status code ShortenUrl fails with for the same url
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ShortenUrlError) Reset() {
+	*x = ShortenUrlError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShortenUrlError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenUrlError) ProtoMessage() {}
+
+func (x *ShortenUrlError) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortenUrlError.ProtoReflect.Descriptor instead.
+func (*ShortenUrlError) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ShortenUrlError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ShortenUrlError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ShortenUrlResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*ShortenUrlResult_Url
+	//	*ShortenUrlResult_Error
+	Result isShortenUrlResult_Result `protobuf_oneof:"result"`
+}
+
+func (x *ShortenUrlResult) Reset() {
+	*x = ShortenUrlResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShortenUrlResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenUrlResult) ProtoMessage() {}
+
+func (x *ShortenUrlResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortenUrlResult.ProtoReflect.Descriptor instead.
+func (*ShortenUrlResult) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{4}
+}
+
+func (m *ShortenUrlResult) GetResult() isShortenUrlResult_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *ShortenUrlResult) GetUrl() *UrlDataResponse {
+	if x, ok := x.GetResult().(*ShortenUrlResult_Url); ok {
+		return x.Url
+	}
+	return nil
+}
+
+func (x *ShortenUrlResult) GetError() *ShortenUrlError {
+	if x, ok := x.GetResult().(*ShortenUrlResult_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isShortenUrlResult_Result interface {
+	isShortenUrlResult_Result()
+}
+
+type ShortenUrlResult_Url struct {
+	Url *UrlDataResponse `protobuf:"bytes,1,opt,name=url,proto3,oneof"`
+}
+
+type ShortenUrlResult_Error struct {
+	Error *ShortenUrlError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ShortenUrlResult_Url) isShortenUrlResult_Result() {}
+
+func (*ShortenUrlResult_Error) isShortenUrlResult_Result() {}
+
+type ShortenUrlsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// results are in the order of urls in the request
+	Results []*ShortenUrlResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *ShortenUrlsResponse) Reset() {
+	*x = ShortenUrlsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShortenUrlsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenUrlsResponse) ProtoMessage() {}
+
+func (x *ShortenUrlsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortenUrlsResponse.ProtoReflect.Descriptor instead.
+func (*ShortenUrlsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ShortenUrlsResponse) GetResults() []*ShortenUrlResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ShortUrlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShortUrl string `protobuf:"bytes,1,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	// client that follows the short url, it is attached to the follow event
+	Client *ClientInfo `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+}
+
+func (x *ShortUrlRequest) Reset() {
+	*x = ShortUrlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShortUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortUrlRequest) ProtoMessage() {}
+
+func (x *ShortUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortUrlRequest.ProtoReflect.Descriptor instead.
+func (*ShortUrlRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ShortUrlRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *ShortUrlRequest) GetClient() *ClientInfo {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type ClientInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Referrer       string `protobuf:"bytes,1,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	UserAgent      string `protobuf:"bytes,2,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	AcceptLanguage string `protobuf:"bytes,3,opt,name=acceptLanguage,proto3" json:"acceptLanguage,omitempty"`
+	Ip             string `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+}
+
+func (x *ClientInfo) Reset() {
+	*x = ClientInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientInfo) ProtoMessage() {}
+
+func (x *ClientInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientInfo.ProtoReflect.Descriptor instead.
+func (*ClientInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ClientInfo) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetAcceptLanguage() string {
+	if x != nil {
+		return x.AcceptLanguage
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type LongUrlResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LongUrl string `protobuf:"bytes,1,opt,name=longUrl,proto3" json:"longUrl,omitempty"`
+}
+
+func (x *LongUrlResponse) Reset() {
+	*x = LongUrlResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LongUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LongUrlResponse) ProtoMessage() {}
+
+func (x *LongUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LongUrlResponse.ProtoReflect.Descriptor instead.
+func (*LongUrlResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LongUrlResponse) GetLongUrl() string {
+	if x != nil {
+		return x.LongUrl
+	}
+	return ""
+}
+
+type SetUrlActiveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShortUrl string `protobuf:"bytes,1,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	Active   bool   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	OwnerId  string `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+}
+
+func (x *SetUrlActiveRequest) Reset() {
+	*x = SetUrlActiveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUrlActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUrlActiveRequest) ProtoMessage() {}
+
+func (x *SetUrlActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUrlActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetUrlActiveRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetUrlActiveRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *SetUrlActiveRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *SetUrlActiveRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type UpdateUrlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShortUrl string `protobuf:"bytes,1,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	LongUrl  string `protobuf:"bytes,2,opt,name=longUrl,proto3" json:"longUrl,omitempty"`
+	OwnerId  string `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+}
+
+func (x *UpdateUrlRequest) Reset() {
+	*x = UpdateUrlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUrlRequest) ProtoMessage() {}
+
+func (x *UpdateUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUrlRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUrlRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateUrlRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateUrlRequest) GetLongUrl() string {
+	if x != nil {
+		return x.LongUrl
+	}
+	return ""
+}
+
+func (x *UpdateUrlRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type DeleteUrlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShortUrl string `protobuf:"bytes,1,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	OwnerId  string `protobuf:"bytes,2,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+}
+
+func (x *DeleteUrlRequest) Reset() {
+	*x = DeleteUrlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUrlRequest) ProtoMessage() {}
+
+func (x *DeleteUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUrlRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUrlRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteUrlRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *DeleteUrlRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type ListUrlsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerId string `protobuf:"bytes,1,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Page    int64  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit   int64  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-func (x *UrlDataResponse) Reset() {
-	*x = UrlDataResponse{}
+func (x *ListUrlsRequest) Reset() {
+	*x = ListUrlsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_url_proto_msgTypes[1]
+		mi := &file_pkg_proto_url_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UrlDataResponse) String() string {
+func (x *ListUrlsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UrlDataResponse) ProtoMessage() {}
+func (*ListUrlsRequest) ProtoMessage() {}
 
-func (x *UrlDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_url_proto_msgTypes[1]
+func (x *ListUrlsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -104,50 +826,61 @@ func (x *UrlDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UrlDataResponse.ProtoReflect.Descriptor instead.
-func (*UrlDataResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_url_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListUrlsRequest.ProtoReflect.Descriptor instead.
+func (*ListUrlsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UrlDataResponse) GetLongUrl() string {
+func (x *ListUrlsRequest) GetOwnerId() string {
 	if x != nil {
-		return x.LongUrl
+		return x.OwnerId
 	}
 	return ""
 }
 
-func (x *UrlDataResponse) GetShortUrl() string {
+func (x *ListUrlsRequest) GetPage() int64 {
 	if x != nil {
-		return x.ShortUrl
+		return x.Page
 	}
-	return ""
+	return 0
 }
 
-type ShortUrlRequest struct {
+func (x *ListUrlsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type UrlInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ShortUrl string `protobuf:"bytes,1,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	ShortUrl  string                 `protobuf:"bytes,1,opt,name=shortUrl,proto3" json:"shortUrl,omitempty"`
+	LongUrl   string                 `protobuf:"bytes,2,opt,name=longUrl,proto3" json:"longUrl,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	Active    bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
 }
 
-func (x *ShortUrlRequest) Reset() {
-	*x = ShortUrlRequest{}
+func (x *UrlInfo) Reset() {
+	*x = UrlInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_url_proto_msgTypes[2]
+		mi := &file_pkg_proto_url_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShortUrlRequest) String() string {
+func (x *UrlInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShortUrlRequest) ProtoMessage() {}
+func (*UrlInfo) ProtoMessage() {}
 
-func (x *ShortUrlRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_url_proto_msgTypes[2]
+func (x *UrlInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,43 +891,75 @@ func (x *ShortUrlRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShortUrlRequest.ProtoReflect.Descriptor instead.
-func (*ShortUrlRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_url_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use UrlInfo.ProtoReflect.Descriptor instead.
+func (*UrlInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ShortUrlRequest) GetShortUrl() string {
+func (x *UrlInfo) GetShortUrl() string {
 	if x != nil {
 		return x.ShortUrl
 	}
 	return ""
 }
 
-type LongUrlResponse struct {
+func (x *UrlInfo) GetLongUrl() string {
+	if x != nil {
+		return x.LongUrl
+	}
+	return ""
+}
+
+func (x *UrlInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UrlInfo) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *UrlInfo) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type Pagination struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LongUrl string `protobuf:"bytes,1,opt,name=longUrl,proto3" json:"longUrl,omitempty"`
+	Next          int64 `protobuf:"varint,1,opt,name=next,proto3" json:"next,omitempty"`
+	Previous      int64 `protobuf:"varint,2,opt,name=previous,proto3" json:"previous,omitempty"`
+	RecordPerPage int64 `protobuf:"varint,3,opt,name=recordPerPage,proto3" json:"recordPerPage,omitempty"`
+	CurrentPage   int64 `protobuf:"varint,4,opt,name=currentPage,proto3" json:"currentPage,omitempty"`
+	TotalPage     int64 `protobuf:"varint,5,opt,name=totalPage,proto3" json:"totalPage,omitempty"`
 }
 
-func (x *LongUrlResponse) Reset() {
-	*x = LongUrlResponse{}
+func (x *Pagination) Reset() {
+	*x = Pagination{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_url_proto_msgTypes[3]
+		mi := &file_pkg_proto_url_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LongUrlResponse) String() string {
+func (x *Pagination) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LongUrlResponse) ProtoMessage() {}
+func (*Pagination) ProtoMessage() {}
 
-func (x *LongUrlResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_url_proto_msgTypes[3]
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,45 +970,249 @@ func (x *LongUrlResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LongUrlResponse.ProtoReflect.Descriptor instead.
-func (*LongUrlResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_url_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *LongUrlResponse) GetLongUrl() string {
+func (x *Pagination) GetNext() int64 {
 	if x != nil {
-		return x.LongUrl
+		return x.Next
 	}
-	return ""
+	return 0
+}
+
+func (x *Pagination) GetPrevious() int64 {
+	if x != nil {
+		return x.Previous
+	}
+	return 0
+}
+
+func (x *Pagination) GetRecordPerPage() int64 {
+	if x != nil {
+		return x.RecordPerPage
+	}
+	return 0
+}
+
+func (x *Pagination) GetCurrentPage() int64 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalPage() int64 {
+	if x != nil {
+		return x.TotalPage
+	}
+	return 0
+}
+
+type ListUrlsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Urls       []*UrlInfo  `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	Pagination *Pagination `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (x *ListUrlsResponse) Reset() {
+	*x = ListUrlsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_url_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListUrlsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUrlsResponse) ProtoMessage() {}
+
+func (x *ListUrlsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_url_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUrlsResponse.ProtoReflect.Descriptor instead.
+func (*ListUrlsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_url_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListUrlsResponse) GetUrls() []*UrlInfo {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *ListUrlsResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
 }
 
 var File_pkg_proto_url_proto protoreflect.FileDescriptor
 
 var file_pkg_proto_url_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x75, 0x72, 0x6c, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x2a, 0x0a, 0x0e, 0x4c, 0x6f,
-	0x6e, 0x67, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c,
-	0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x22, 0x47, 0x0a, 0x0f, 0x55, 0x72, 0x6c, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x6e,
-	0x67, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x6e, 0x67,
-	0x55, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x22,
-	0x2d, 0x0a, 0x0f, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x22, 0x2b,
-	0x0a, 0x0f, 0x4c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x32, 0x7b, 0x0a, 0x03, 0x55,
-	0x72, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c,
-	0x12, 0x13, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x4c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x55, 0x72, 0x6c, 0x44,
-	0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a,
-	0x09, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x55, 0x72, 0x6c, 0x12, 0x14, 0x2e, 0x75, 0x72, 0x6c,
-	0x2e, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x14, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x4c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2f, 0x3b, 0x75,
-	0x72, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x75, 0x72, 0x6c, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
+	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc0, 0x01, 0x0a, 0x0e, 0x4c, 0x6f, 0x6e,
+	0x67, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6c,
+	0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f,
+	0x6e, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x1e, 0x0a, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00,
+	0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x12, 0x3a, 0x0a, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x09, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49,
+	0x64, 0x42, 0x08, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x81, 0x01, 0x0a, 0x0f,
+	0x55, 0x72, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x55, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22,
+	0x57, 0x0a, 0x12, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x4c, 0x6f, 0x6e, 0x67, 0x55, 0x72,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x0f, 0x53, 0x68, 0x6f, 0x72,
+	0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x74, 0x0a, 0x10, 0x53, 0x68, 0x6f,
+	0x72, 0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x75, 0x72, 0x6c,
+	0x2e, 0x55, 0x72, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x2c, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x53, 0x68, 0x6f,
+	0x72, 0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
+	0x46, 0x0a, 0x13, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x53, 0x68,
+	0x6f, 0x72, 0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x56, 0x0a, 0x0f, 0x53, 0x68, 0x6f, 0x72, 0x74,
+	0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68,
+	0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68,
+	0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x27, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x22,
+	0x7e, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x73, 0x65,
+	0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73,
+	0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x22,
+	0x2b, 0x0a, 0x0f, 0x4c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x22, 0x63, 0x0a, 0x13,
+	0x53, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0x62, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x72, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x49, 0x64, 0x22, 0x48, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55,
+	0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x55, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0x55, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xcb, 0x01, 0x0a, 0x07, 0x55, 0x72, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x22, 0xa2, 0x01, 0x0a, 0x0a, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x65, 0x72,
+	0x50, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x50, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x22, 0x65, 0x0a, 0x10, 0x4c, 0x69, 0x73,
+	0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a,
+	0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x75, 0x72,
+	0x6c, 0x2e, 0x55, 0x72, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12,
+	0x2f, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x32, 0xb8, 0x03, 0x0a, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x53, 0x68, 0x6f, 0x72,
+	0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x13, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x4c, 0x6f, 0x6e,
+	0x67, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x75, 0x72,
+	0x6c, 0x2e, 0x55, 0x72, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0b, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x55, 0x72,
+	0x6c, 0x73, 0x12, 0x17, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e,
+	0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x72,
+	0x6c, 0x2e, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x09, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x55, 0x72, 0x6c, 0x12, 0x14, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x53, 0x68, 0x6f, 0x72, 0x74,
+	0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x75, 0x72, 0x6c,
+	0x2e, 0x4c, 0x6f, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x72, 0x6c, 0x12,
+	0x15, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x72, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
+	0x12, 0x42, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x12, 0x18, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x72,
+	0x6c, 0x12, 0x15, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x72,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x55,
+	0x72, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x39, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x14, 0x2e, 0x75,
+	0x72, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x72, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x2e,
+	0x2f, 0x3b, 0x75, 0x72, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -258,23 +1227,58 @@ func file_pkg_proto_url_proto_rawDescGZIP() []byte {
 	return file_pkg_proto_url_proto_rawDescData
 }
 
-var file_pkg_proto_url_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pkg_proto_url_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_pkg_proto_url_proto_goTypes = []interface{}{
-	(*LongUrlRequest)(nil),  // 0: url.LongUrlRequest
-	(*UrlDataResponse)(nil), // 1: url.UrlDataResponse
-	(*ShortUrlRequest)(nil), // 2: url.ShortUrlRequest
-	(*LongUrlResponse)(nil), // 3: url.LongUrlResponse
+	(*LongUrlRequest)(nil),        // 0: url.LongUrlRequest
+	(*UrlDataResponse)(nil),       // 1: url.UrlDataResponse
+	(*ShortenUrlsRequest)(nil),    // 2: url.ShortenUrlsRequest
+	(*ShortenUrlError)(nil),       // 3: url.ShortenUrlError
+	(*ShortenUrlResult)(nil),      // 4: url.ShortenUrlResult
+	(*ShortenUrlsResponse)(nil),   // 5: url.ShortenUrlsResponse
+	(*ShortUrlRequest)(nil),       // 6: url.ShortUrlRequest
+	(*ClientInfo)(nil),            // 7: url.ClientInfo
+	(*LongUrlResponse)(nil),       // 8: url.LongUrlResponse
+	(*SetUrlActiveRequest)(nil),   // 9: url.SetUrlActiveRequest
+	(*UpdateUrlRequest)(nil),      // 10: url.UpdateUrlRequest
+	(*DeleteUrlRequest)(nil),      // 11: url.DeleteUrlRequest
+	(*ListUrlsRequest)(nil),       // 12: url.ListUrlsRequest
+	(*UrlInfo)(nil),               // 13: url.UrlInfo
+	(*Pagination)(nil),            // 14: url.Pagination
+	(*ListUrlsResponse)(nil),      // 15: url.ListUrlsResponse
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 17: google.protobuf.Empty
 }
 var file_pkg_proto_url_proto_depIdxs = []int32{
-	0, // 0: url.Url.ShortenUrl:input_type -> url.LongUrlRequest
-	2, // 1: url.Url.FollowUrl:input_type -> url.ShortUrlRequest
-	1, // 2: url.Url.ShortenUrl:output_type -> url.UrlDataResponse
-	3, // 3: url.Url.FollowUrl:output_type -> url.LongUrlResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	16, // 0: url.LongUrlRequest.expiresAt:type_name -> google.protobuf.Timestamp
+	16, // 1: url.UrlDataResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	0,  // 2: url.ShortenUrlsRequest.urls:type_name -> url.LongUrlRequest
+	1,  // 3: url.ShortenUrlResult.url:type_name -> url.UrlDataResponse
+	3,  // 4: url.ShortenUrlResult.error:type_name -> url.ShortenUrlError
+	4,  // 5: url.ShortenUrlsResponse.results:type_name -> url.ShortenUrlResult
+	7,  // 6: url.ShortUrlRequest.client:type_name -> url.ClientInfo
+	16, // 7: url.UrlInfo.createdAt:type_name -> google.protobuf.Timestamp
+	16, // 8: url.UrlInfo.expiresAt:type_name -> google.protobuf.Timestamp
+	13, // 9: url.ListUrlsResponse.urls:type_name -> url.UrlInfo
+	14, // 10: url.ListUrlsResponse.pagination:type_name -> url.Pagination
+	0,  // 11: url.Url.ShortenUrl:input_type -> url.LongUrlRequest
+	2,  // 12: url.Url.ShortenUrls:input_type -> url.ShortenUrlsRequest
+	6,  // 13: url.Url.FollowUrl:input_type -> url.ShortUrlRequest
+	11, // 14: url.Url.DeleteUrl:input_type -> url.DeleteUrlRequest
+	9,  // 15: url.Url.SetUrlActive:input_type -> url.SetUrlActiveRequest
+	10, // 16: url.Url.UpdateUrl:input_type -> url.UpdateUrlRequest
+	12, // 17: url.Url.ListUrls:input_type -> url.ListUrlsRequest
+	1,  // 18: url.Url.ShortenUrl:output_type -> url.UrlDataResponse
+	5,  // 19: url.Url.ShortenUrls:output_type -> url.ShortenUrlsResponse
+	8,  // 20: url.Url.FollowUrl:output_type -> url.LongUrlResponse
+	17, // 21: url.Url.DeleteUrl:output_type -> google.protobuf.Empty
+	17, // 22: url.Url.SetUrlActive:output_type -> google.protobuf.Empty
+	1,  // 23: url.Url.UpdateUrl:output_type -> url.UrlDataResponse
+	15, // 24: url.Url.ListUrls:output_type -> url.ListUrlsResponse
+	18, // [18:25] is the sub-list for method output_type
+	11, // [11:18] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_url_proto_init() }
@@ -308,7 +1312,7 @@ func file_pkg_proto_url_proto_init() {
 			}
 		}
 		file_pkg_proto_url_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShortUrlRequest); i {
+			switch v := v.(*ShortenUrlsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -320,6 +1324,66 @@ func file_pkg_proto_url_proto_init() {
 			}
 		}
 		file_pkg_proto_url_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShortenUrlError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShortenUrlResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShortenUrlsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShortUrlRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LongUrlResponse); i {
 			case 0:
 				return &v.state
@@ -331,6 +1395,98 @@ func file_pkg_proto_url_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_proto_url_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUrlActiveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateUrlRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteUrlRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListUrlsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UrlInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pagination); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_url_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListUrlsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_pkg_proto_url_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*LongUrlRequest_ExpiresIn)(nil),
+		(*LongUrlRequest_ExpiresAt)(nil),
+	}
+	file_pkg_proto_url_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*ShortenUrlResult_Url)(nil),
+		(*ShortenUrlResult_Error)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -338,7 +1494,7 @@ func file_pkg_proto_url_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_proto_url_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
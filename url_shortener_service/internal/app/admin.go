@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"CoolUrlShortener/internal/config"
+	"CoolUrlShortener/internal/repository/postgresql"
+	"CoolUrlShortener/internal/repository/rediscache"
+	"CoolUrlShortener/internal/service"
+	"CoolUrlShortener/internal/transport/admin"
+)
+
+// RunAdmin runs a command of the admin cli, args do not include the program name.
+// Logs go to stderr since stdout may carry exported urls.
+func RunAdmin(args []string) error {
+	cfg, err := config.ParseAdminConfig()
+	if err != nil {
+		return err
+	}
+
+	logger := slog.New(
+		slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	dbPool := createDBPool(cfg.DatabaseConfig)
+	defer dbPool.Close()
+
+	redisClient, err := setupRedisClient(cfg.RedisConfig)
+	if err != nil {
+		return err
+	}
+	defer redisClient.Close()
+
+	urlShortener, err := setupURLShortener(cfg.Shortener)
+	if err != nil {
+		return err
+	}
+	// A leased snowflake worker id is released on return, before the pool is closed
+	doneCh := make(chan struct{})
+	var wg sync.WaitGroup
+	defer func() {
+		close(doneCh)
+		wg.Wait()
+	}()
+	idGenerator, err := setupIDGenerator(cfg.IDGenerator, dbPool, doneCh, &wg)
+	if err != nil {
+		return err
+	}
+
+	migrationService := service.NewMigrationService(
+		logger,
+		postgresql.NewUrlRepoPostgres(dbPool),
+		rediscache.NewURLCacheRedis(redisClient),
+		postgresql.NewTxManagerPostgres(dbPool),
+		urlShortener,
+		idGenerator,
+	)
+
+	cli := admin.NewCLI(logger, migrationService, os.Stdin, os.Stdout, os.Stderr)
+	return cli.Run(ctx, args)
+}
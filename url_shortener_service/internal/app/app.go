@@ -8,18 +8,24 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"CoolUrlShortener/internal/config"
+	"CoolUrlShortener/internal/principal"
+	"CoolUrlShortener/internal/repository"
 	"CoolUrlShortener/internal/repository/events"
 	"CoolUrlShortener/internal/repository/postgresql"
 	"CoolUrlShortener/internal/repository/rediscache"
 	"CoolUrlShortener/internal/service"
 	url_grpc "CoolUrlShortener/internal/transport/grpc"
 	"CoolUrlShortener/internal/transport/rest"
+	"CoolUrlShortener/pkg/idgen"
 	url "CoolUrlShortener/pkg/proto"
 	"CoolUrlShortener/pkg/shortener"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 )
@@ -31,15 +37,14 @@ const (
 	httpServerPort    = "8001"
 	grpcServerPort    = "8101"
 	grpcServerNetwork = "tcp"
+
+	urlDataIDRangeName = "url_data"
 )
 
 func Run() {
+	// Components stop when doneCh is closed, wg is done once they no longer use the pool
 	doneCh := make(chan struct{})
-
-	defer func() {
-		doneCh <- struct{}{}
-		close(doneCh)
-	}()
+	var wg sync.WaitGroup
 
 	cfg, err := config.ParseConfig()
 	if err != nil {
@@ -52,9 +57,9 @@ func Run() {
 	}
 
 	dbPool := createDBPool(cfg.DatabaseConfig)
-	defer dbPool.Close()
 
-	runGrpcServer(logger, cfg, dbPool, doneCh)
+	runGrpcServer(logger, cfg, dbPool, doneCh, &wg)
+	runOutboxRelay(logger, cfg, dbPool, doneCh, &wg)
 	runHttpServer(logger)
 
 	// Graceful shutdown
@@ -62,6 +67,9 @@ func Run() {
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
 	<-stop
+	close(doneCh)
+	wg.Wait()
+	dbPool.Close()
 }
 
 func setupLogger(env string) (*slog.Logger, error) {
@@ -112,14 +120,86 @@ func setupRedisClient(redisCfg config.RedisConfig) (*redis.Client, error) {
 	return redisClient, nil
 }
 
+func setupURLShortener(shortenerCfg config.ShortenerConfig) (shortener.URLShortener, error) {
+	switch shortenerCfg.Encoder {
+	case shortener.EncoderFixedLength:
+		return shortener.NewFixedLengthShortener(shortenerCfg.Alphabet, shortenerCfg.CodeLength)
+	case shortener.EncoderObfuscating:
+		return shortener.NewObfuscatingShortener(shortenerCfg.Alphabet, shortenerCfg.Salt)
+	default:
+		return shortener.NewBase62UrlShortener(), nil
+	}
+}
+
+func setupIDGenerator(
+	idGeneratorCfg config.IDGeneratorConfig,
+	dbPool *pgxpool.Pool,
+	doneCh <-chan struct{},
+	wg *sync.WaitGroup,
+) (idgen.IDGenerator, error) {
+	switch idGeneratorCfg.Strategy {
+	case idgen.StrategySnowflake:
+		if idGeneratorCfg.LeaseWorkerID {
+			return idgen.NewLeasedSnowflakeIDGenerator(
+				context.Background(),
+				postgresql.NewWorkerLeaser(dbPool),
+				idGeneratorCfg.WorkerLeaseTTL,
+				doneCh,
+				wg,
+			)
+		}
+		return idgen.NewSnowflakeIDGenerator(idGeneratorCfg.WorkerID)
+	case idgen.StrategySequence:
+		return postgresql.NewSequenceIDGenerator(dbPool), nil
+	case idgen.StrategyRange:
+		leaser := postgresql.NewIDRangeLeaser(dbPool, urlDataIDRangeName)
+		return idgen.NewRangeIDGenerator(leaser, idGeneratorCfg.RangeSize)
+	default:
+		return idgen.NewUUIDIDGenerator(), nil
+	}
+}
+
+func setupEventsProducer(
+	logger *slog.Logger,
+	kafkaCfg config.KafkaConfig,
+	doneCh <-chan struct{},
+	wg *sync.WaitGroup,
+) (repository.EventsProducer, error) {
+	metrics := events.NewProducerMetrics(prometheus.DefaultRegisterer)
+	if kafkaCfg.Producer.Mode == events.ProducerModeSync {
+		return events.NewKafkaEventProducer(logger, kafkaCfg.Addrs, nil, kafkaCfg.EventsEncoding, metrics, doneCh)
+	}
+
+	compression, err := events.ParseCompression(kafkaCfg.Producer.Compression)
+	if err != nil {
+		return nil, err
+	}
+	return events.NewAsyncKafkaEventProducer(
+		logger,
+		kafkaCfg.Addrs,
+		events.AsyncProducerConfig{
+			BufferSize:       kafkaCfg.Producer.BufferSize,
+			FullBufferPolicy: kafkaCfg.Producer.FullBufferPolicy,
+			BatchSize:        kafkaCfg.Producer.BatchSize,
+			FlushInterval:    kafkaCfg.Producer.FlushInterval,
+			Compression:      compression,
+			Encoding:         kafkaCfg.EventsEncoding,
+		},
+		metrics,
+		doneCh,
+		wg,
+	)
+}
+
 func runGrpcServer(
 	logger *slog.Logger,
 	cfg config.Config,
 	dbPool *pgxpool.Pool,
 	doneCh <-chan struct{},
+	wg *sync.WaitGroup,
 ) {
 
-	eventsServiceProducer, err := events.NewKafkaEventProducer(logger, cfg.KafkaConfig.Addrs, nil, doneCh)
+	eventsServiceProducer, err := setupEventsProducer(logger, cfg.KafkaConfig, doneCh, wg)
 	if err != nil {
 		panic(err)
 	}
@@ -129,20 +209,56 @@ func runGrpcServer(
 		panic(err.Error())
 	}
 
-	base62URLShortener := shortener.NewBase62UrlShortener()
+	urlShortener, err := setupURLShortener(cfg.Shortener)
+	if err != nil {
+		panic(err)
+	}
+	idGenerator, err := setupIDGenerator(cfg.IDGenerator, dbPool, doneCh, wg)
+	if err != nil {
+		panic(err)
+	}
 
 	urlCache := rediscache.NewURLCacheRedis(redisClient)
 	urlRepo := postgresql.NewUrlRepoPostgres(dbPool)
-	urlService := service.NewURLService(logger, urlRepo, urlCache, eventsServiceProducer, base62URLShortener)
+	urlService := service.NewURLService(
+		logger,
+		postgresql.NewTxManagerPostgres(dbPool),
+		urlRepo,
+		postgresql.NewEventsOutboxPostgres(dbPool),
+		urlCache,
+		eventsServiceProducer,
+		urlShortener,
+		idGenerator,
+	)
+
+	apiKeyRepo := postgresql.NewAPIKeyRepoPostgres(dbPool)
+	apiKeyService := service.NewAPIKeyService(logger, apiKeyRepo)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(principal.UnaryServerInterceptor),
+	)
+	urlServer := url_grpc.NewUrlServer(
+		logger,
+		urlService,
+	)
+
+	apiKeyServer := url_grpc.NewApiKeyServer(
+		logger,
+		apiKeyService,
+	)
+
+	url.RegisterUrlServer(s, urlServer)
+	url.RegisterApiKeyServer(s, apiKeyServer)
 
+	// Requests in flight finish before the pool is closed
+	wg.Add(1)
 	go func() {
-		s := grpc.NewServer()
-		urlServer := url_grpc.NewUrlServer(
-			logger,
-			urlService,
-		)
+		defer wg.Done()
+		<-doneCh
+		s.GracefulStop()
+	}()
 
-		url.RegisterUrlServer(s, urlServer)
+	go func() {
 		port := fmt.Sprintf(":%s", grpcServerPort)
 		listener, err := net.Listen(grpcServerNetwork, port)
 		if err != nil {
@@ -157,12 +273,56 @@ func runGrpcServer(
 	}()
 }
 
+func runOutboxRelay(
+	logger *slog.Logger,
+	cfg config.Config,
+	dbPool *pgxpool.Pool,
+	doneCh <-chan struct{},
+	wg *sync.WaitGroup,
+) {
+	eventsPublisher, err := events.NewKafkaEventPublisher(
+		logger,
+		cfg.KafkaConfig.Addrs,
+		nil,
+		cfg.KafkaConfig.EventsEncoding,
+		doneCh,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	outboxRelay := service.NewOutboxRelay(
+		logger,
+		postgresql.NewTxManagerPostgres(dbPool),
+		postgresql.NewEventsOutboxPostgres(dbPool),
+		eventsPublisher,
+		service.OutboxRelayConfig{
+			PollInterval: cfg.Outbox.PollInterval,
+			BatchSize:    cfg.Outbox.BatchSize,
+			Retention:    cfg.Outbox.Retention,
+			LeaseTTL:     cfg.Outbox.LeaseTTL,
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-doneCh
+		cancel()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		outboxRelay.Run(ctx)
+	}()
+}
+
 func runHttpServer(logger *slog.Logger) {
 	healthCheckHandler := rest.NewHealthCheckHandler(logger)
 
 	go func() {
 		mux := http.NewServeMux()
 		mux.HandleFunc("GET /api/healthcheck", healthCheckHandler.HealthCheck)
+		mux.Handle("GET /metrics", promhttp.Handler())
 
 		addr := fmt.Sprintf(":%s", httpServerPort)
 		server := http.Server{
@@ -3,7 +3,13 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"CoolUrlShortener/internal/repository/events"
+	"CoolUrlShortener/pkg/idgen"
+	"CoolUrlShortener/pkg/shortener"
 )
 
 const (
@@ -18,7 +24,48 @@ const (
 	redisPortKey     = "REDIS_PORT"
 	redisPasswordKey = "REDIS_PASSWORD"
 
-	kafkaAddrsKey = "KAFKA_ADDRS"
+	kafkaAddrsKey          = "KAFKA_ADDRS"
+	kafkaEventsEncodingKey = "KAFKA_EVENTS_ENCODING"
+
+	kafkaProducerModeKey             = "KAFKA_PRODUCER_MODE"
+	kafkaProducerBufferSizeKey       = "KAFKA_PRODUCER_BUFFER_SIZE"
+	kafkaProducerFullBufferPolicyKey = "KAFKA_PRODUCER_FULL_BUFFER_POLICY"
+	kafkaProducerBatchSizeKey        = "KAFKA_PRODUCER_BATCH_SIZE"
+	kafkaProducerFlushIntervalKey    = "KAFKA_PRODUCER_FLUSH_INTERVAL"
+	kafkaProducerCompressionKey      = "KAFKA_PRODUCER_COMPRESSION"
+
+	defaultKafkaProducerBufferSize    = 10000
+	defaultKafkaProducerBatchSize     = 500
+	defaultKafkaProducerFlushInterval = 100 * time.Millisecond
+	defaultKafkaProducerCompression   = "snappy"
+
+	outboxPollIntervalKey = "OUTBOX_POLL_INTERVAL"
+	outboxBatchSizeKey    = "OUTBOX_BATCH_SIZE"
+	outboxRetentionKey    = "OUTBOX_RETENTION"
+	outboxLeaseTTLKey     = "OUTBOX_LEASE_TTL"
+
+	defaultOutboxPollInterval = time.Second
+	defaultOutboxBatchSize    = 100
+	defaultOutboxRetention    = 24 * time.Hour
+	defaultOutboxLeaseTTL     = 30 * time.Second
+
+	idGeneratorKey             = "ID_GENERATOR"
+	snowflakeWorkerIDKey       = "SNOWFLAKE_WORKER_ID"
+	snowflakeWorkerLeaseTTLKey = "SNOWFLAKE_WORKER_LEASE_TTL"
+	idRangeSizeKey             = "ID_RANGE_SIZE"
+
+	defaultIDRangeSize             = 1000
+	defaultSnowflakeWorkerLeaseTTL = time.Minute
+
+	shortenerEncoderKey    = "SHORTENER_ENCODER"
+	shortenerAlphabetKey   = "SHORTENER_ALPHABET"
+	shortenerCodeLengthKey = "SHORTENER_CODE_LENGTH"
+	shortenerSaltKey       = "SHORTENER_SALT"
+
+	alphabetDefault     = "default"
+	alphabetUnambiguous = "unambiguous"
+
+	defaultCodeLength = 7
 )
 
 type Config struct {
@@ -26,6 +73,17 @@ type Config struct {
 	DatabaseConfig DatabaseConfig
 	RedisConfig    RedisConfig
 	KafkaConfig    KafkaConfig
+	Outbox         OutboxConfig
+	IDGenerator    IDGeneratorConfig
+	Shortener      ShortenerConfig
+}
+
+// AdminConfig is a config of the admin cli
+type AdminConfig struct {
+	DatabaseConfig DatabaseConfig
+	RedisConfig    RedisConfig
+	IDGenerator    IDGeneratorConfig
+	Shortener      ShortenerConfig
 }
 
 type DatabaseConfig struct {
@@ -44,6 +102,52 @@ type RedisConfig struct {
 
 type KafkaConfig struct {
 	Addrs []string
+	// EventsEncoding is one of events.Encoding* values
+	EventsEncoding string
+	Producer       KafkaProducerConfig
+}
+
+// KafkaProducerConfig configures the producer of follow events,
+// all fields except Mode are used by the async producer only
+type KafkaProducerConfig struct {
+	// Mode is one of events.ProducerMode* values, async by default so redirects do not wait for kafka
+	Mode       string
+	BufferSize int
+	// FullBufferPolicy is one of events.FullBuffer* values
+	FullBufferPolicy string
+	BatchSize        int
+	FlushInterval    time.Duration
+	// Compression is one of none, gzip, snappy, lz4, zstd
+	Compression string
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Retention is how long sent events are kept in the outbox
+	Retention time.Duration
+	// LeaseTTL is how long a relay may publish events of a partition of the outbox
+	LeaseTTL time.Duration
+}
+
+type IDGeneratorConfig struct {
+	// Strategy is one of idgen.Strategy* values
+	Strategy string
+	// WorkerID must be unique for every replica when snowflake strategy is used.
+	// It is leased from the database for WorkerLeaseTTL when LeaseWorkerID is set
+	WorkerID       int64
+	LeaseWorkerID  bool
+	WorkerLeaseTTL time.Duration
+	// RangeSize is a number of ids leased at once when range strategy is used
+	RangeSize int64
+}
+
+type ShortenerConfig struct {
+	// Encoder is one of shortener.Encoder* values
+	Encoder    string
+	Alphabet   string
+	CodeLength int
+	Salt       string
 }
 
 func ParseConfig() (Config, error) {
@@ -53,68 +157,377 @@ func ParseConfig() (Config, error) {
 		panic(msg)
 	}
 
+	dbCfg, err := parseDatabaseConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	redisCfg, err := parseRedisConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	kafkaAddrsRaw := os.Getenv(kafkaAddrsKey)
+	if kafkaAddrsRaw == "" {
+		return Config{}, fmt.Errorf("you did not provide env: %s", kafkaAddrsKey)
+	}
+	kafkaAddrs := strings.Split(kafkaAddrsRaw, ",")
+
+	eventsEncoding := os.Getenv(kafkaEventsEncodingKey)
+	if eventsEncoding == "" {
+		eventsEncoding = events.EncodingJSON
+	}
+	switch eventsEncoding {
+	case events.EncodingJSON, events.EncodingProtobuf, events.EncodingDual:
+	default:
+		return Config{}, fmt.Errorf("unknown events encoding: %s", eventsEncoding)
+	}
+
+	kafkaProducerCfg, err := parseKafkaProducerConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	outboxCfg, err := parseOutboxConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	idGeneratorCfg, err := parseIDGeneratorConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	shortenerCfg, err := parseShortenerConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{
+		Env:            env,
+		DatabaseConfig: dbCfg,
+		RedisConfig:    redisCfg,
+		KafkaConfig: KafkaConfig{
+			Addrs:          kafkaAddrs,
+			EventsEncoding: eventsEncoding,
+			Producer:       kafkaProducerCfg,
+		},
+		Outbox:      outboxCfg,
+		IDGenerator: idGeneratorCfg,
+		Shortener:   shortenerCfg,
+	}, nil
+}
+
+// ParseAdminConfig parses config of the admin cli, it does not need kafka
+// because imported urls are not reported as created
+func ParseAdminConfig() (AdminConfig, error) {
+	dbCfg, err := parseDatabaseConfig()
+	if err != nil {
+		return AdminConfig{}, err
+	}
+
+	redisCfg, err := parseRedisConfig()
+	if err != nil {
+		return AdminConfig{}, err
+	}
+
+	idGeneratorCfg, err := parseIDGeneratorConfig()
+	if err != nil {
+		return AdminConfig{}, err
+	}
+
+	shortenerCfg, err := parseShortenerConfig()
+	if err != nil {
+		return AdminConfig{}, err
+	}
+
+	return AdminConfig{
+		DatabaseConfig: dbCfg,
+		RedisConfig:    redisCfg,
+		IDGenerator:    idGeneratorCfg,
+		Shortener:      shortenerCfg,
+	}, nil
+}
+
+func parseDatabaseConfig() (DatabaseConfig, error) {
 	dbUsername := os.Getenv(databaseUsernameKey)
 	if dbUsername == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", databaseUsernameKey)
+		return DatabaseConfig{}, fmt.Errorf("you did not provide env: %s", databaseUsernameKey)
 	}
 
 	dbPassword := os.Getenv(databasePasswordKey)
 	if dbPassword == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", databasePasswordKey)
+		return DatabaseConfig{}, fmt.Errorf("you did not provide env: %s", databasePasswordKey)
 	}
 
 	dbHost := os.Getenv(databaseHostKey)
 	if dbHost == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", databaseHostKey)
+		return DatabaseConfig{}, fmt.Errorf("you did not provide env: %s", databaseHostKey)
 	}
 
 	dbPort := os.Getenv(databasePortKey)
 	if dbPort == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", databasePortKey)
+		return DatabaseConfig{}, fmt.Errorf("you did not provide env: %s", databasePortKey)
 	}
 
 	dbName := os.Getenv(databaseNameKey)
 	if dbName == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", databaseNameKey)
+		return DatabaseConfig{}, fmt.Errorf("you did not provide env: %s", databaseNameKey)
 	}
 
+	return DatabaseConfig{
+		Username: dbUsername,
+		Password: dbPassword,
+		Host:     dbHost,
+		Port:     dbPort,
+		Name:     dbName,
+	}, nil
+}
+
+func parseRedisConfig() (RedisConfig, error) {
 	redisHost := os.Getenv(redisHostKey)
 	if redisHost == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", redisHostKey)
+		return RedisConfig{}, fmt.Errorf("you did not provide env: %s", redisHostKey)
 	}
 
 	redisPort := os.Getenv(redisPortKey)
 	if redisPort == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", redisPortKey)
+		return RedisConfig{}, fmt.Errorf("you did not provide env: %s", redisPortKey)
 	}
 
 	redisPassword := os.Getenv(redisPasswordKey)
 	if redisPassword == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", redisPasswordKey)
+		return RedisConfig{}, fmt.Errorf("you did not provide env: %s", redisPasswordKey)
 	}
 
-	kafkaAddrsRaw := os.Getenv(kafkaAddrsKey)
-	if kafkaAddrsRaw == "" {
-		return Config{}, fmt.Errorf("you did not provide env: %s", kafkaAddrsKey)
+	return RedisConfig{
+		Host:     redisHost,
+		Port:     redisPort,
+		Password: redisPassword,
+	}, nil
+}
+
+func parseKafkaProducerConfig() (KafkaProducerConfig, error) {
+	cfg := KafkaProducerConfig{
+		Mode:             events.ProducerModeAsync,
+		BufferSize:       defaultKafkaProducerBufferSize,
+		FullBufferPolicy: events.FullBufferDrop,
+		BatchSize:        defaultKafkaProducerBatchSize,
+		FlushInterval:    defaultKafkaProducerFlushInterval,
+		Compression:      defaultKafkaProducerCompression,
 	}
-	kafkaAddrs := strings.Split(kafkaAddrsRaw, ",")
 
-	return Config{
-		Env: env,
-		DatabaseConfig: DatabaseConfig{
-			Username: dbUsername,
-			Password: dbPassword,
-			Host:     dbHost,
-			Port:     dbPort,
-			Name:     dbName,
-		},
-		RedisConfig: RedisConfig{
-			Host:     redisHost,
-			Port:     redisPort,
-			Password: redisPassword,
-		},
-		KafkaConfig: KafkaConfig{
-			Addrs: kafkaAddrs,
-		},
-	}, nil
+	mode := os.Getenv(kafkaProducerModeKey)
+	if mode != "" {
+		cfg.Mode = mode
+	}
+	switch cfg.Mode {
+	case events.ProducerModeSync, events.ProducerModeAsync:
+	default:
+		return KafkaProducerConfig{}, fmt.Errorf("unknown kafka producer mode: %s", cfg.Mode)
+	}
+
+	bufferSizeRaw := os.Getenv(kafkaProducerBufferSizeKey)
+	if bufferSizeRaw != "" {
+		bufferSize, err := strconv.Atoi(bufferSizeRaw)
+		if err != nil {
+			return KafkaProducerConfig{}, err
+		}
+		if bufferSize <= 0 {
+			return KafkaProducerConfig{}, fmt.Errorf("%s must be positive", kafkaProducerBufferSizeKey)
+		}
+		cfg.BufferSize = bufferSize
+	}
+
+	fullBufferPolicy := os.Getenv(kafkaProducerFullBufferPolicyKey)
+	if fullBufferPolicy != "" {
+		cfg.FullBufferPolicy = fullBufferPolicy
+	}
+	switch cfg.FullBufferPolicy {
+	case events.FullBufferDrop, events.FullBufferBlock:
+	default:
+		return KafkaProducerConfig{}, fmt.Errorf("unknown full buffer policy: %s", cfg.FullBufferPolicy)
+	}
+
+	batchSizeRaw := os.Getenv(kafkaProducerBatchSizeKey)
+	if batchSizeRaw != "" {
+		batchSize, err := strconv.Atoi(batchSizeRaw)
+		if err != nil {
+			return KafkaProducerConfig{}, err
+		}
+		cfg.BatchSize = batchSize
+	}
+
+	flushIntervalRaw := os.Getenv(kafkaProducerFlushIntervalKey)
+	if flushIntervalRaw != "" {
+		flushInterval, err := time.ParseDuration(flushIntervalRaw)
+		if err != nil {
+			return KafkaProducerConfig{}, err
+		}
+		cfg.FlushInterval = flushInterval
+	}
+
+	compression := os.Getenv(kafkaProducerCompressionKey)
+	if compression != "" {
+		cfg.Compression = compression
+	}
+	_, err := events.ParseCompression(cfg.Compression)
+	if err != nil {
+		return KafkaProducerConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+func parseOutboxConfig() (OutboxConfig, error) {
+	cfg := OutboxConfig{
+		PollInterval: defaultOutboxPollInterval,
+		BatchSize:    defaultOutboxBatchSize,
+		Retention:    defaultOutboxRetention,
+		LeaseTTL:     defaultOutboxLeaseTTL,
+	}
+
+	pollIntervalRaw := os.Getenv(outboxPollIntervalKey)
+	if pollIntervalRaw != "" {
+		pollInterval, err := time.ParseDuration(pollIntervalRaw)
+		if err != nil {
+			return OutboxConfig{}, err
+		}
+		if pollInterval <= 0 {
+			return OutboxConfig{}, fmt.Errorf("%s must be positive", outboxPollIntervalKey)
+		}
+		cfg.PollInterval = pollInterval
+	}
+
+	batchSizeRaw := os.Getenv(outboxBatchSizeKey)
+	if batchSizeRaw != "" {
+		batchSize, err := strconv.Atoi(batchSizeRaw)
+		if err != nil {
+			return OutboxConfig{}, err
+		}
+		if batchSize <= 0 {
+			return OutboxConfig{}, fmt.Errorf("%s must be positive", outboxBatchSizeKey)
+		}
+		cfg.BatchSize = batchSize
+	}
+
+	retentionRaw := os.Getenv(outboxRetentionKey)
+	if retentionRaw != "" {
+		retention, err := time.ParseDuration(retentionRaw)
+		if err != nil {
+			return OutboxConfig{}, err
+		}
+		if retention <= 0 {
+			return OutboxConfig{}, fmt.Errorf("%s must be positive", outboxRetentionKey)
+		}
+		cfg.Retention = retention
+	}
+
+	leaseTTLRaw := os.Getenv(outboxLeaseTTLKey)
+	if leaseTTLRaw != "" {
+		leaseTTL, err := time.ParseDuration(leaseTTLRaw)
+		if err != nil {
+			return OutboxConfig{}, err
+		}
+		if leaseTTL <= 0 {
+			return OutboxConfig{}, fmt.Errorf("%s must be positive", outboxLeaseTTLKey)
+		}
+		cfg.LeaseTTL = leaseTTL
+	}
+
+	return cfg, nil
+}
+
+func parseIDGeneratorConfig() (IDGeneratorConfig, error) {
+	cfg := IDGeneratorConfig{
+		Strategy:  os.Getenv(idGeneratorKey),
+		RangeSize: defaultIDRangeSize,
+	}
+	if cfg.Strategy == "" {
+		cfg.Strategy = idgen.StrategyUUID
+	}
+
+	switch cfg.Strategy {
+	case idgen.StrategyUUID, idgen.StrategySequence:
+	case idgen.StrategySnowflake:
+		// Replicas lease worker ids unless one is set explicitly
+		workerIDRaw := os.Getenv(snowflakeWorkerIDKey)
+		if workerIDRaw == "" {
+			cfg.LeaseWorkerID = true
+			cfg.WorkerLeaseTTL = defaultSnowflakeWorkerLeaseTTL
+			leaseTTLRaw := os.Getenv(snowflakeWorkerLeaseTTLKey)
+			if leaseTTLRaw != "" {
+				leaseTTL, err := time.ParseDuration(leaseTTLRaw)
+				if err != nil {
+					return IDGeneratorConfig{}, err
+				}
+				cfg.WorkerLeaseTTL = leaseTTL
+			}
+			break
+		}
+		workerID, err := strconv.ParseInt(workerIDRaw, 10, 64)
+		if err != nil {
+			return IDGeneratorConfig{}, err
+		}
+		cfg.WorkerID = workerID
+	case idgen.StrategyRange:
+		rangeSizeRaw := os.Getenv(idRangeSizeKey)
+		if rangeSizeRaw != "" {
+			rangeSize, err := strconv.ParseInt(rangeSizeRaw, 10, 64)
+			if err != nil {
+				return IDGeneratorConfig{}, err
+			}
+			cfg.RangeSize = rangeSize
+		}
+	default:
+		return IDGeneratorConfig{}, fmt.Errorf("unknown %s: %s", idGeneratorKey, cfg.Strategy)
+	}
+
+	return cfg, nil
+}
+
+func parseShortenerConfig() (ShortenerConfig, error) {
+	cfg := ShortenerConfig{
+		Encoder:    os.Getenv(shortenerEncoderKey),
+		Alphabet:   shortener.DefaultAlphabet,
+		CodeLength: defaultCodeLength,
+	}
+	if cfg.Encoder == "" {
+		cfg.Encoder = shortener.EncoderBase62
+	}
+
+	// Alphabet may be a name of predefined alphabet or a custom set of characters
+	alphabet := os.Getenv(shortenerAlphabetKey)
+	switch alphabet {
+	case "", alphabetDefault:
+	case alphabetUnambiguous:
+		cfg.Alphabet = shortener.UnambiguousAlphabet
+	default:
+		cfg.Alphabet = alphabet
+	}
+
+	switch cfg.Encoder {
+	case shortener.EncoderBase62:
+		if alphabet != "" {
+			return ShortenerConfig{}, fmt.Errorf("%s is not supported by %s encoder", shortenerAlphabetKey, shortener.EncoderBase62)
+		}
+	case shortener.EncoderFixedLength:
+		codeLengthRaw := os.Getenv(shortenerCodeLengthKey)
+		if codeLengthRaw != "" {
+			codeLength, err := strconv.Atoi(codeLengthRaw)
+			if err != nil {
+				return ShortenerConfig{}, err
+			}
+			cfg.CodeLength = codeLength
+		}
+	case shortener.EncoderObfuscating:
+		cfg.Salt = os.Getenv(shortenerSaltKey)
+		if cfg.Salt == "" {
+			return ShortenerConfig{}, fmt.Errorf("you did not provide env: %s", shortenerSaltKey)
+		}
+	default:
+		return ShortenerConfig{}, fmt.Errorf("unknown %s: %s", shortenerEncoderKey, cfg.Encoder)
+	}
+
+	return cfg, nil
 }
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type APIKey struct {
+	ID      int64
+	OwnerID string
+	Name    string
+	// KeyHash is a hex encoded sha256 of the key, the key itself is never stored
+	KeyHash   string
+	CreatedAt time.Time
+	// RevokedAt is zero for keys that were not revoked
+	RevokedAt time.Time
+}
+
+func (k APIKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
@@ -0,0 +1,30 @@
+package domain
+
+// ConflictPolicy decides what happens to an imported url whose short url is already taken
+type ConflictPolicy string
+
+const (
+	ConflictSkip      ConflictPolicy = "skip"
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	ConflictFail      ConflictPolicy = "fail"
+)
+
+type ImportParams struct {
+	Conflict ConflictPolicy
+	// OverwriteOwner gives an overwritten url the owner of the imported one,
+	// by default the url stays with its current owner
+	OverwriteOwner bool
+	// DryRun resolves conflicts and counts urls without writing anything
+	DryRun bool
+	// BatchSize limits the number of urls in a single query, all of them are saved at once if not positive
+	BatchSize int
+}
+
+// ImportResult accounts every imported url in exactly one of Created, Overwritten and Skipped
+type ImportResult struct {
+	Created     int
+	Overwritten int
+	Skipped     int
+	// Conflicts are short urls that were already taken, in the order of imported urls
+	Conflicts []string
+}
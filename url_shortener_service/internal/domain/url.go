@@ -7,4 +7,40 @@ type URLData struct {
 	ShortUrl  string
 	LongUrl   string
 	CreatedAt time.Time
+	// ExpiresAt is zero for links that never expire
+	ExpiresAt time.Time
+	// IsActive is false for links that were deactivated by the owner
+	IsActive bool
+	// UpdatedAt is zero for links whose long url was never changed
+	UpdatedAt time.Time
+	// OwnerID is empty for anonymous links
+	OwnerID string
+}
+
+func (d URLData) IsExpired(now time.Time) bool {
+	return !d.ExpiresAt.IsZero() && !now.Before(d.ExpiresAt)
+}
+
+type SaveURLParams struct {
+	LongURL string
+	// Alias is used as a short url instead of a generated one when not empty
+	Alias string
+	// ExpiresAt is zero for links that never expire
+	ExpiresAt time.Time
+	// OwnerID is empty for anonymous links
+	OwnerID string
+}
+
+// ClientInfo describes the client that follows a short url, fields are empty when unknown
+type ClientInfo struct {
+	Referrer       string
+	UserAgent      string
+	AcceptLanguage string
+	IP             string
+}
+
+// SaveURLResult is the outcome of saving a single url of a batch
+type SaveURLResult struct {
+	ShortURL string
+	Err      error
 }
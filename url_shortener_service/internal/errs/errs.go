@@ -2,4 +2,23 @@ package errs
 
 import "errors"
 
-var ErrNoURL = errors.New("url not found")
+var (
+	ErrNoURL              = errors.New("url not found")
+	ErrURLExpired         = errors.New("url expired")
+	ErrURLInactive        = errors.New("url is deactivated")
+	ErrInvalidExpiry      = errors.New("expiry must be in the future")
+	ErrInvalidAlias       = errors.New("alias must be 3-32 characters long and contain only latin letters, digits, '_' or '-'")
+	ErrReservedAlias      = errors.New("alias is reserved")
+	ErrInvalidShortURL    = errors.New("short url must be 1-32 characters long and contain only latin letters, digits, '_' or '-'")
+	ErrAliasAlreadyExists = errors.New("alias already exists")
+	ErrIDConflict         = errors.New("url with such id already exists")
+	ErrShortURLConflict   = errors.New("url with such short url already exists")
+	// ErrShortURLGeneration is returned when no free short url was found in the bounded number of attempts
+	ErrShortURLGeneration = errors.New("could not generate unique short url")
+	ErrNoAPIKey           = errors.New("api key not found")
+	ErrAPIKeyRevoked      = errors.New("api key revoked")
+	// ErrOwnerRequired is returned when an anonymous caller changes a url, anonymous urls can not be changed
+	ErrOwnerRequired = errors.New("owner is required to change a url")
+	// ErrNoOutboxPartition is returned when every partition of the outbox with unsent events is leased
+	ErrNoOutboxPartition = errors.New("no free outbox partition with unsent events")
+)
@@ -0,0 +1,47 @@
+package principal
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys the api gateway forwards the authenticated caller with
+const (
+	OwnerIDMetadataKey  = "x-owner-id"
+	APIKeyIDMetadataKey = "x-api-key-id"
+)
+
+// UnaryServerInterceptor puts the caller forwarded by the api gateway into the context.
+// The service is reachable only from the internal network, so the metadata is trusted as is.
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	ownerIDs := md.Get(OwnerIDMetadataKey)
+	if len(ownerIDs) == 0 || ownerIDs[0] == "" {
+		return handler(ctx, req)
+	}
+
+	p := Principal{OwnerID: ownerIDs[0]}
+	if apiKeyIDs := md.Get(APIKeyIDMetadataKey); len(apiKeyIDs) > 0 {
+		apiKeyID, err := strconv.ParseInt(apiKeyIDs[0], 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bad %s metadata", APIKeyIDMetadataKey)
+		}
+		p.APIKeyID = apiKeyID
+	}
+
+	return handler(WithPrincipal(ctx, p), req)
+}
@@ -0,0 +1,68 @@
+package principal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	testCases := []struct {
+		name              string
+		metadata          metadata.MD
+		expectedPrincipal Principal
+		isAnonymous       bool
+		expectedCode      codes.Code
+	}{
+		{
+			name:         "without metadata anonymous",
+			isAnonymous:  true,
+			expectedCode: codes.OK,
+		},
+		{
+			name:              "caller authenticated with jwt",
+			metadata:          metadata.Pairs(OwnerIDMetadataKey, "owner"),
+			expectedPrincipal: Principal{OwnerID: "owner"},
+			expectedCode:      codes.OK,
+		},
+		{
+			name:              "caller authenticated with api key",
+			metadata:          metadata.Pairs(OwnerIDMetadataKey, "tool", APIKeyIDMetadataKey, "7"),
+			expectedPrincipal: Principal{OwnerID: "tool", APIKeyID: 7},
+			expectedCode:      codes.OK,
+		},
+		{
+			name:         "bad api key id. 3 InvalidArgument",
+			metadata:     metadata.Pairs(OwnerIDMetadataKey, "tool", APIKeyIDMetadataKey, "seven"),
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, tc.metadata)
+			}
+
+			var gotPrincipal Principal
+			var gotOk bool
+			handler := func(ctx context.Context, req any) (any, error) {
+				gotPrincipal, gotOk = FromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if err == nil {
+				assert.Equal(t, !tc.isAnonymous, gotOk)
+				assert.Equal(t, tc.expectedPrincipal, gotPrincipal)
+			}
+		})
+	}
+}
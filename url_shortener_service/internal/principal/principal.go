@@ -0,0 +1,23 @@
+package principal
+
+import "context"
+
+// Principal is the caller authenticated by the api gateway
+type Principal struct {
+	OwnerID string
+	// APIKeyID is set when the caller authenticated with an api key
+	APIKeyID int64
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the caller
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the caller. ok is false for anonymous callers.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
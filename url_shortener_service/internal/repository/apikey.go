@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name APIKeyRepo
+type APIKeyRepo interface {
+	// SaveAPIKey stores the key and returns its id
+	SaveAPIKey(ctx context.Context, apiKey domain.APIKey) (int64, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (domain.APIKey, error)
+	// RevokeAPIKey marks the key as revoked. Already revoked keys are reported as not found.
+	RevokeAPIKey(ctx context.Context, id int64, revokedAt time.Time) error
+}
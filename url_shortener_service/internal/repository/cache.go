@@ -1,9 +1,20 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+)
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name URLCache
 type URLCache interface {
-	SetLongURL(ctx context.Context, shortURL string, longURL string) error
+	// SetLongURL caches longURL. The entry never outlives expiresAt unless it is zero.
+	SetLongURL(ctx context.Context, shortURL string, longURL string, expiresAt time.Time) error
+	// SetLongURLs caches long urls of all urls at once
+	SetLongURLs(ctx context.Context, urls []domain.URLData) error
 	GetLongURL(ctx context.Context, shortURL string) (string, error)
+	DeleteLongURL(ctx context.Context, shortURL string) error
+	// DeleteLongURLs is a batched DeleteLongURL
+	DeleteLongURLs(ctx context.Context, shortURLs []string) error
 }
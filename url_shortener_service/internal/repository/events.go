@@ -1,10 +1,20 @@
 package repository
 
 import (
+	"context"
+
 	"CoolUrlShortener/internal/repository/models"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name EventsProducer
 type EventsProducer interface {
+	// ProduceEvent sends event without reporting failures, they are only logged
 	ProduceEvent(event models.URLEvent)
 }
+
+// EventsPublisher sends events to the broker and reports whether all of them were delivered
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name EventsPublisher
+type EventsPublisher interface {
+	PublishEvents(ctx context.Context, events []models.URLEvent) error
+}
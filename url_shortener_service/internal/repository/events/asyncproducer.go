@@ -0,0 +1,256 @@
+package events
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/internal/repository/models"
+	"github.com/IBM/sarama"
+)
+
+// Policies of the async producer when its buffer is full
+const (
+	// FullBufferDrop drops the event, so the caller never waits for kafka
+	FullBufferDrop = "drop"
+	// FullBufferBlock makes the caller wait until there is room in the buffer
+	FullBufferBlock = "block"
+)
+
+type AsyncProducerConfig struct {
+	// BufferSize is the number of events waiting to be sent
+	BufferSize int
+	// FullBufferPolicy is one of FullBuffer* values
+	FullBufferPolicy string
+	// BatchSize and FlushInterval trigger sending of a batch, whichever comes first
+	BatchSize     int
+	FlushInterval time.Duration
+	Compression   sarama.CompressionCodec
+	// Encoding is one of Encoding* values
+	Encoding string
+}
+
+// ParseCompression accepts none, gzip, snappy, lz4 and zstd
+func ParseCompression(name string) (sarama.CompressionCodec, error) {
+	var codec sarama.CompressionCodec
+	err := codec.UnmarshalText([]byte(name))
+	if err != nil {
+		return 0, fmt.Errorf("unknown compression: %s", name)
+	}
+
+	return codec, nil
+}
+
+// asyncKafkaEventProducer returns to the caller as soon as the event is buffered.
+// Events are sent in batches, failed ones are logged and counted as dropped
+type asyncKafkaEventProducer struct {
+	logger           *slog.Logger
+	producer         sarama.AsyncProducer
+	encoder          eventEncoder
+	buffer           chan models.URLEvent
+	fullBufferPolicy string
+	metrics          *ProducerMetrics
+	doneCh           <-chan struct{}
+
+	// mu guards buffering of events against the shutdown: closed is set under the write lock,
+	// so no event is buffered after the buffer is drained for the last time
+	mu     sync.RWMutex
+	closed bool
+	// stopped is closed once closed is set
+	stopped chan struct{}
+}
+
+// NewAsyncKafkaEventProducer drains the buffer when doneCh fires,
+// wg is done once every drained event is acknowledged or dropped
+func NewAsyncKafkaEventProducer(
+	logger *slog.Logger,
+	addrs []string,
+	cfg AsyncProducerConfig,
+	metrics *ProducerMetrics,
+	doneCh <-chan struct{},
+	wg *sync.WaitGroup,
+) (repository.EventsProducer, error) {
+	encoder, err := newEventEncoder(cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	kafkaCfg := sarama.NewConfig()
+	kafkaCfg.Producer.Return.Successes = true
+	kafkaCfg.Producer.Return.Errors = true
+	kafkaCfg.Producer.Flush.Messages = cfg.BatchSize
+	kafkaCfg.Producer.Flush.Frequency = cfg.FlushInterval
+	kafkaCfg.Producer.Compression = cfg.Compression
+
+	producer, err := sarama.NewAsyncProducer(addrs, kafkaCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAsyncKafkaEventProducer(logger, producer, encoder, cfg, metrics, doneCh, wg), nil
+}
+
+func newAsyncKafkaEventProducer(
+	logger *slog.Logger,
+	producer sarama.AsyncProducer,
+	encoder eventEncoder,
+	cfg AsyncProducerConfig,
+	metrics *ProducerMetrics,
+	doneCh <-chan struct{},
+	wg *sync.WaitGroup,
+) *asyncKafkaEventProducer {
+	p := &asyncKafkaEventProducer{
+		logger:           logger,
+		producer:         producer,
+		encoder:          encoder,
+		buffer:           make(chan models.URLEvent, cfg.BufferSize),
+		fullBufferPolicy: cfg.FullBufferPolicy,
+		metrics:          metrics,
+		doneCh:           doneCh,
+		stopped:          make(chan struct{}),
+	}
+	go p.forward()
+	// Results of the events drained on shutdown are collected before wg is done
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.collectResults()
+	}()
+
+	return p
+}
+
+func (p *asyncKafkaEventProducer) ProduceEvent(event models.URLEvent) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		p.metrics.dropped.WithLabelValues(dropReasonShutdown).Inc()
+		return
+	}
+
+	// forward keeps draining the buffer until closed is set, so a blocked producer is let through
+	if p.fullBufferPolicy == FullBufferBlock {
+		p.buffer <- event
+		p.metrics.buffered.Inc()
+		return
+	}
+
+	select {
+	case p.buffer <- event:
+		p.metrics.buffered.Inc()
+	default:
+		p.metrics.dropped.WithLabelValues(dropReasonBufferFull).Inc()
+	}
+}
+
+// forward moves events from the buffer to kafka, it blocks while kafka
+// client is saturated so the buffer fills up and the full buffer policy applies
+func (p *asyncKafkaEventProducer) forward() {
+	for {
+		select {
+		case event := <-p.buffer:
+			p.send(event)
+		case <-p.doneCh:
+			go p.stop()
+			p.drain()
+			return
+		}
+	}
+}
+
+// stop rejects events produced from now on, it waits for producers that are buffering an event
+func (p *asyncKafkaEventProducer) stop() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	close(p.stopped)
+}
+
+// drain sends events buffered before the shutdown and closes the kafka client
+func (p *asyncKafkaEventProducer) drain() {
+	for {
+		select {
+		case event := <-p.buffer:
+			p.send(event)
+		case <-p.stopped:
+			for {
+				select {
+				case event := <-p.buffer:
+					p.send(event)
+				default:
+					p.producer.AsyncClose()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *asyncKafkaEventProducer) send(event models.URLEvent) {
+	p.metrics.buffered.Dec()
+
+	msgs, err := p.encoder.messages(event)
+	if err != nil {
+		p.logger.Error(err.Error())
+		p.metrics.dropped.WithLabelValues(dropReasonSendFailed).Inc()
+		return
+	}
+
+	delivery := &eventDelivery{pending: len(msgs)}
+	for _, msg := range msgs {
+		msg.Metadata = delivery
+		p.producer.Input() <- msg
+	}
+}
+
+// eventDelivery is shared by the messages of an event, so results are counted per event.
+// It is changed by collectResults only
+type eventDelivery struct {
+	pending int
+	failed  bool
+}
+
+// countResult counts the event once results of all of its messages are known
+func (p *asyncKafkaEventProducer) countResult(msg *sarama.ProducerMessage, err error) {
+	delivery, ok := msg.Metadata.(*eventDelivery)
+	if !ok {
+		return
+	}
+
+	delivery.pending--
+	delivery.failed = delivery.failed || err != nil
+	if delivery.pending > 0 {
+		return
+	}
+	if delivery.failed {
+		p.metrics.dropped.WithLabelValues(dropReasonSendFailed).Inc()
+		return
+	}
+	p.metrics.sent.Inc()
+}
+
+// collectResults counts acknowledged and failed events until the producer is closed
+func (p *asyncKafkaEventProducer) collectResults() {
+	successes := p.producer.Successes()
+	errors := p.producer.Errors()
+	for successes != nil || errors != nil {
+		select {
+		case msg, ok := <-successes:
+			if !ok {
+				successes = nil
+				continue
+			}
+			p.countResult(msg, nil)
+		case err, ok := <-errors:
+			if !ok {
+				errors = nil
+				continue
+			}
+			p.logger.Error(err.Error())
+			p.countResult(err.Msg, err)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package events
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"CoolUrlShortener/internal/repository/models"
+	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubAsyncProducer hands messages to the test through an unbuffered input,
+// so the test decides when kafka client accepts them
+type stubAsyncProducer struct {
+	sarama.AsyncProducer
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newStubAsyncProducer() *stubAsyncProducer {
+	return &stubAsyncProducer{
+		input:     make(chan *sarama.ProducerMessage),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+}
+
+func (s *stubAsyncProducer) Input() chan<- *sarama.ProducerMessage {
+	return s.input
+}
+
+func (s *stubAsyncProducer) Successes() <-chan *sarama.ProducerMessage {
+	return s.successes
+}
+
+func (s *stubAsyncProducer) Errors() <-chan *sarama.ProducerError {
+	return s.errors
+}
+
+func (s *stubAsyncProducer) AsyncClose() {
+	close(s.successes)
+	close(s.errors)
+}
+
+func newTestAsyncProducer(
+	t *testing.T,
+	fullBufferPolicy string,
+) (*asyncKafkaEventProducer, *stubAsyncProducer, *ProducerMetrics, chan struct{}, *sync.WaitGroup) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	stub := newStubAsyncProducer()
+	metrics := NewProducerMetrics(prometheus.NewRegistry())
+	doneCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+
+	producer := newAsyncKafkaEventProducer(
+		logger,
+		stub,
+		eventEncoder{encoding: EncodingJSON},
+		AsyncProducerConfig{BufferSize: 1, FullBufferPolicy: fullBufferPolicy},
+		metrics,
+		doneCh,
+		wg,
+	)
+	t.Cleanup(func() {
+		select {
+		case <-doneCh:
+		default:
+			close(doneCh)
+		}
+		go func() {
+			for range stub.input {
+			}
+		}()
+	})
+
+	return producer, stub, metrics, doneCh, wg
+}
+
+func followEvent(shortURL string) models.URLEvent {
+	return models.URLEvent{
+		EventType: models.EventTypeFollow,
+		ShortURL:  shortURL,
+		EventTime: time.Now().Unix(),
+	}
+}
+
+func TestAsyncProducerReportsResults(t *testing.T) {
+	producer, stub, metrics, _, _ := newTestAsyncProducer(t, FullBufferDrop)
+
+	producer.ProduceEvent(followEvent("abc"))
+	msg := <-stub.input
+	stub.successes <- msg
+
+	producer.ProduceEvent(followEvent("abd"))
+	msg = <-stub.input
+	stub.errors <- &sarama.ProducerError{Msg: msg, Err: errors.New("kafka is unavailable")}
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.sent) == 1 &&
+			testutil.ToFloat64(metrics.dropped.WithLabelValues(dropReasonSendFailed)) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.buffered))
+}
+
+func TestAsyncProducerCountsEventsOfDualEncoding(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	stub := newStubAsyncProducer()
+	metrics := NewProducerMetrics(prometheus.NewRegistry())
+	doneCh := make(chan struct{})
+	var wg sync.WaitGroup
+
+	producer := newAsyncKafkaEventProducer(
+		logger,
+		stub,
+		eventEncoder{encoding: EncodingDual},
+		AsyncProducerConfig{BufferSize: 1, FullBufferPolicy: FullBufferDrop},
+		metrics,
+		doneCh,
+		&wg,
+	)
+	t.Cleanup(func() {
+		close(doneCh)
+		wg.Wait()
+	})
+
+	// Both messages of the event are acknowledged
+	producer.ProduceEvent(followEvent("abc"))
+	for range 2 {
+		stub.successes <- <-stub.input
+	}
+
+	// One of the messages failed
+	producer.ProduceEvent(followEvent("abd"))
+	stub.successes <- <-stub.input
+	msg := <-stub.input
+	stub.errors <- &sarama.ProducerError{Msg: msg, Err: errors.New("kafka is unavailable")}
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.sent) == 1 &&
+			testutil.ToFloat64(metrics.dropped.WithLabelValues(dropReasonSendFailed)) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAsyncProducerDropsWhenBufferIsFull(t *testing.T) {
+	producer, _, metrics, _, _ := newTestAsyncProducer(t, FullBufferDrop)
+
+	// The first event is taken by the kafka client that does not accept it
+	producer.ProduceEvent(followEvent("abc"))
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.buffered) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	producer.ProduceEvent(followEvent("abd"))
+	producer.ProduceEvent(followEvent("abe"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.buffered))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.dropped.WithLabelValues(dropReasonBufferFull)))
+}
+
+func TestAsyncProducerBlocksWhenBufferIsFull(t *testing.T) {
+	producer, stub, metrics, _, _ := newTestAsyncProducer(t, FullBufferBlock)
+
+	producer.ProduceEvent(followEvent("abc"))
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.buffered) == 0
+	}, time.Second, 10*time.Millisecond)
+	producer.ProduceEvent(followEvent("abd"))
+
+	produced := make(chan struct{})
+	go func() {
+		producer.ProduceEvent(followEvent("abe"))
+		close(produced)
+	}()
+
+	select {
+	case <-produced:
+		t.Fatal("event is produced while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	<-stub.input
+	select {
+	case <-produced:
+	case <-time.After(time.Second):
+		t.Fatal("event is not produced after the buffer is freed")
+	}
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.dropped.WithLabelValues(dropReasonBufferFull)))
+}
+
+func TestAsyncProducerSendsBufferedEventsOnShutdown(t *testing.T) {
+	producer, stub, metrics, doneCh, _ := newTestAsyncProducer(t, FullBufferDrop)
+
+	producer.ProduceEvent(followEvent("abc"))
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.buffered) == 0
+	}, time.Second, 10*time.Millisecond)
+	producer.ProduceEvent(followEvent("abd"))
+
+	close(doneCh)
+	var shortURLs []string
+	for range 2 {
+		msg := <-stub.input
+		shortURLs = append(shortURLs, string(msg.Key.(sarama.StringEncoder)))
+	}
+
+	assert.Eventually(t, func() bool {
+		producer.ProduceEvent(followEvent("abe"))
+		return testutil.ToFloat64(metrics.dropped.WithLabelValues(dropReasonShutdown)) > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"abc", "abd"}, shortURLs)
+}
+
+func TestAsyncProducerShutdownDoesNotLoseEvents(t *testing.T) {
+	producer, stub, metrics, doneCh, shutdownWg := newTestAsyncProducer(t, FullBufferBlock)
+
+	var sent atomic.Int64
+	go func() {
+		for range stub.input {
+			sent.Add(1)
+		}
+	}()
+
+	const producers = 50
+	var wg sync.WaitGroup
+	for i := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			producer.ProduceEvent(followEvent(strconv.Itoa(i)))
+		}()
+	}
+	close(doneCh)
+	wg.Wait()
+
+	shutdownWg.Wait()
+	// Every event is either sent or counted as dropped, none is left in the buffer
+	assert.Eventually(t, func() bool {
+		dropped := testutil.ToFloat64(metrics.dropped.WithLabelValues(dropReasonShutdown))
+		return float64(sent.Load())+dropped == producers
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.buffered))
+}
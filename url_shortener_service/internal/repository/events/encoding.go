@@ -0,0 +1,146 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"CoolUrlShortener/internal/repository/models"
+	eventspb "CoolUrlShortener/pkg/proto/events"
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+)
+
+// Encodings of url events, dual encoding lets consumers migrate to protobuf one by one
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+	EncodingDual     = "dual"
+)
+
+const (
+	// eventsTopic carries json events read by the clickhouse kafka engine as JSONEachRow,
+	// fields may only be added
+	eventsTopic = "events"
+	// eventsV1Topic carries protobuf envelopes
+	eventsV1Topic = "url-events.v1"
+
+	// SchemaVersion is the version of the protobuf envelope written by this producer
+	SchemaVersion = 1
+
+	HeaderContentType   = "content-type"
+	HeaderSchemaVersion = "schema-version"
+
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// legacyURLEvent is the json event as it was before the protobuf envelope, event time is in seconds.
+// Fields added since are omitted when empty, consumers skip unknown fields
+type legacyURLEvent struct {
+	// EventID lets consumers skip events delivered more than once
+	EventID        string `json:"event_id,omitempty"`
+	LongURL        string `json:"long_url"`
+	ShortURL       string `json:"short_url"`
+	EventTime      int64  `json:"event_time"`
+	EventType      int8   `json:"event_type"`
+	Referrer       string `json:"referrer,omitempty"`
+	UserAgent      string `json:"user_agent,omitempty"`
+	AcceptLanguage string `json:"accept_language,omitempty"`
+	ClientIP       string `json:"client_ip,omitempty"`
+	// OwnerID is set on events of urls created by an identified caller
+	OwnerID string `json:"owner_id,omitempty"`
+}
+
+// eventEncoder builds a kafka message for every encoding in use
+type eventEncoder struct {
+	encoding string
+}
+
+func newEventEncoder(encoding string) (eventEncoder, error) {
+	switch encoding {
+	case EncodingJSON, EncodingProtobuf, EncodingDual:
+		return eventEncoder{encoding: encoding}, nil
+	default:
+		return eventEncoder{}, fmt.Errorf("unknown events encoding: %s", encoding)
+	}
+}
+
+func (e eventEncoder) messages(event models.URLEvent) ([]*sarama.ProducerMessage, error) {
+	msgs := make([]*sarama.ProducerMessage, 0, 2)
+	if e.encoding == EncodingJSON || e.encoding == EncodingDual {
+		msg, err := jsonEventMessage(event)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	if e.encoding == EncodingProtobuf || e.encoding == EncodingDual {
+		msg, err := protobufEventMessage(event)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// Messages are keyed by short url, so events of a url keep their order within a partition
+func jsonEventMessage(event models.URLEvent) (*sarama.ProducerMessage, error) {
+	bytes, err := json.Marshal(legacyURLEvent{
+		EventID:        event.ID,
+		LongURL:        event.LongURL,
+		ShortURL:       event.ShortURL,
+		EventTime:      event.EventTime / 1000,
+		EventType:      event.EventType,
+		Referrer:       event.Context.Referrer,
+		UserAgent:      event.Context.UserAgent,
+		AcceptLanguage: event.Context.AcceptLanguage,
+		ClientIP:       event.Context.ClientIP,
+		OwnerID:        event.Context.OwnerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sarama.ProducerMessage{
+		Topic: eventsTopic,
+		Key:   sarama.StringEncoder(event.ShortURL),
+		Value: sarama.ByteEncoder(bytes),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(HeaderContentType), Value: []byte(ContentTypeJSON)},
+		},
+	}, nil
+}
+
+func protobufEventMessage(event models.URLEvent) (*sarama.ProducerMessage, error) {
+	bytes, err := proto.Marshal(&eventspb.UrlEvent{
+		EventId:       event.ID,
+		SchemaVersion: SchemaVersion,
+		EventType:     eventspb.EventType(event.EventType),
+		ShortUrl:      event.ShortURL,
+		LongUrl:       event.LongURL,
+		EventTimeMs:   event.EventTime,
+		Context: &eventspb.RequestContext{
+			Referrer:       event.Context.Referrer,
+			UserAgent:      event.Context.UserAgent,
+			OwnerId:        event.Context.OwnerID,
+			AcceptLanguage: event.Context.AcceptLanguage,
+			ClientIp:       event.Context.ClientIP,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sarama.ProducerMessage{
+		Topic: eventsV1Topic,
+		Key:   sarama.StringEncoder(event.ShortURL),
+		Value: sarama.ByteEncoder(bytes),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(HeaderContentType), Value: []byte(ContentTypeProtobuf)},
+			{Key: []byte(HeaderSchemaVersion), Value: []byte(strconv.Itoa(SchemaVersion))},
+		},
+	}, nil
+}
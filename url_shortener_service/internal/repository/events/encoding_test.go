@@ -0,0 +1,120 @@
+package events
+
+import (
+	"testing"
+
+	"CoolUrlShortener/internal/repository/models"
+	eventspb "CoolUrlShortener/pkg/proto/events"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEventEncoderMessages(t *testing.T) {
+	event := models.URLEvent{
+		ID:        "0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1",
+		LongURL:   "https://test.longurl",
+		ShortURL:  "abc",
+		EventTime: 1700000000123,
+		EventType: models.EventTypeFollow,
+		Context: models.EventContext{
+			Referrer:  "https://referrer.test",
+			UserAgent: "test-agent",
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		encoding       string
+		expectedTopics []string
+	}{
+		{
+			name:           "Json",
+			encoding:       EncodingJSON,
+			expectedTopics: []string{eventsTopic},
+		},
+		{
+			name:           "Protobuf",
+			encoding:       EncodingProtobuf,
+			expectedTopics: []string{eventsV1Topic},
+		},
+		{
+			name:           "Dual",
+			encoding:       EncodingDual,
+			expectedTopics: []string{eventsTopic, eventsV1Topic},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoder, err := newEventEncoder(tc.encoding)
+			require.NoError(t, err)
+
+			msgs, err := encoder.messages(event)
+			require.NoError(t, err)
+
+			topics := make([]string, len(msgs))
+			for i, msg := range msgs {
+				topics[i] = msg.Topic
+				assert.Equal(t, sarama.StringEncoder(event.ShortURL), msg.Key)
+			}
+			assert.Equal(t, tc.expectedTopics, topics)
+		})
+	}
+}
+
+func TestJSONEventMessageKeepsLegacyFormat(t *testing.T) {
+	msg, err := jsonEventMessage(models.URLEvent{
+		ID:        "0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1",
+		LongURL:   "https://test.longurl",
+		ShortURL:  "abc",
+		EventTime: 1700000000123,
+		EventType: models.EventTypeCreate,
+		Context:   models.EventContext{OwnerID: "owner"},
+	})
+	require.NoError(t, err)
+
+	value, err := msg.Value.Encode()
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"event_id":"0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1","long_url":"https://test.longurl","short_url":"abc","event_time":1700000000,"event_type":1,"owner_id":"owner"}`,
+		string(value),
+	)
+	assert.Equal(t, []sarama.RecordHeader{
+		{Key: []byte(HeaderContentType), Value: []byte(ContentTypeJSON)},
+	}, msg.Headers)
+}
+
+func TestProtobufEventMessage(t *testing.T) {
+	msg, err := protobufEventMessage(models.URLEvent{
+		ID:        "0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1",
+		LongURL:   "https://test.longurl",
+		ShortURL:  "abc",
+		EventTime: 1700000000123,
+		EventType: models.EventTypeUpdate,
+		Context:   models.EventContext{OwnerID: "owner"},
+	})
+	require.NoError(t, err)
+
+	value, err := msg.Value.Encode()
+	require.NoError(t, err)
+	var decoded eventspb.UrlEvent
+	require.NoError(t, proto.Unmarshal(value, &decoded))
+
+	assert.Equal(t, "0b9f7a52-5d2e-4c3a-9a57-2f7ad0e4c0d1", decoded.GetEventId())
+	assert.Equal(t, uint32(SchemaVersion), decoded.GetSchemaVersion())
+	assert.Equal(t, eventspb.EventType_EVENT_TYPE_UPDATE, decoded.GetEventType())
+	assert.Equal(t, int64(1700000000123), decoded.GetEventTimeMs())
+	assert.Equal(t, "owner", decoded.GetContext().GetOwnerId())
+	assert.Equal(t, []sarama.RecordHeader{
+		{Key: []byte(HeaderContentType), Value: []byte(ContentTypeProtobuf)},
+		{Key: []byte(HeaderSchemaVersion), Value: []byte("1")},
+	}, msg.Headers)
+}
+
+func TestUnknownEncoding(t *testing.T) {
+	_, err := newEventEncoder("avro")
+	assert.EqualError(t, err, "unknown events encoding: avro")
+}
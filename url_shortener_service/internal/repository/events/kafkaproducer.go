@@ -1,8 +1,6 @@
 package events
 
 import (
-	"encoding/json"
-	"log"
 	"log/slog"
 
 	"CoolUrlShortener/internal/repository"
@@ -10,17 +8,33 @@ import (
 	"github.com/IBM/sarama"
 )
 
+// Modes of EventsProducer that can be selected through config
+const (
+	ProducerModeSync  = "sync"
+	ProducerModeAsync = "async"
+)
+
+// kafkaEventProducer blocks the caller until kafka acknowledges the event
 type kafkaEventProducer struct {
 	logger         *slog.Logger
 	eventsProducer sarama.SyncProducer
+	encoder        eventEncoder
+	metrics        *ProducerMetrics
 }
 
 func NewKafkaEventProducer(
 	logger *slog.Logger,
 	addrs []string,
 	kafkaCfg *sarama.Config,
+	encoding string,
+	metrics *ProducerMetrics,
 	doneCh <-chan struct{},
 ) (repository.EventsProducer, error) {
+	encoder, err := newEventEncoder(encoding)
+	if err != nil {
+		return nil, err
+	}
+
 	producer, err := sarama.NewSyncProducer(addrs, kafkaCfg)
 	if err != nil {
 		return nil, err
@@ -36,25 +50,24 @@ func NewKafkaEventProducer(
 	return &kafkaEventProducer{
 		logger:         logger,
 		eventsProducer: producer,
+		encoder:        encoder,
+		metrics:        metrics,
 	}, nil
 }
 
 func (k *kafkaEventProducer) ProduceEvent(event models.URLEvent) {
-	bytes, err := json.Marshal(event)
+	msgs, err := k.encoder.messages(event)
 	if err != nil {
 		k.logger.Error(err.Error())
+		k.metrics.dropped.WithLabelValues(dropReasonSendFailed).Inc()
 		return
 	}
 
-	log.Println(bytes)
-	msg := &sarama.ProducerMessage{
-		Topic: "events",
-		Key:   sarama.StringEncoder(event.ShortURL),
-		Value: sarama.ByteEncoder(bytes),
-	}
-
-	_, _, err = k.eventsProducer.SendMessage(msg)
+	err = k.eventsProducer.SendMessages(msgs)
 	if err != nil {
 		k.logger.Error(err.Error())
+		k.metrics.dropped.WithLabelValues(dropReasonSendFailed).Inc()
+		return
 	}
+	k.metrics.sent.Inc()
 }
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/internal/repository/models"
+	"github.com/IBM/sarama"
+)
+
+type kafkaEventPublisher struct {
+	producer sarama.SyncProducer
+	encoder  eventEncoder
+}
+
+func NewKafkaEventPublisher(
+	logger *slog.Logger,
+	addrs []string,
+	kafkaCfg *sarama.Config,
+	encoding string,
+	doneCh <-chan struct{},
+) (repository.EventsPublisher, error) {
+	encoder, err := newEventEncoder(encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	producer, err := sarama.NewSyncProducer(addrs, kafkaCfg)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-doneCh
+		err := producer.Close()
+		if err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+
+	return &kafkaEventPublisher{
+		producer: producer,
+		encoder:  encoder,
+	}, nil
+}
+
+// PublishEvents fails if any of events was not delivered, the delivered ones may be sent again on retry
+func (k *kafkaEventPublisher) PublishEvents(ctx context.Context, events []models.URLEvent) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(events))
+	for _, event := range events {
+		eventMsgs, err := k.encoder.messages(event)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, eventMsgs...)
+	}
+
+	return k.producer.SendMessages(msgs)
+}
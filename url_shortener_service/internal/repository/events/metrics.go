@@ -0,0 +1,46 @@
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons of dropped events
+const (
+	dropReasonBufferFull = "buffer_full"
+	dropReasonSendFailed = "send_failed"
+	dropReasonShutdown   = "shutdown"
+)
+
+// ProducerMetrics counts events of EventsProducer, both sync and async producers report them.
+// An event is counted once however many messages it is encoded into
+type ProducerMetrics struct {
+	sent     prometheus.Counter
+	dropped  *prometheus.CounterVec
+	buffered prometheus.Gauge
+}
+
+func NewProducerMetrics(registerer prometheus.Registerer) *ProducerMetrics {
+	metrics := &ProducerMetrics{
+		sent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "url_shortener",
+			Subsystem: "events_producer",
+			Name:      "sent_total",
+			Help:      "Events acknowledged by kafka in every encoding in use.",
+		}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "url_shortener",
+			Subsystem: "events_producer",
+			Name:      "dropped_total",
+			Help:      "Events that were not delivered to kafka in at least one encoding by reason.",
+		}, []string{"reason"}),
+		buffered: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "url_shortener",
+			Subsystem: "events_producer",
+			Name:      "buffered",
+			Help:      "Events waiting in the buffer of the async producer.",
+		}),
+	}
+	registerer.MustRegister(metrics.sent, metrics.dropped, metrics.buffered)
+
+	return metrics
+}
@@ -0,0 +1,171 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "CoolUrlShortener/internal/repository/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// EventsOutbox is an autogenerated mock type for the EventsOutbox type
+type EventsOutbox struct {
+	mock.Mock
+}
+
+// AddEvents provides a mock function with given fields: ctx, events
+func (_m *EventsOutbox) AddEvents(ctx context.Context, events []models.URLEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.URLEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimPartition provides a mock function with given fields: ctx, ttl
+func (_m *EventsOutbox) ClaimPartition(ctx context.Context, ttl time.Duration) (models.OutboxLease, error) {
+	ret := _m.Called(ctx, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPartition")
+	}
+
+	var r0 models.OutboxLease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (models.OutboxLease, error)); ok {
+		return rf(ctx, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) models.OutboxLease); ok {
+		r0 = rf(ctx, ttl)
+	} else {
+		r0 = ret.Get(0).(models.OutboxLease)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSentEvents provides a mock function with given fields: ctx, sentBefore
+func (_m *EventsOutbox) DeleteSentEvents(ctx context.Context, sentBefore time.Time) (int, error) {
+	ret := _m.Called(ctx, sentBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSentEvents")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, sentBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, sentBefore)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, sentBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkEventsSent provides a mock function with given fields: ctx, ids, sentAt
+func (_m *EventsOutbox) MarkEventsSent(ctx context.Context, ids []int64, sentAt time.Time) error {
+	ret := _m.Called(ctx, ids, sentAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEventsSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, time.Time) error); ok {
+		r0 = rf(ctx, ids, sentAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleasePartition provides a mock function with given fields: ctx, lease
+func (_m *EventsOutbox) ReleasePartition(ctx context.Context, lease models.OutboxLease) error {
+	ret := _m.Called(ctx, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleasePartition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OutboxLease) error); ok {
+		r0 = rf(ctx, lease)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsentEvents provides a mock function with given fields: ctx, partition, limit
+func (_m *EventsOutbox) UnsentEvents(ctx context.Context, partition int, limit int) ([]models.OutboxEvent, error) {
+	ret := _m.Called(ctx, partition, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsentEvents")
+	}
+
+	var r0 []models.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.OutboxEvent, error)); ok {
+		return rf(ctx, partition, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.OutboxEvent); ok {
+		r0 = rf(ctx, partition, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, partition, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventsOutbox creates a new instance of EventsOutbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventsOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventsOutbox {
+	mock := &EventsOutbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "CoolUrlShortener/internal/repository/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventsPublisher is an autogenerated mock type for the EventsPublisher type
+type EventsPublisher struct {
+	mock.Mock
+}
+
+// PublishEvents provides a mock function with given fields: ctx, events
+func (_m *EventsPublisher) PublishEvents(ctx context.Context, events []models.URLEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.URLEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEventsPublisher creates a new instance of EventsPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventsPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventsPublisher {
+	mock := &EventsPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,9 +3,12 @@
 package mocks
 
 import (
+	domain "CoolUrlShortener/internal/domain"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // URLCache is an autogenerated mock type for the URLCache type
@@ -13,6 +16,42 @@ type URLCache struct {
 	mock.Mock
 }
 
+// DeleteLongURL provides a mock function with given fields: ctx, shortURL
+func (_m *URLCache) DeleteLongURL(ctx context.Context, shortURL string) error {
+	ret := _m.Called(ctx, shortURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLongURL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shortURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLongURLs provides a mock function with given fields: ctx, shortURLs
+func (_m *URLCache) DeleteLongURLs(ctx context.Context, shortURLs []string) error {
+	ret := _m.Called(ctx, shortURLs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLongURLs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, shortURLs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetLongURL provides a mock function with given fields: ctx, shortURL
 func (_m *URLCache) GetLongURL(ctx context.Context, shortURL string) (string, error) {
 	ret := _m.Called(ctx, shortURL)
@@ -41,17 +80,35 @@ func (_m *URLCache) GetLongURL(ctx context.Context, shortURL string) (string, er
 	return r0, r1
 }
 
-// SetLongURL provides a mock function with given fields: ctx, shortURL, longURL
-func (_m *URLCache) SetLongURL(ctx context.Context, shortURL string, longURL string) error {
-	ret := _m.Called(ctx, shortURL, longURL)
+// SetLongURL provides a mock function with given fields: ctx, shortURL, longURL, expiresAt
+func (_m *URLCache) SetLongURL(ctx context.Context, shortURL string, longURL string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, shortURL, longURL, expiresAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetLongURL")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, shortURL, longURL)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, shortURL, longURL, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLongURLs provides a mock function with given fields: ctx, urls
+func (_m *URLCache) SetLongURLs(ctx context.Context, urls []domain.URLData) error {
+	ret := _m.Called(ctx, urls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLongURLs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLData) error); ok {
+		r0 = rf(ctx, urls)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UrlRepo is an autogenerated mock type for the UrlRepo type
@@ -14,27 +16,55 @@ type UrlRepo struct {
 	mock.Mock
 }
 
-// GetLongURL provides a mock function with given fields: ctx, shortUrl
-func (_m *UrlRepo) GetLongURL(ctx context.Context, shortUrl string) (string, error) {
-	ret := _m.Called(ctx, shortUrl)
+// CountURLs provides a mock function with given fields: ctx, ownerID
+func (_m *UrlRepo) CountURLs(ctx context.Context, ownerID string) (int, error) {
+	ret := _m.Called(ctx, ownerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLongURL")
+		panic("no return value specified for CountURLs")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteURL provides a mock function with given fields: ctx, shortURL, ownerID
+func (_m *UrlRepo) DeleteURL(ctx context.Context, shortURL string, ownerID string) (string, error) {
+	ret := _m.Called(ctx, shortURL, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURL")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, shortUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, shortURL, ownerID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, shortUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, shortURL, ownerID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shortUrl)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, shortURL, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,9 +72,9 @@ func (_m *UrlRepo) GetLongURL(ctx context.Context, shortUrl string) (string, err
 	return r0, r1
 }
 
-// GetShortURLByLongURL provides a mock function with given fields: ctx, longURL
-func (_m *UrlRepo) GetShortURLByLongURL(ctx context.Context, longURL string) (string, error) {
-	ret := _m.Called(ctx, longURL)
+// GetShortURLByLongURL provides a mock function with given fields: ctx, longURL, ownerID
+func (_m *UrlRepo) GetShortURLByLongURL(ctx context.Context, longURL string, ownerID string) (string, error) {
+	ret := _m.Called(ctx, longURL, ownerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetShortURLByLongURL")
@@ -52,17 +82,193 @@ func (_m *UrlRepo) GetShortURLByLongURL(ctx context.Context, longURL string) (st
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, longURL)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, longURL, ownerID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, longURL)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, longURL, ownerID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, longURL, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShortURLsByLongURLs provides a mock function with given fields: ctx, longURLs, ownerID
+func (_m *UrlRepo) GetShortURLsByLongURLs(ctx context.Context, longURLs []string, ownerID string) (map[string]string, error) {
+	ret := _m.Called(ctx, longURLs, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShortURLsByLongURLs")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) (map[string]string, error)); ok {
+		return rf(ctx, longURLs, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) map[string]string); ok {
+		r0 = rf(ctx, longURLs, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, longURLs, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURL provides a mock function with given fields: ctx, shortUrl
+func (_m *UrlRepo) GetURL(ctx context.Context, shortUrl string) (domain.URLData, error) {
+	ret := _m.Called(ctx, shortUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURL")
+	}
+
+	var r0 domain.URLData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.URLData, error)); ok {
+		return rf(ctx, shortUrl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.URLData); ok {
+		r0 = rf(ctx, shortUrl)
+	} else {
+		r0 = ret.Get(0).(domain.URLData)
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, longURL)
+		r1 = rf(ctx, shortUrl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLs provides a mock function with given fields: ctx, shortURLs
+func (_m *UrlRepo) GetURLs(ctx context.Context, shortURLs []string) ([]domain.URLData, error) {
+	ret := _m.Called(ctx, shortURLs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetURLs")
+	}
+
+	var r0 []domain.URLData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.URLData, error)); ok {
+		return rf(ctx, shortURLs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.URLData); ok {
+		r0 = rf(ctx, shortURLs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.URLData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, shortURLs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAllURLs provides a mock function with given fields: ctx, afterID, limit
+func (_m *UrlRepo) ListAllURLs(ctx context.Context, afterID int64, limit int) ([]domain.URLData, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllURLs")
+	}
+
+	var r0 []domain.URLData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]domain.URLData, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []domain.URLData); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.URLData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListURLs provides a mock function with given fields: ctx, ownerID, paginationParams
+func (_m *UrlRepo) ListURLs(ctx context.Context, ownerID string, paginationParams domain.PaginationParams) ([]domain.URLData, error) {
+	ret := _m.Called(ctx, ownerID, paginationParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLs")
+	}
+
+	var r0 []domain.URLData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PaginationParams) ([]domain.URLData, error)); ok {
+		return rf(ctx, ownerID, paginationParams)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PaginationParams) []domain.URLData); ok {
+		r0 = rf(ctx, ownerID, paginationParams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.URLData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PaginationParams) error); ok {
+		r1 = rf(ctx, ownerID, paginationParams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceURLs provides a mock function with given fields: ctx, urls, overwriteOwner
+func (_m *UrlRepo) ReplaceURLs(ctx context.Context, urls []domain.URLData, overwriteOwner bool) (int, error) {
+	ret := _m.Called(ctx, urls, overwriteOwner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceURLs")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLData, bool) (int, error)); ok {
+		return rf(ctx, urls, overwriteOwner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLData, bool) int); ok {
+		r0 = rf(ctx, urls, overwriteOwner)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.URLData, bool) error); ok {
+		r1 = rf(ctx, urls, overwriteOwner)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -88,6 +294,82 @@ func (_m *UrlRepo) SaveURL(ctx context.Context, urlData domain.URLData) error {
 	return r0
 }
 
+// SaveURLs provides a mock function with given fields: ctx, urls
+func (_m *UrlRepo) SaveURLs(ctx context.Context, urls []domain.URLData) ([]int64, error) {
+	ret := _m.Called(ctx, urls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveURLs")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLData) ([]int64, error)); ok {
+		return rf(ctx, urls)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLData) []int64); ok {
+		r0 = rf(ctx, urls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.URLData) error); ok {
+		r1 = rf(ctx, urls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetURLActive provides a mock function with given fields: ctx, shortURL, ownerID, active
+func (_m *UrlRepo) SetURLActive(ctx context.Context, shortURL string, ownerID string, active bool) error {
+	ret := _m.Called(ctx, shortURL, ownerID, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, shortURL, ownerID, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLongURL provides a mock function with given fields: ctx, shortURL, ownerID, longURL, updatedAt
+func (_m *UrlRepo) UpdateLongURL(ctx context.Context, shortURL string, ownerID string, longURL string, updatedAt time.Time) (domain.URLData, error) {
+	ret := _m.Called(ctx, shortURL, ownerID, longURL, updatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLongURL")
+	}
+
+	var r0 domain.URLData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) (domain.URLData, error)); ok {
+		return rf(ctx, shortURL, ownerID, longURL, updatedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) domain.URLData); ok {
+		r0 = rf(ctx, shortURL, ownerID, longURL, updatedAt)
+	} else {
+		r0 = ret.Get(0).(domain.URLData)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, time.Time) error); ok {
+		r1 = rf(ctx, shortURL, ownerID, longURL, updatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUrlRepo creates a new instance of UrlRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUrlRepo(t interface {
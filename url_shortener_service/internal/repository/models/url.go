@@ -1,13 +1,43 @@
 package models
 
+import "time"
+
 const (
 	EventTypeCreate = 1
 	EventTypeFollow = 2
+	EventTypeDelete = 3
+	EventTypeUpdate = 4
 )
 
 type URLEvent struct {
-	LongURL   string `json:"long_url"`
-	ShortURL  string `json:"short_url"`
-	EventTime int64  `json:"event_time"`
-	EventType int8   `json:"event_type"`
+	// ID is a uuid, consumers use it to skip events delivered more than once
+	ID       string
+	LongURL  string
+	ShortURL string
+	// EventTime is unix time in milliseconds
+	EventTime int64
+	EventType int8
+	Context   EventContext
+}
+
+// EventContext describes the request that caused the event, fields are empty when unknown
+type EventContext struct {
+	Referrer       string `json:"referrer,omitempty"`
+	UserAgent      string `json:"user_agent,omitempty"`
+	AcceptLanguage string `json:"accept_language,omitempty"`
+	ClientIP       string `json:"client_ip,omitempty"`
+	OwnerID        string `json:"owner_id,omitempty"`
+}
+
+// OutboxEvent is an event stored in the outbox until it is published
+type OutboxEvent struct {
+	ID    int64
+	Event URLEvent
+}
+
+// OutboxLease is a partition of the outbox leased by a relay, events of a short url always
+// fall into the same partition
+type OutboxLease struct {
+	Partition    int
+	ClaimedUntil time.Time
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"CoolUrlShortener/internal/repository/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name TxManager
+type TxManager interface {
+	// WithinTx runs fn in a transaction that is committed when fn returns nil.
+	// Repositories take part in the transaction when they are called with ctx passed to fn
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EventsOutbox keeps events until they are published, so an event is stored
+// in the same transaction as the change it describes
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name EventsOutbox
+type EventsOutbox interface {
+	AddEvents(ctx context.Context, events []models.URLEvent) error
+	// ClaimPartition leases the least recently relayed partition with unsent events for ttl.
+	// It returns errs.ErrNoOutboxPartition when every such partition is leased by other relays
+	ClaimPartition(ctx context.Context, ttl time.Duration) (models.OutboxLease, error)
+	// UnsentEvents returns up to limit oldest unsent events of the partition
+	UnsentEvents(ctx context.Context, partition, limit int) ([]models.OutboxEvent, error)
+	MarkEventsSent(ctx context.Context, ids []int64, sentAt time.Time) error
+	// ReleasePartition ends the lease early, a lease that has expired and was claimed again is kept
+	ReleasePartition(ctx context.Context, lease models.OutboxLease) error
+	// DeleteSentEvents removes events sent before sentBefore and returns their number
+	DeleteSentEvents(ctx context.Context, sentBefore time.Time) (int, error)
+}
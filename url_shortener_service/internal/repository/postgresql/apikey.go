@@ -0,0 +1,80 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type apiKeyRepoPostgres struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewAPIKeyRepoPostgres(
+	dbPool *pgxpool.Pool,
+) repository.APIKeyRepo {
+	return &apiKeyRepoPostgres{
+		dbPool: dbPool,
+	}
+}
+
+const saveAPIKeyQuery = `INSERT INTO api_keys (owner_id, name, key_hash, created_at) 
+VALUES ($1, $2, $3, $4) 
+RETURNING id`
+
+func (r *apiKeyRepoPostgres) SaveAPIKey(ctx context.Context, apiKey domain.APIKey) (int64, error) {
+	var id int64
+	row := r.dbPool.QueryRow(ctx, saveAPIKeyQuery, apiKey.OwnerID, apiKey.Name, apiKey.KeyHash, apiKey.CreatedAt)
+
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getAPIKeyByHashQuery = `SELECT id, owner_id, name, key_hash, created_at, revoked_at 
+FROM api_keys WHERE key_hash = $1`
+
+func (r *apiKeyRepoPostgres) GetAPIKeyByHash(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	var apiKey domain.APIKey
+	var revokedAt *time.Time
+	row := r.dbPool.QueryRow(ctx, getAPIKeyByHashQuery, keyHash)
+
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.OwnerID,
+		&apiKey.Name,
+		&apiKey.KeyHash,
+		&apiKey.CreatedAt,
+		&revokedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.APIKey{}, errs.ErrNoAPIKey
+	}
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	if revokedAt != nil {
+		apiKey.RevokedAt = *revokedAt
+	}
+	return apiKey, nil
+}
+
+const revokeAPIKeyQuery = `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+func (r *apiKeyRepoPostgres) RevokeAPIKey(ctx context.Context, id int64, revokedAt time.Time) error {
+	tag, err := r.dbPool.Exec(ctx, revokeAPIKeyQuery, id, revokedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrNoAPIKey
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"CoolUrlShortener/pkg/idgen"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type sequenceIDGenerator struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewSequenceIDGenerator(dbPool *pgxpool.Pool) idgen.IDGenerator {
+	return &sequenceIDGenerator{
+		dbPool: dbPool,
+	}
+}
+
+const nextIDQuery = `SELECT nextval('url_data_id_seq')`
+
+func (g *sequenceIDGenerator) NextID(ctx context.Context) (uint64, error) {
+	var id int64
+	err := g.dbPool.QueryRow(ctx, nextIDQuery).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(id), nil
+}
+
+type idRangeLeaser struct {
+	dbPool    *pgxpool.Pool
+	rangeName string
+}
+
+func NewIDRangeLeaser(dbPool *pgxpool.Pool, rangeName string) idgen.RangeLeaser {
+	return &idRangeLeaser{
+		dbPool:    dbPool,
+		rangeName: rangeName,
+	}
+}
+
+const leaseIDRangeQuery = `UPDATE id_ranges SET next_id = next_id + $2 WHERE name = $1 RETURNING next_id - $2`
+
+func (l *idRangeLeaser) LeaseRange(ctx context.Context, size uint64) (uint64, error) {
+	var start int64
+	err := l.dbPool.QueryRow(ctx, leaseIDRangeQuery, l.rangeName, int64(size)).Scan(&start)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(start), nil
+}
+
+type workerLeaser struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewWorkerLeaser(dbPool *pgxpool.Pool) idgen.WorkerLeaser {
+	return &workerLeaser{
+		dbPool: dbPool,
+	}
+}
+
+// leaseWorkerIDQuery takes the lowest worker id that is free. Replicas racing for the same id
+// are told apart by the conflict, the loser gets no rows
+const leaseWorkerIDQuery = `INSERT INTO snowflake_workers (worker_id, owner, leased_until) 
+SELECT w.id, $1, now() + $2 * INTERVAL '1 millisecond' 
+FROM generate_series(0, $3::INT) AS w(id) 
+WHERE NOT EXISTS (SELECT 1 FROM snowflake_workers s WHERE s.worker_id = w.id AND s.leased_until > now()) 
+ORDER BY w.id 
+LIMIT 1 
+ON CONFLICT (worker_id) DO UPDATE SET owner = EXCLUDED.owner, leased_until = EXCLUDED.leased_until 
+WHERE snowflake_workers.leased_until <= now() 
+RETURNING worker_id`
+
+// maxLeaseWorkerIDAttempts bounds retries of replicas that start at the same time
+const maxLeaseWorkerIDAttempts = 5
+
+func (l *workerLeaser) LeaseWorkerID(ctx context.Context, owner string, ttl time.Duration) (int64, error) {
+	for range maxLeaseWorkerIDAttempts {
+		var workerID int64
+		err := l.dbPool.QueryRow(ctx, leaseWorkerIDQuery, owner, ttl.Milliseconds(), idgen.MaxWorkerID).Scan(&workerID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		return workerID, nil
+	}
+
+	return 0, errors.New("no free snowflake worker id")
+}
+
+const renewWorkerIDQuery = `UPDATE snowflake_workers SET leased_until = now() + $3 * INTERVAL '1 millisecond' 
+WHERE worker_id = $1 AND owner = $2`
+
+func (l *workerLeaser) RenewWorkerID(ctx context.Context, workerID int64, owner string, ttl time.Duration) error {
+	tag, err := l.dbPool.Exec(ctx, renewWorkerIDQuery, workerID, owner, ttl.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return idgen.ErrWorkerLeaseLost
+	}
+
+	return nil
+}
+
+const releaseWorkerIDQuery = `DELETE FROM snowflake_workers WHERE worker_id = $1 AND owner = $2`
+
+func (l *workerLeaser) ReleaseWorkerID(ctx context.Context, workerID int64, owner string) error {
+	_, err := l.dbPool.Exec(ctx, releaseWorkerIDQuery, workerID, owner)
+	return err
+}
@@ -0,0 +1,137 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/internal/repository/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type eventsOutboxPostgres struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewEventsOutboxPostgres(dbPool *pgxpool.Pool) repository.EventsOutbox {
+	return &eventsOutboxPostgres{
+		dbPool: dbPool,
+	}
+}
+
+// event_time is written in seconds as well, replicas of the previous version may relay the event
+const addEventsQuery = `INSERT INTO url_events_outbox (event_id, short_url, long_url, event_time, event_time_ms, event_type, context) 
+SELECT id, short_url, long_url, event_time_ms / 1000, event_time_ms, event_type, context 
+FROM unnest($1::UUID[], $2::VARCHAR[], $3::TEXT[], $4::BIGINT[], $5::SMALLINT[], $6::JSONB[]) 
+AS e(id, short_url, long_url, event_time_ms, event_type, context)`
+
+func (o *eventsOutboxPostgres) AddEvents(ctx context.Context, events []models.URLEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(events))
+	shortURLs := make([]string, len(events))
+	longURLs := make([]string, len(events))
+	eventTimes := make([]int64, len(events))
+	eventTypes := make([]int16, len(events))
+	contexts := make([]models.EventContext, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+		shortURLs[i] = event.ShortURL
+		longURLs[i] = event.LongURL
+		eventTimes[i] = event.EventTime
+		eventTypes[i] = int16(event.EventType)
+		contexts[i] = event.Context
+	}
+
+	_, err := conn(ctx, o.dbPool).Exec(ctx, addEventsQuery, ids, shortURLs, longURLs, eventTimes, eventTypes, contexts)
+	return err
+}
+
+// outboxPartition is the partition of an event, partitions are rows of url_events_outbox_partitions
+const outboxPartition = `mod(hashtext(short_url) & 2147483647, (SELECT count(*) FROM url_events_outbox_partitions))`
+
+const claimPartitionQuery = `UPDATE url_events_outbox_partitions 
+SET claimed_until = now() + $1 * INTERVAL '1 millisecond' 
+WHERE partition = (
+    SELECT p.partition FROM url_events_outbox_partitions p 
+    WHERE p.claimed_until < now() 
+    AND EXISTS (SELECT 1 FROM url_events_outbox WHERE sent_at IS NULL AND ` + outboxPartition + ` = p.partition) 
+    ORDER BY p.claimed_until 
+    LIMIT 1 
+    FOR UPDATE SKIP LOCKED
+) 
+RETURNING partition, claimed_until`
+
+func (o *eventsOutboxPostgres) ClaimPartition(ctx context.Context, ttl time.Duration) (models.OutboxLease, error) {
+	var lease models.OutboxLease
+	err := conn(ctx, o.dbPool).QueryRow(ctx, claimPartitionQuery, ttl.Milliseconds()).
+		Scan(&lease.Partition, &lease.ClaimedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.OutboxLease{}, errs.ErrNoOutboxPartition
+	}
+	if err != nil {
+		return models.OutboxLease{}, err
+	}
+
+	return lease, nil
+}
+
+// Events written by replicas of the previous version have event time in seconds only
+const unsentEventsQuery = `SELECT id, event_id, short_url, long_url, COALESCE(event_time_ms, event_time * 1000), event_type, context 
+FROM url_events_outbox 
+WHERE sent_at IS NULL AND ` + outboxPartition + ` = $1 
+ORDER BY id 
+LIMIT $2`
+
+func (o *eventsOutboxPostgres) UnsentEvents(ctx context.Context, partition, limit int) ([]models.OutboxEvent, error) {
+	rows, err := conn(ctx, o.dbPool).Query(ctx, unsentEventsQuery, partition, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.OutboxEvent, error) {
+		var event models.OutboxEvent
+		err := row.Scan(
+			&event.ID,
+			&event.Event.ID,
+			&event.Event.ShortURL,
+			&event.Event.LongURL,
+			&event.Event.EventTime,
+			&event.Event.EventType,
+			&event.Event.Context,
+		)
+		return event, err
+	})
+}
+
+const markEventsSentQuery = `UPDATE url_events_outbox SET sent_at = $2 WHERE id = ANY($1)`
+
+func (o *eventsOutboxPostgres) MarkEventsSent(ctx context.Context, ids []int64, sentAt time.Time) error {
+	_, err := conn(ctx, o.dbPool).Exec(ctx, markEventsSentQuery, ids, sentAt)
+	return err
+}
+
+// Released partitions go to the end of the queue of ClaimPartition
+const releasePartitionQuery = `UPDATE url_events_outbox_partitions SET claimed_until = now() 
+WHERE partition = $1 AND claimed_until = $2`
+
+func (o *eventsOutboxPostgres) ReleasePartition(ctx context.Context, lease models.OutboxLease) error {
+	_, err := conn(ctx, o.dbPool).Exec(ctx, releasePartitionQuery, lease.Partition, lease.ClaimedUntil)
+	return err
+}
+
+const deleteSentEventsQuery = `DELETE FROM url_events_outbox WHERE sent_at < $1`
+
+func (o *eventsOutboxPostgres) DeleteSentEvents(ctx context.Context, sentBefore time.Time) (int, error) {
+	tag, err := conn(ctx, o.dbPool).Exec(ctx, deleteSentEventsQuery, sentBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,48 @@
+package postgresql
+
+import (
+	"context"
+
+	"CoolUrlShortener/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// querier is implemented by both the pool and a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// conn returns the transaction started by TxManager.WithinTx or the pool outside of it
+func conn(ctx context.Context, dbPool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return dbPool
+}
+
+type txManagerPostgres struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTxManagerPostgres(dbPool *pgxpool.Pool) repository.TxManager {
+	return &txManagerPostgres{
+		dbPool: dbPool,
+	}
+}
+
+// WithinTx joins the transaction of ctx when there is one
+func (m *txManagerPostgres) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	return pgx.BeginFunc(ctx, m.dbPool, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
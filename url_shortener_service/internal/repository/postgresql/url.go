@@ -3,14 +3,23 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"time"
 
 	"CoolUrlShortener/internal/domain"
 	"CoolUrlShortener/internal/errs"
 	"CoolUrlShortener/internal/repository"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const (
+	uniqueViolationCode = "23505"
+
+	primaryKeyConstraint = "url_data_pkey"
+	shortURLConstraint   = "url_data_short_url_key"
+)
+
 type urlRepoPostgres struct {
 	dbPool *pgxpool.Pool
 }
@@ -23,28 +32,96 @@ func NewUrlRepoPostgres(
 	}
 }
 
-const getLongURLQuery = `SELECT long_url FROM url_data WHERE short_url = $1`
+const urlDataColumns = `id, short_url, long_url, created_at, expires_at, is_active, updated_at, owner_id`
 
-func (r *urlRepoPostgres) GetLongURL(ctx context.Context, shortUrl string) (string, error) {
-	var longURL string
-	row := r.dbPool.QueryRow(ctx, getLongURLQuery, shortUrl)
+const getURLQuery = `SELECT ` + urlDataColumns + ` FROM url_data WHERE short_url = $1`
 
-	err := row.Scan(&longURL)
+func (r *urlRepoPostgres) GetURL(ctx context.Context, shortUrl string) (domain.URLData, error) {
+	row := conn(ctx, r.dbPool).QueryRow(ctx, getURLQuery, shortUrl)
+	return scanURLData(row)
+}
+
+const getURLsQuery = `SELECT ` + urlDataColumns + ` FROM url_data WHERE short_url = ANY($1)`
+
+func (r *urlRepoPostgres) GetURLs(ctx context.Context, shortURLs []string) ([]domain.URLData, error) {
+	rows, err := conn(ctx, r.dbPool).Query(ctx, getURLsQuery, shortURLs)
+	if err != nil {
+		return nil, err
+	}
+	return scanURLDataRows(rows, len(shortURLs))
+}
+
+func scanURLDataRows(rows pgx.Rows, capacity int) ([]domain.URLData, error) {
+	defer rows.Close()
+
+	urls := make([]domain.URLData, 0, capacity)
+	for rows.Next() {
+		urlData, err := scanURLData(rows)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, urlData)
+	}
+
+	return urls, rows.Err()
+}
+
+func scanURLData(row pgx.Row) (domain.URLData, error) {
+	var urlData domain.URLData
+	var expiresAt, updatedAt *time.Time
+	var ownerID *string
+
+	err := row.Scan(
+		&urlData.ID,
+		&urlData.ShortUrl,
+		&urlData.LongUrl,
+		&urlData.CreatedAt,
+		&expiresAt,
+		&urlData.IsActive,
+		&updatedAt,
+		&ownerID,
+	)
 	if errors.Is(err, pgx.ErrNoRows) {
-		return "", errs.ErrNoURL
+		return domain.URLData{}, errs.ErrNoURL
+	}
+	if err != nil {
+		return domain.URLData{}, err
 	}
 
-	return longURL, err
+	if expiresAt != nil {
+		urlData.ExpiresAt = *expiresAt
+	}
+	if updatedAt != nil {
+		urlData.UpdatedAt = *updatedAt
+	}
+	if ownerID != nil {
+		urlData.OwnerID = *ownerID
+	}
+	return urlData, nil
 }
 
-const saveURLQuery = `INSERT INTO url_data (id, short_url, long_url, created_at) 
-VALUES ($1, $2, $3, $4)`
+// nullableOwnerID maps anonymous owner to NULL
+func nullableOwnerID(ownerID string) *string {
+	if ownerID == "" {
+		return nil
+	}
+	return &ownerID
+}
+
+const saveURLQuery = `INSERT INTO url_data (id, short_url, long_url, created_at, expires_at, owner_id) 
+VALUES ($1, $2, $3, $4, $5, $6)`
 
-const getShortURLByLongURL = `SELECT short_url FROM url_data WHERE long_url = $1`
+// Links are deduplicated only within the same owner, anonymous links only with each other.
+// Links with an expiry, deactivated links and links with an edited destination
+// are never reused for deduplication: the owner of an edited link may move it
+// again, so handing it out for the new long url would be a surprise for the caller
+const getShortURLByLongURL = `SELECT short_url FROM url_data 
+WHERE long_url = $1 AND owner_id IS NOT DISTINCT FROM $2 
+AND expires_at IS NULL AND is_active AND updated_at IS NULL`
 
-func (r *urlRepoPostgres) GetShortURLByLongURL(ctx context.Context, longURL string) (string, error) {
+func (r *urlRepoPostgres) GetShortURLByLongURL(ctx context.Context, longURL string, ownerID string) (string, error) {
 	var shortURL string
-	row := r.dbPool.QueryRow(ctx, getShortURLByLongURL, longURL)
+	row := conn(ctx, r.dbPool).QueryRow(ctx, getShortURLByLongURL, longURL, nullableOwnerID(ownerID))
 
 	err := row.Scan(&shortURL)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -55,6 +132,250 @@ func (r *urlRepoPostgres) GetShortURLByLongURL(ctx context.Context, longURL stri
 }
 
 func (r *urlRepoPostgres) SaveURL(ctx context.Context, urlData domain.URLData) error {
-	_, err := r.dbPool.Exec(ctx, saveURLQuery, urlData.ID, urlData.ShortUrl, urlData.LongUrl, urlData.CreatedAt)
-	return err
+	var expiresAt *time.Time
+	if !urlData.ExpiresAt.IsZero() {
+		expiresAt = &urlData.ExpiresAt
+	}
+
+	_, err := conn(ctx, r.dbPool).Exec(
+		ctx,
+		saveURLQuery,
+		urlData.ID,
+		urlData.ShortUrl,
+		urlData.LongUrl,
+		urlData.CreatedAt,
+		expiresAt,
+		nullableOwnerID(urlData.OwnerID),
+	)
+	return mapUniqueViolation(err)
+}
+
+const getShortURLsByLongURLs = `SELECT DISTINCT ON (long_url) long_url, short_url FROM url_data 
+WHERE long_url = ANY($1) AND owner_id IS NOT DISTINCT FROM $2 
+AND expires_at IS NULL AND is_active AND updated_at IS NULL 
+ORDER BY long_url, id`
+
+func (r *urlRepoPostgres) GetShortURLsByLongURLs(
+	ctx context.Context,
+	longURLs []string,
+	ownerID string,
+) (map[string]string, error) {
+	rows, err := conn(ctx, r.dbPool).Query(ctx, getShortURLsByLongURLs, longURLs, nullableOwnerID(ownerID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shortURLs := make(map[string]string)
+	for rows.Next() {
+		var longURL, shortURL string
+		err = rows.Scan(&longURL, &shortURL)
+		if err != nil {
+			return nil, err
+		}
+		shortURLs[longURL] = shortURL
+	}
+
+	return shortURLs, rows.Err()
+}
+
+// Conflicts on any unique constraint skip the row instead of failing the whole batch
+const saveURLsQuery = `INSERT INTO url_data (id, short_url, long_url, created_at, expires_at, is_active, updated_at, owner_id) 
+SELECT * FROM unnest(
+$1::BIGINT[], $2::VARCHAR[], $3::TEXT[], $4::TIMESTAMPTZ[], $5::TIMESTAMPTZ[], $6::BOOLEAN[], $7::TIMESTAMPTZ[], $8::VARCHAR[]
+) 
+ON CONFLICT DO NOTHING 
+RETURNING id`
+
+func (r *urlRepoPostgres) SaveURLs(ctx context.Context, urls []domain.URLData) ([]int64, error) {
+	columns := urlDataArrays(urls)
+	rows, err := conn(ctx, r.dbPool).Query(
+		ctx,
+		saveURLsQuery,
+		columns.ids,
+		columns.shortURLs,
+		columns.longURLs,
+		columns.createdAts,
+		columns.expiresAts,
+		columns.isActives,
+		columns.updatedAts,
+		columns.ownerIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+const replaceURLsQuery = `UPDATE url_data SET 
+long_url = src.long_url, created_at = src.created_at, expires_at = src.expires_at, 
+is_active = src.is_active, updated_at = src.updated_at, 
+owner_id = CASE WHEN $8::BOOLEAN THEN src.owner_id ELSE url_data.owner_id END 
+FROM unnest(
+$1::VARCHAR[], $2::TEXT[], $3::TIMESTAMPTZ[], $4::TIMESTAMPTZ[], $5::BOOLEAN[], $6::TIMESTAMPTZ[], $7::VARCHAR[]
+) AS src (short_url, long_url, created_at, expires_at, is_active, updated_at, owner_id) 
+WHERE url_data.short_url = src.short_url`
+
+func (r *urlRepoPostgres) ReplaceURLs(ctx context.Context, urls []domain.URLData, overwriteOwner bool) (int, error) {
+	columns := urlDataArrays(urls)
+	tag, err := conn(ctx, r.dbPool).Exec(
+		ctx,
+		replaceURLsQuery,
+		columns.shortURLs,
+		columns.longURLs,
+		columns.createdAts,
+		columns.expiresAts,
+		columns.isActives,
+		columns.updatedAts,
+		columns.ownerIDs,
+		overwriteOwner,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// urlDataColumnArrays holds urls column by column to pass them to unnest
+type urlDataColumnArrays struct {
+	ids        []int64
+	shortURLs  []string
+	longURLs   []string
+	createdAts []time.Time
+	expiresAts []*time.Time
+	isActives  []bool
+	updatedAts []*time.Time
+	ownerIDs   []*string
+}
+
+func urlDataArrays(urls []domain.URLData) urlDataColumnArrays {
+	columns := urlDataColumnArrays{
+		ids:        make([]int64, len(urls)),
+		shortURLs:  make([]string, len(urls)),
+		longURLs:   make([]string, len(urls)),
+		createdAts: make([]time.Time, len(urls)),
+		expiresAts: make([]*time.Time, len(urls)),
+		isActives:  make([]bool, len(urls)),
+		updatedAts: make([]*time.Time, len(urls)),
+		ownerIDs:   make([]*string, len(urls)),
+	}
+	for i, urlData := range urls {
+		columns.ids[i] = urlData.ID
+		columns.shortURLs[i] = urlData.ShortUrl
+		columns.longURLs[i] = urlData.LongUrl
+		columns.createdAts[i] = urlData.CreatedAt
+		if !urlData.ExpiresAt.IsZero() {
+			columns.expiresAts[i] = &urls[i].ExpiresAt
+		}
+		columns.isActives[i] = urlData.IsActive
+		if !urlData.UpdatedAt.IsZero() {
+			columns.updatedAts[i] = &urls[i].UpdatedAt
+		}
+		columns.ownerIDs[i] = nullableOwnerID(urlData.OwnerID)
+	}
+
+	return columns
+}
+
+const deleteURLQuery = `DELETE FROM url_data WHERE short_url = $1 AND owner_id = $2 
+RETURNING long_url`
+
+func (r *urlRepoPostgres) DeleteURL(ctx context.Context, shortURL string, ownerID string) (string, error) {
+	var longURL string
+	row := conn(ctx, r.dbPool).QueryRow(ctx, deleteURLQuery, shortURL, ownerID)
+
+	err := row.Scan(&longURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", errs.ErrNoURL
+	}
+
+	return longURL, err
+}
+
+const setURLActiveQuery = `UPDATE url_data SET is_active = $3 WHERE short_url = $1 AND owner_id = $2`
+
+func (r *urlRepoPostgres) SetURLActive(ctx context.Context, shortURL string, ownerID string, active bool) error {
+	tag, err := conn(ctx, r.dbPool).Exec(ctx, setURLActiveQuery, shortURL, ownerID, active)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrNoURL
+	}
+
+	return nil
+}
+
+const updateLongURLQuery = `UPDATE url_data SET long_url = $3, updated_at = $4 
+WHERE short_url = $1 AND owner_id = $2 
+RETURNING ` + urlDataColumns
+
+func (r *urlRepoPostgres) UpdateLongURL(
+	ctx context.Context,
+	shortURL string,
+	ownerID string,
+	longURL string,
+	updatedAt time.Time,
+) (domain.URLData, error) {
+	row := conn(ctx, r.dbPool).QueryRow(ctx, updateLongURLQuery, shortURL, ownerID, longURL, updatedAt)
+	return scanURLData(row)
+}
+
+const listURLsQuery = `SELECT ` + urlDataColumns + ` FROM url_data WHERE owner_id = $1 
+ORDER BY created_at DESC, id DESC 
+LIMIT $2 
+OFFSET $3`
+
+func (r *urlRepoPostgres) ListURLs(
+	ctx context.Context,
+	ownerID string,
+	paginationParams domain.PaginationParams,
+) ([]domain.URLData, error) {
+	offset := paginationParams.Limit * (paginationParams.Page - 1)
+
+	rows, err := conn(ctx, r.dbPool).Query(ctx, listURLsQuery, ownerID, paginationParams.Limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanURLDataRows(rows, paginationParams.Limit)
+}
+
+const countURLsQuery = `SELECT count(*) FROM url_data WHERE owner_id = $1`
+
+func (r *urlRepoPostgres) CountURLs(ctx context.Context, ownerID string) (int, error) {
+	var count int
+	err := conn(ctx, r.dbPool).QueryRow(ctx, countURLsQuery, ownerID).Scan(&count)
+	return count, err
+}
+
+const listAllURLsQuery = `SELECT ` + urlDataColumns + ` FROM url_data WHERE id > $1 
+ORDER BY id 
+LIMIT $2`
+
+func (r *urlRepoPostgres) ListAllURLs(ctx context.Context, afterID int64, limit int) ([]domain.URLData, error) {
+	rows, err := conn(ctx, r.dbPool).Query(ctx, listAllURLsQuery, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanURLDataRows(rows, limit)
+}
+
+func mapUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolationCode {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case primaryKeyConstraint:
+		return errs.ErrIDConflict
+	case shortURLConstraint:
+		return errs.ErrShortURLConflict
+	default:
+		return err
+	}
 }
@@ -0,0 +1,67 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/errs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDatabaseURLKey points to a migrated database, e.g. the one of make migrateup.
+// Tests of queries are skipped without it
+const testDatabaseURLKey = "TEST_DATABASE_URL"
+
+// errRollback ends a test transaction, so nothing written by a test is kept
+var errRollback = errors.New("rollback")
+
+func newTestDBPool(t *testing.T) *pgxpool.Pool {
+	databaseURL := os.Getenv(testDatabaseURLKey)
+	if databaseURL == "" {
+		t.Skipf("%s is not set", testDatabaseURLKey)
+	}
+
+	dbPool, err := pgxpool.New(context.Background(), databaseURL)
+	require.NoError(t, err)
+	t.Cleanup(dbPool.Close)
+
+	return dbPool
+}
+
+func TestSaveURLTwiceAnonymously(t *testing.T) {
+	dbPool := newTestDBPool(t)
+	urlRepo := NewUrlRepoPostgres(dbPool)
+	longURL := "https://test.longurl/anonymous-dedup"
+
+	err := NewTxManagerPostgres(dbPool).WithinTx(context.Background(), func(ctx context.Context) error {
+		err := urlRepo.SaveURL(ctx, domain.URLData{
+			ID:        time.Now().UnixNano(),
+			ShortUrl:  "anondedup",
+			LongUrl:   longURL,
+			CreatedAt: time.Now(),
+		})
+		require.NoError(t, err)
+
+		// The second anonymous save reuses the code of the first one
+		shortURL, err := urlRepo.GetShortURLByLongURL(ctx, longURL, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "anondedup", shortURL)
+
+		shortURLs, err := urlRepo.GetShortURLsByLongURLs(ctx, []string{longURL}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{longURL: "anondedup"}, shortURLs)
+
+		// Anonymous links are not handed out to owners
+		_, err = urlRepo.GetShortURLByLongURL(ctx, longURL, "owner")
+		assert.ErrorIs(t, err, errs.ErrNoURL)
+
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+}
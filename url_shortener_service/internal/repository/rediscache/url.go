@@ -4,10 +4,13 @@ import (
 	"context"
 	"time"
 
+	"CoolUrlShortener/internal/domain"
 	"CoolUrlShortener/internal/repository"
 	"github.com/redis/go-redis/v9"
 )
 
+const defaultTTL = 10 * time.Minute
+
 type urlCacheRedis struct {
 	client *redis.Client
 }
@@ -18,11 +21,55 @@ func NewURLCacheRedis(client *redis.Client) repository.URLCache {
 	}
 }
 
-func (u *urlCacheRedis) SetLongURL(ctx context.Context, shortURL string, longURL string) error {
-	return u.client.Set(ctx, shortURL, longURL, 10*time.Minute).Err()
+func (u *urlCacheRedis) SetLongURL(ctx context.Context, shortURL string, longURL string, expiresAt time.Time) error {
+	ttl, ok := cacheTTL(expiresAt)
+	if !ok {
+		return nil
+	}
+
+	return u.client.Set(ctx, shortURL, longURL, ttl).Err()
+}
+
+func (u *urlCacheRedis) SetLongURLs(ctx context.Context, urls []domain.URLData) error {
+	pipe := u.client.Pipeline()
+	for _, urlData := range urls {
+		ttl, ok := cacheTTL(urlData.ExpiresAt)
+		if !ok {
+			continue
+		}
+		pipe.Set(ctx, urlData.ShortUrl, urlData.LongUrl, ttl)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// cacheTTL keeps entries no longer than the link lives, ok is false for expired links
+func cacheTTL(expiresAt time.Time) (time.Duration, bool) {
+	ttl := defaultTTL
+	if !expiresAt.IsZero() {
+		untilExpiry := time.Until(expiresAt)
+		if untilExpiry <= 0 {
+			return 0, false
+		}
+		ttl = min(ttl, untilExpiry)
+	}
+
+	return ttl, true
 }
 
 func (u *urlCacheRedis) GetLongURL(ctx context.Context, shortURL string) (string, error) {
 	longURL, err := u.client.Get(ctx, shortURL).Result()
 	return longURL, err
 }
+
+func (u *urlCacheRedis) DeleteLongURL(ctx context.Context, shortURL string) error {
+	return u.client.Del(ctx, shortURL).Err()
+}
+
+func (u *urlCacheRedis) DeleteLongURLs(ctx context.Context, shortURLs []string) error {
+	if len(shortURLs) == 0 {
+		return nil
+	}
+	return u.client.Del(ctx, shortURLs...).Err()
+}
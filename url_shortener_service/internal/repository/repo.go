@@ -2,13 +2,42 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"CoolUrlShortener/internal/domain"
 )
 
+// Methods that take ownerID only touch urls of that owner.
+// Lookups treat empty ownerID as anonymous urls, mutations never match anonymous urls.
+//
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name UrlRepo
 type UrlRepo interface {
-	GetLongURL(ctx context.Context, shortUrl string) (string, error)
-	GetShortURLByLongURL(ctx context.Context, longURL string) (string, error)
+	GetURL(ctx context.Context, shortUrl string) (domain.URLData, error)
+	// GetURLs is a batched GetURL, short urls without a url are absent in the result
+	GetURLs(ctx context.Context, shortURLs []string) ([]domain.URLData, error)
+	GetShortURLByLongURL(ctx context.Context, longURL string, ownerID string) (string, error)
+	// GetShortURLsByLongURLs is a batched GetShortURLByLongURL, long urls without a short url are absent in the result
+	GetShortURLsByLongURLs(ctx context.Context, longURLs []string, ownerID string) (map[string]string, error)
 	SaveURL(ctx context.Context, urlData domain.URLData) error
+	// SaveURLs inserts urls at once and returns ids of the saved ones.
+	// Urls whose id or short url is already taken are skipped.
+	SaveURLs(ctx context.Context, urls []domain.URLData) ([]int64, error)
+	// ReplaceURLs overwrites everything but id of the urls with the same short urls
+	// and returns the number of replaced urls. Owners are kept unless overwriteOwner is set.
+	ReplaceURLs(ctx context.Context, urls []domain.URLData, overwriteOwner bool) (int, error)
+	// DeleteURL removes url and returns its long url
+	DeleteURL(ctx context.Context, shortURL string, ownerID string) (string, error)
+	SetURLActive(ctx context.Context, shortURL string, ownerID string, active bool) error
+	// UpdateLongURL changes the destination of url and returns the updated url
+	UpdateLongURL(
+		ctx context.Context,
+		shortURL string,
+		ownerID string,
+		longURL string,
+		updatedAt time.Time,
+	) (domain.URLData, error)
+	ListURLs(ctx context.Context, ownerID string, paginationParams domain.PaginationParams) ([]domain.URLData, error)
+	CountURLs(ctx context.Context, ownerID string) (int, error)
+	// ListAllURLs returns up to limit urls of all owners with id greater than afterID ordered by id
+	ListAllURLs(ctx context.Context, afterID int64, limit int) ([]domain.URLData, error)
 }
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+
+	"CoolUrlShortener/internal/errs"
+)
+
+var aliasRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{3,32}$`)
+
+// importedCodeRegexp accepts codes shorter than an alias could be, other shorteners hand them out
+var importedCodeRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+
+// reservedAliases contains path segments that are served by api_gateway itself.
+// A custom alias with one of these names would shadow the route.
+var reservedAliases = map[string]struct{}{
+	"api":         {},
+	"admin":       {},
+	"docs":        {},
+	"swagger":     {},
+	"healthcheck": {},
+	"static":      {},
+	"assets":      {},
+	"me":          {},
+	"urls":        {},
+}
+
+func validateAlias(alias string) error {
+	if !aliasRegexp.MatchString(alias) {
+		return errs.ErrInvalidAlias
+	}
+
+	return checkReserved(alias)
+}
+
+func validateImportedCode(code string) error {
+	if !importedCodeRegexp.MatchString(code) {
+		return errs.ErrInvalidShortURL
+	}
+
+	return checkReserved(code)
+}
+
+func checkReserved(code string) error {
+	if _, ok := reservedAliases[strings.ToLower(code)]; ok {
+		return errs.ErrReservedAlias
+	}
+
+	return nil
+}
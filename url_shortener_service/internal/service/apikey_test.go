@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIssueAPIKey(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	testOwnerID := "owner"
+	testName := "internal tool"
+	unexpectedErr := errors.New("unexpected error")
+
+	testCases := []struct {
+		name            string
+		buildAPIKeyRepo func() repository.APIKeyRepo
+		expectedID      int64
+		expectedErr     error
+		isKeyExpected   bool
+		expectedOwnerID string
+		expectedKeyName string
+	}{
+		{
+			name: "Issue api key. Should store hash of the key",
+			buildAPIKeyRepo: func() repository.APIKeyRepo {
+				mockRepo := mocks.NewAPIKeyRepo(t)
+				mockRepo.On("SaveAPIKey", mock.Anything, mock.MatchedBy(func(apiKey domain.APIKey) bool {
+					return apiKey.OwnerID == testOwnerID && apiKey.Name == testName && len(apiKey.KeyHash) == 64
+				})).
+					Return(int64(7), nil).
+					Once()
+
+				return mockRepo
+			},
+			expectedID:      7,
+			expectedErr:     nil,
+			isKeyExpected:   true,
+			expectedOwnerID: testOwnerID,
+			expectedKeyName: testName,
+		},
+		{
+			name: "Could not save api key. Should be error",
+			buildAPIKeyRepo: func() repository.APIKeyRepo {
+				mockRepo := mocks.NewAPIKeyRepo(t)
+				mockRepo.On("SaveAPIKey", mock.Anything, mock.Anything).
+					Return(int64(0), unexpectedErr).
+					Once()
+
+				return mockRepo
+			},
+			expectedErr:   unexpectedErr,
+			isKeyExpected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apiKeyService := NewAPIKeyService(logger, tc.buildAPIKeyRepo())
+
+			apiKey, key, err := apiKeyService.IssueAPIKey(context.Background(), testOwnerID, testName)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedID, apiKey.ID)
+			assert.Equal(t, tc.expectedOwnerID, apiKey.OwnerID)
+			assert.Equal(t, tc.expectedKeyName, apiKey.Name)
+			if tc.isKeyExpected {
+				assert.True(t, strings.HasPrefix(key, apiKeyPrefix))
+				assert.Equal(t, hashAPIKey(key), apiKey.KeyHash)
+			} else {
+				assert.Empty(t, key)
+			}
+		})
+	}
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	key1, err := generateAPIKey()
+	assert.NoError(t, err)
+	key2, err := generateAPIKey()
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, key1, key2)
+	assert.NotEqual(t, hashAPIKey(key1), hashAPIKey(key2))
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	testKey := "cus_test"
+	storedKey := domain.APIKey{ID: 1, OwnerID: "owner", KeyHash: hashAPIKey(testKey)}
+
+	testCases := []struct {
+		name            string
+		buildAPIKeyRepo func() repository.APIKeyRepo
+		expectedAPIKey  domain.APIKey
+		expectedErr     error
+	}{
+		{
+			name: "Valid api key",
+			buildAPIKeyRepo: func() repository.APIKeyRepo {
+				mockRepo := mocks.NewAPIKeyRepo(t)
+				mockRepo.On("GetAPIKeyByHash", mock.Anything, hashAPIKey(testKey)).
+					Return(storedKey, nil)
+
+				return mockRepo
+			},
+			expectedAPIKey: storedKey,
+			expectedErr:    nil,
+		},
+		{
+			name: "Unknown api key. Should be error",
+			buildAPIKeyRepo: func() repository.APIKeyRepo {
+				mockRepo := mocks.NewAPIKeyRepo(t)
+				mockRepo.On("GetAPIKeyByHash", mock.Anything, hashAPIKey(testKey)).
+					Return(domain.APIKey{}, errs.ErrNoAPIKey)
+
+				return mockRepo
+			},
+			expectedAPIKey: domain.APIKey{},
+			expectedErr:    errs.ErrNoAPIKey,
+		},
+		{
+			name: "Revoked api key. Should be error",
+			buildAPIKeyRepo: func() repository.APIKeyRepo {
+				revokedKey := storedKey
+				revokedKey.RevokedAt = time.Now().Add(-time.Minute)
+
+				mockRepo := mocks.NewAPIKeyRepo(t)
+				mockRepo.On("GetAPIKeyByHash", mock.Anything, hashAPIKey(testKey)).
+					Return(revokedKey, nil)
+
+				return mockRepo
+			},
+			expectedAPIKey: domain.APIKey{},
+			expectedErr:    errs.ErrAPIKeyRevoked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apiKeyService := NewAPIKeyService(logger, tc.buildAPIKeyRepo())
+
+			apiKey, err := apiKeyService.VerifyAPIKey(context.Background(), testKey)
+			assert.Equal(t, tc.expectedAPIKey, apiKey)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+
+	testCases := []struct {
+		name            string
+		buildAPIKeyRepo func() repository.APIKeyRepo
+		expectedErr     error
+	}{
+		{
+			name: "Revoke api key",
+			buildAPIKeyRepo: func() repository.APIKeyRepo {
+				mockRepo := mocks.NewAPIKeyRepo(t)
+				mockRepo.On("RevokeAPIKey", mock.Anything, int64(1), mock.Anything).
+					Return(nil)
+
+				return mockRepo
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Api key not found. Should be error",
+			buildAPIKeyRepo: func() repository.APIKeyRepo {
+				mockRepo := mocks.NewAPIKeyRepo(t)
+				mockRepo.On("RevokeAPIKey", mock.Anything, int64(1), mock.Anything).
+					Return(errs.ErrNoAPIKey)
+
+				return mockRepo
+			},
+			expectedErr: errs.ErrNoAPIKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apiKeyService := NewAPIKeyService(logger, tc.buildAPIKeyRepo())
+
+			err := apiKeyService.RevokeAPIKey(context.Background(), 1)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/pkg/idgen"
+	"CoolUrlShortener/pkg/shortener"
+)
+
+// MigrationService moves urls in and out of the service in bulk.
+// Imported urls are not reported as created, they were created by another service.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name MigrationService
+type MigrationService interface {
+	// ExportURLs passes urls of all owners to fn in batches ordered by id
+	ExportURLs(ctx context.Context, batchSize int, fn func(urls []domain.URLData) error) error
+	// ImportURLs saves urls keeping their short urls, urls without a short url get a generated one.
+	// Urls are saved in a single transaction, with domain.ConflictFail nothing is saved if any short url is taken.
+	ImportURLs(ctx context.Context, urls []domain.URLData, params domain.ImportParams) (domain.ImportResult, error)
+}
+
+type migrationService struct {
+	logger       *slog.Logger
+	urlRepo      repository.UrlRepo
+	urlCache     repository.URLCache
+	txManager    repository.TxManager
+	urlShortener shortener.URLShortener
+	idGenerator  idgen.IDGenerator
+}
+
+func NewMigrationService(
+	logger *slog.Logger,
+	repo repository.UrlRepo,
+	urlCache repository.URLCache,
+	txManager repository.TxManager,
+	urlShortener shortener.URLShortener,
+	idGenerator idgen.IDGenerator,
+) MigrationService {
+	return &migrationService{
+		logger:       logger,
+		urlRepo:      repo,
+		urlCache:     urlCache,
+		txManager:    txManager,
+		urlShortener: urlShortener,
+		idGenerator:  idGenerator,
+	}
+}
+
+func (s *migrationService) ExportURLs(
+	ctx context.Context,
+	batchSize int,
+	fn func(urls []domain.URLData) error,
+) error {
+	afterID := int64(math.MinInt64)
+	for {
+		urls, err := s.urlRepo.ListAllURLs(ctx, afterID, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(urls) == 0 {
+			return nil
+		}
+
+		err = fn(urls)
+		if err != nil {
+			return err
+		}
+		if len(urls) < batchSize {
+			return nil
+		}
+		afterID = urls[len(urls)-1].ID
+	}
+}
+
+func (s *migrationService) ImportURLs(
+	ctx context.Context,
+	urls []domain.URLData,
+	params domain.ImportParams,
+) (domain.ImportResult, error) {
+	var result domain.ImportResult
+	now := time.Now()
+
+	// A short url repeated within urls conflicts with its first occurrence
+	// the same way as with a url that is already saved
+	firstIndexes := make(map[string]int)
+	var preserved, generated []domain.URLData
+	for i, urlData := range urls {
+		if urlData.CreatedAt.IsZero() {
+			urlData.CreatedAt = now
+		}
+		if urlData.ShortUrl == "" {
+			generated = append(generated, urlData)
+			continue
+		}
+
+		err := validateImportedCode(urlData.ShortUrl)
+		if err != nil {
+			return domain.ImportResult{}, fmt.Errorf("url %d: %w", i, err)
+		}
+
+		first, ok := firstIndexes[urlData.ShortUrl]
+		if !ok {
+			firstIndexes[urlData.ShortUrl] = len(preserved)
+			preserved = append(preserved, urlData)
+			continue
+		}
+
+		result.Conflicts = append(result.Conflicts, urlData.ShortUrl)
+		switch params.Conflict {
+		case domain.ConflictOverwrite:
+			preserved[first] = urlData
+			result.Overwritten++
+		default:
+			result.Skipped++
+		}
+	}
+
+	taken, err := s.takenShortURLs(ctx, preserved, params.BatchSize)
+	if err != nil {
+		return domain.ImportResult{}, err
+	}
+
+	var toCreate, toReplace []domain.URLData
+	for _, urlData := range preserved {
+		if _, ok := taken[urlData.ShortUrl]; !ok {
+			toCreate = append(toCreate, urlData)
+			continue
+		}
+
+		result.Conflicts = append(result.Conflicts, urlData.ShortUrl)
+		switch params.Conflict {
+		case domain.ConflictOverwrite:
+			toReplace = append(toReplace, urlData)
+		default:
+			result.Skipped++
+		}
+	}
+
+	if params.DryRun {
+		result.Created += len(toCreate) + len(generated)
+		result.Overwritten += len(toReplace)
+	}
+	if params.Conflict == domain.ConflictFail && len(result.Conflicts) > 0 {
+		return result, fmt.Errorf("%w: %d short urls are taken", errs.ErrShortURLConflict, len(result.Conflicts))
+	}
+	if params.DryRun {
+		return result, nil
+	}
+
+	var saved domain.ImportResult
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		saved = domain.ImportResult{}
+		for _, batch := range importBatches(toReplace, params.BatchSize) {
+			replaced, err := s.urlRepo.ReplaceURLs(ctx, batch, params.OverwriteOwner)
+			if err != nil {
+				return err
+			}
+			saved.Overwritten += replaced
+			// Urls deleted since they were looked up are not replaced
+			saved.Skipped += len(batch) - replaced
+		}
+
+		for _, batch := range importBatches(toCreate, params.BatchSize) {
+			created, concurrent, err := s.createPreservedURLs(ctx, batch)
+			if err != nil {
+				return err
+			}
+			saved.Created += created
+			saved.Conflicts = append(saved.Conflicts, concurrent...)
+			saved.Skipped += len(concurrent)
+		}
+		if params.Conflict == domain.ConflictFail && len(saved.Conflicts) > 0 {
+			return fmt.Errorf("%w: %d short urls were taken while importing", errs.ErrShortURLConflict, len(saved.Conflicts))
+		}
+
+		for _, batch := range importBatches(generated, params.BatchSize) {
+			created, err := s.createGeneratedURLs(ctx, batch)
+			if err != nil {
+				return err
+			}
+			saved.Created += created
+		}
+
+		return nil
+	})
+	if err != nil {
+		// The transaction is rolled back, nothing was saved
+		result.Conflicts = append(result.Conflicts, saved.Conflicts...)
+		return result, err
+	}
+
+	if len(saved.Conflicts) > 0 {
+		s.logger.Warn("short urls were taken while importing", slog.Int("count", len(saved.Conflicts)))
+	}
+	result.Created += saved.Created
+	result.Overwritten += saved.Overwritten
+	result.Skipped += saved.Skipped
+	result.Conflicts = append(result.Conflicts, saved.Conflicts...)
+
+	// Cached urls are dropped once the new ones are visible to readers
+	if len(toReplace) > 0 {
+		replacedShortURLs := make([]string, len(toReplace))
+		for i, urlData := range toReplace {
+			replacedShortURLs[i] = urlData.ShortUrl
+		}
+		err = s.urlCache.DeleteLongURLs(ctx, replacedShortURLs)
+		if err != nil {
+			s.logger.Error(err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+// importBatches splits urls into batches of up to size urls, a single batch if size is not positive
+func importBatches(urls []domain.URLData, size int) [][]domain.URLData {
+	if len(urls) == 0 {
+		return nil
+	}
+	if size <= 0 || size >= len(urls) {
+		return [][]domain.URLData{urls}
+	}
+
+	batches := make([][]domain.URLData, 0, (len(urls)+size-1)/size)
+	for len(urls) > size {
+		batches = append(batches, urls[:size])
+		urls = urls[size:]
+	}
+
+	return append(batches, urls)
+}
+
+func (s *migrationService) takenShortURLs(
+	ctx context.Context,
+	urls []domain.URLData,
+	batchSize int,
+) (map[string]struct{}, error) {
+	taken := make(map[string]struct{})
+	for _, batch := range importBatches(urls, batchSize) {
+		shortURLs := make([]string, len(batch))
+		for i, urlData := range batch {
+			shortURLs[i] = urlData.ShortUrl
+		}
+		existing, err := s.urlRepo.GetURLs(ctx, shortURLs)
+		if err != nil {
+			return nil, err
+		}
+		for _, urlData := range existing {
+			taken[urlData.ShortUrl] = struct{}{}
+		}
+	}
+
+	return taken, nil
+}
+
+// createPreservedURLs saves urls under their own short urls and returns
+// the number of saved urls and short urls that turned out to be taken
+func (s *migrationService) createPreservedURLs(ctx context.Context, urls []domain.URLData) (int, []string, error) {
+	if len(urls) == 0 {
+		return 0, nil, nil
+	}
+
+	for i := range urls {
+		id, err := s.idGenerator.NextID(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+		urls[i].ID = int64(id)
+	}
+
+	savedIDs, err := s.urlRepo.SaveURLs(ctx, urls)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(savedIDs) == len(urls) {
+		return len(urls), nil, nil
+	}
+
+	saved := make(map[int64]bool, len(savedIDs))
+	for _, id := range savedIDs {
+		saved[id] = true
+	}
+	var taken []string
+	for _, urlData := range urls {
+		if !saved[urlData.ID] {
+			taken = append(taken, urlData.ShortUrl)
+		}
+	}
+
+	return len(savedIDs), taken, nil
+}
+
+// createGeneratedURLs saves urls under generated short urls retrying collided ones
+func (s *migrationService) createGeneratedURLs(ctx context.Context, urls []domain.URLData) (int, error) {
+	created := 0
+	pending := urls
+	for attempt := 1; attempt <= maxSaveURLAttempts && len(pending) > 0; attempt++ {
+		for i := range pending {
+			id, err := s.idGenerator.NextID(ctx)
+			if err != nil {
+				return created, err
+			}
+			pending[i].ID = int64(id)
+			pending[i].ShortUrl = s.urlShortener.ShortenURL(id)
+		}
+
+		savedIDs, err := s.urlRepo.SaveURLs(ctx, pending)
+		if err != nil {
+			return created, err
+		}
+		created += len(savedIDs)
+
+		saved := make(map[int64]bool, len(savedIDs))
+		for _, id := range savedIDs {
+			saved[id] = true
+		}
+		var collided []domain.URLData
+		for _, urlData := range pending {
+			if !saved[urlData.ID] {
+				collided = append(collided, urlData)
+			}
+		}
+		if len(collided) > 0 {
+			s.logger.Warn("short url collisions in import", slog.Int("count", len(collided)), slog.Int("attempt", attempt))
+		}
+		pending = collided
+	}
+	if len(pending) > 0 {
+		return created, fmt.Errorf("%w: %d urls were not imported", errs.ErrShortURLGeneration, len(pending))
+	}
+
+	return created, nil
+}
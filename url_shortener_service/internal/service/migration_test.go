@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"os"
+	"testing"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/internal/repository/mocks"
+	"CoolUrlShortener/pkg/idgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	idgenmocks "CoolUrlShortener/pkg/idgen/mocks"
+	shortenermocks "CoolUrlShortener/pkg/shortener/mocks"
+)
+
+func TestImportURLs(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+
+	newURL := domain.URLData{ShortUrl: "abc", LongUrl: "https://new.longurl", IsActive: true}
+	takenURL := domain.URLData{ShortUrl: "taken", LongUrl: "https://taken.longurl", IsActive: true}
+	generatedURL := domain.URLData{LongUrl: "https://generated.longurl", IsActive: true}
+	generatedShortURL := "gen"
+
+	buildIDGenerator := func() idgen.IDGenerator {
+		mockIDGenerator := idgenmocks.NewIDGenerator(t)
+		var nextID uint64
+		mockIDGenerator.On("NextID", mock.Anything).
+			Return(func(ctx context.Context) (uint64, error) {
+				nextID++
+				return nextID, nil
+			}, nil).
+			Maybe()
+
+		return mockIDGenerator
+	}
+	savedIDs := func(ctx context.Context, urls []domain.URLData) ([]int64, error) {
+		ids := make([]int64, len(urls))
+		for i, urlData := range urls {
+			ids[i] = urlData.ID
+		}
+		return ids, nil
+	}
+	hasShortURL := func(shortURL string) func(urls []domain.URLData) bool {
+		return func(urls []domain.URLData) bool {
+			return len(urls) == 1 && urls[0].ShortUrl == shortURL
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		urls           []domain.URLData
+		params         domain.ImportParams
+		buildURLRepo   func() repository.UrlRepo
+		buildURLCache  func() repository.URLCache
+		expectedResult domain.ImportResult
+		expectedErr    error
+	}{
+		{
+			name:   "Import preserved and generated short urls",
+			urls:   []domain.URLData{newURL, generatedURL},
+			params: domain.ImportParams{Conflict: domain.ConflictFail},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl}).
+					Return(nil, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(hasShortURL(newURL.ShortUrl))).
+					Return(savedIDs, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(hasShortURL(generatedShortURL))).
+					Return(savedIDs, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Created: 2},
+		},
+		{
+			name:   "Skip taken short url",
+			urls:   []domain.URLData{newURL, takenURL},
+			params: domain.ImportParams{Conflict: domain.ConflictSkip},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl, takenURL.ShortUrl}).
+					Return([]domain.URLData{takenURL}, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(hasShortURL(newURL.ShortUrl))).
+					Return(savedIDs, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Created: 1, Skipped: 1, Conflicts: []string{takenURL.ShortUrl}},
+		},
+		{
+			name:   "Overwrite taken short url",
+			urls:   []domain.URLData{takenURL},
+			params: domain.ImportParams{Conflict: domain.ConflictOverwrite},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{takenURL.ShortUrl}).
+					Return([]domain.URLData{takenURL}, nil).
+					Once()
+				mockRepo.On("ReplaceURLs", mock.Anything, mock.MatchedBy(hasShortURL(takenURL.ShortUrl)), false).
+					Return(1, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("DeleteLongURLs", mock.Anything, []string{takenURL.ShortUrl}).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			expectedResult: domain.ImportResult{Overwritten: 1, Conflicts: []string{takenURL.ShortUrl}},
+		},
+		{
+			name:   "Overwrite taken short url with its owner",
+			urls:   []domain.URLData{takenURL},
+			params: domain.ImportParams{Conflict: domain.ConflictOverwrite, OverwriteOwner: true},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{takenURL.ShortUrl}).
+					Return([]domain.URLData{takenURL}, nil).
+					Once()
+				mockRepo.On("ReplaceURLs", mock.Anything, mock.MatchedBy(hasShortURL(takenURL.ShortUrl)), true).
+					Return(1, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("DeleteLongURLs", mock.Anything, []string{takenURL.ShortUrl}).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			expectedResult: domain.ImportResult{Overwritten: 1, Conflicts: []string{takenURL.ShortUrl}},
+		},
+		{
+			name:   "Look up and save urls in batches",
+			urls:   []domain.URLData{newURL, takenURL},
+			params: domain.ImportParams{Conflict: domain.ConflictFail, BatchSize: 1},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl}).
+					Return(nil, nil).
+					Once()
+				mockRepo.On("GetURLs", mock.Anything, []string{takenURL.ShortUrl}).
+					Return(nil, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(hasShortURL(newURL.ShortUrl))).
+					Return(savedIDs, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(hasShortURL(takenURL.ShortUrl))).
+					Return(savedIDs, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Created: 2},
+		},
+		{
+			name:   "Fail on taken short url without saving the batch",
+			urls:   []domain.URLData{newURL, takenURL},
+			params: domain.ImportParams{Conflict: domain.ConflictFail},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl, takenURL.ShortUrl}).
+					Return([]domain.URLData{takenURL}, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Skipped: 1, Conflicts: []string{takenURL.ShortUrl}},
+			expectedErr:    errs.ErrShortURLConflict,
+		},
+		{
+			name:   "Dry run does not write",
+			urls:   []domain.URLData{newURL, takenURL, generatedURL},
+			params: domain.ImportParams{Conflict: domain.ConflictOverwrite, DryRun: true},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl, takenURL.ShortUrl}).
+					Return([]domain.URLData{takenURL}, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Created: 2, Overwritten: 1, Conflicts: []string{takenURL.ShortUrl}},
+		},
+		{
+			name:   "Repeated short url conflicts with its first occurrence",
+			urls:   []domain.URLData{newURL, newURL},
+			params: domain.ImportParams{Conflict: domain.ConflictSkip},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl}).
+					Return(nil, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(hasShortURL(newURL.ShortUrl))).
+					Return(savedIDs, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Created: 1, Skipped: 1, Conflicts: []string{newURL.ShortUrl}},
+		},
+		{
+			name:   "Short url taken concurrently is skipped",
+			urls:   []domain.URLData{newURL},
+			params: domain.ImportParams{Conflict: domain.ConflictSkip},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl}).
+					Return(nil, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.Anything).
+					Return([]int64{}, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Skipped: 1, Conflicts: []string{newURL.ShortUrl}},
+		},
+		{
+			name:   "Fail on short url taken concurrently",
+			urls:   []domain.URLData{newURL, generatedURL},
+			params: domain.ImportParams{Conflict: domain.ConflictFail},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURLs", mock.Anything, []string{newURL.ShortUrl}).
+					Return(nil, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(hasShortURL(newURL.ShortUrl))).
+					Return([]int64{}, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedResult: domain.ImportResult{Conflicts: []string{newURL.ShortUrl}},
+			expectedErr:    errs.ErrShortURLConflict,
+		},
+		{
+			name:   "Reserved short url",
+			urls:   []domain.URLData{{ShortUrl: "api", LongUrl: "https://test.longurl"}},
+			params: domain.ImportParams{Conflict: domain.ConflictSkip},
+			buildURLRepo: func() repository.UrlRepo {
+				return mocks.NewUrlRepo(t)
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedErr: errs.ErrReservedAlias,
+		},
+		{
+			name:   "Invalid short url",
+			urls:   []domain.URLData{{ShortUrl: "a/b", LongUrl: "https://test.longurl"}},
+			params: domain.ImportParams{Conflict: domain.ConflictSkip},
+			buildURLRepo: func() repository.UrlRepo {
+				return mocks.NewUrlRepo(t)
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedErr: errs.ErrInvalidShortURL,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlShortener := shortenermocks.NewURLShortener(t)
+			urlShortener.On("ShortenURL", mock.Anything).
+				Return(generatedShortURL).
+				Maybe()
+
+			migrationService := NewMigrationService(
+				logger,
+				tc.buildURLRepo(),
+				tc.buildURLCache(),
+				newTxManager(t),
+				urlShortener,
+				buildIDGenerator(),
+			)
+
+			result, err := migrationService.ImportURLs(context.Background(), tc.urls, tc.params)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestExportURLs(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+
+	firstBatch := []domain.URLData{{ID: 1, ShortUrl: "a"}, {ID: 2, ShortUrl: "b"}}
+	secondBatch := []domain.URLData{{ID: 5, ShortUrl: "c"}}
+
+	mockRepo := mocks.NewUrlRepo(t)
+	mockRepo.On("ListAllURLs", mock.Anything, int64(math.MinInt64), 2).
+		Return(firstBatch, nil).
+		Once()
+	mockRepo.On("ListAllURLs", mock.Anything, int64(2), 2).
+		Return(secondBatch, nil).
+		Once()
+
+	migrationService := NewMigrationService(
+		logger,
+		mockRepo,
+		mocks.NewURLCache(t),
+		mocks.NewTxManager(t),
+		shortenermocks.NewURLShortener(t),
+		idgenmocks.NewIDGenerator(t),
+	)
+
+	var exported []domain.URLData
+	err := migrationService.ExportURLs(context.Background(), 2, func(urls []domain.URLData) error {
+		exported = append(exported, urls...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, append(firstBatch, secondBatch...), exported)
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "CoolUrlShortener/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyService is an autogenerated mock type for the APIKeyService type
+type APIKeyService struct {
+	mock.Mock
+}
+
+// IssueAPIKey provides a mock function with given fields: ctx, ownerID, name
+func (_m *APIKeyService) IssueAPIKey(ctx context.Context, ownerID string, name string) (domain.APIKey, string, error) {
+	ret := _m.Called(ctx, ownerID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueAPIKey")
+	}
+
+	var r0 domain.APIKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.APIKey, string, error)); ok {
+		return rf(ctx, ownerID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.APIKey); ok {
+		r0 = rf(ctx, ownerID, name)
+	} else {
+		r0 = ret.Get(0).(domain.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = rf(ctx, ownerID, name)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, ownerID, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, id
+func (_m *APIKeyService) RevokeAPIKey(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyAPIKey provides a mock function with given fields: ctx, key
+func (_m *APIKeyService) VerifyAPIKey(ctx context.Context, key string) (domain.APIKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAPIKey")
+	}
+
+	var r0 domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.APIKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.APIKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(domain.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAPIKeyService creates a new instance of APIKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyService {
+	mock := &APIKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,75 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "CoolUrlShortener/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MigrationService is an autogenerated mock type for the MigrationService type
+type MigrationService struct {
+	mock.Mock
+}
+
+// ExportURLs provides a mock function with given fields: ctx, batchSize, fn
+func (_m *MigrationService) ExportURLs(ctx context.Context, batchSize int, fn func([]domain.URLData) error) error {
+	ret := _m.Called(ctx, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportURLs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func([]domain.URLData) error) error); ok {
+		r0 = rf(ctx, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportURLs provides a mock function with given fields: ctx, urls, params
+func (_m *MigrationService) ImportURLs(ctx context.Context, urls []domain.URLData, params domain.ImportParams) (domain.ImportResult, error) {
+	ret := _m.Called(ctx, urls, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportURLs")
+	}
+
+	var r0 domain.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLData, domain.ImportParams) (domain.ImportResult, error)); ok {
+		return rf(ctx, urls, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.URLData, domain.ImportParams) domain.ImportResult); ok {
+		r0 = rf(ctx, urls, params)
+	} else {
+		r0 = ret.Get(0).(domain.ImportResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.URLData, domain.ImportParams) error); ok {
+		r1 = rf(ctx, urls, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMigrationService creates a new instance of MigrationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMigrationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MigrationService {
+	mock := &MigrationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	domain "CoolUrlShortener/internal/domain"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,9 +14,27 @@ type URLService struct {
 	mock.Mock
 }
 
-// GetLongURL provides a mock function with given fields: ctx, shortUrl
-func (_m *URLService) GetLongURL(ctx context.Context, shortUrl string) (string, error) {
-	ret := _m.Called(ctx, shortUrl)
+// DeleteURL provides a mock function with given fields: ctx, shortURL, ownerID
+func (_m *URLService) DeleteURL(ctx context.Context, shortURL string, ownerID string) error {
+	ret := _m.Called(ctx, shortURL, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteURL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, shortURL, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLongURL provides a mock function with given fields: ctx, shortUrl, client
+func (_m *URLService) GetLongURL(ctx context.Context, shortUrl string, client domain.ClientInfo) (string, error) {
+	ret := _m.Called(ctx, shortUrl, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLongURL")
@@ -23,17 +42,17 @@ func (_m *URLService) GetLongURL(ctx context.Context, shortUrl string) (string,
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, shortUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ClientInfo) (string, error)); ok {
+		return rf(ctx, shortUrl, client)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, shortUrl)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ClientInfo) string); ok {
+		r0 = rf(ctx, shortUrl, client)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shortUrl)
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.ClientInfo) error); ok {
+		r1 = rf(ctx, shortUrl, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -41,9 +60,46 @@ func (_m *URLService) GetLongURL(ctx context.Context, shortUrl string) (string,
 	return r0, r1
 }
 
-// SaveURL provides a mock function with given fields: ctx, longURL
-func (_m *URLService) SaveURL(ctx context.Context, longURL string) (string, error) {
-	ret := _m.Called(ctx, longURL)
+// ListURLs provides a mock function with given fields: ctx, ownerID, paginationParams
+func (_m *URLService) ListURLs(ctx context.Context, ownerID string, paginationParams domain.PaginationParams) ([]domain.URLData, domain.Pagination, error) {
+	ret := _m.Called(ctx, ownerID, paginationParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListURLs")
+	}
+
+	var r0 []domain.URLData
+	var r1 domain.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PaginationParams) ([]domain.URLData, domain.Pagination, error)); ok {
+		return rf(ctx, ownerID, paginationParams)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PaginationParams) []domain.URLData); ok {
+		r0 = rf(ctx, ownerID, paginationParams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.URLData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PaginationParams) domain.Pagination); ok {
+		r1 = rf(ctx, ownerID, paginationParams)
+	} else {
+		r1 = ret.Get(1).(domain.Pagination)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, domain.PaginationParams) error); ok {
+		r2 = rf(ctx, ownerID, paginationParams)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SaveURL provides a mock function with given fields: ctx, params
+func (_m *URLService) SaveURL(ctx context.Context, params domain.SaveURLParams) (string, error) {
+	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveURL")
@@ -51,17 +107,83 @@ func (_m *URLService) SaveURL(ctx context.Context, longURL string) (string, erro
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, longURL)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SaveURLParams) (string, error)); ok {
+		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, longURL)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.SaveURLParams) string); ok {
+		r0 = rf(ctx, params)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, longURL)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.SaveURLParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveURLs provides a mock function with given fields: ctx, params
+func (_m *URLService) SaveURLs(ctx context.Context, params []domain.SaveURLParams) []domain.SaveURLResult {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveURLs")
+	}
+
+	var r0 []domain.SaveURLResult
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.SaveURLParams) []domain.SaveURLResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SaveURLResult)
+		}
+	}
+
+	return r0
+}
+
+// SetURLActive provides a mock function with given fields: ctx, shortURL, ownerID, active
+func (_m *URLService) SetURLActive(ctx context.Context, shortURL string, ownerID string, active bool) error {
+	ret := _m.Called(ctx, shortURL, ownerID, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetURLActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, shortURL, ownerID, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateURL provides a mock function with given fields: ctx, shortURL, ownerID, longURL
+func (_m *URLService) UpdateURL(ctx context.Context, shortURL string, ownerID string, longURL string) (domain.URLData, error) {
+	ret := _m.Called(ctx, shortURL, ownerID, longURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURL")
+	}
+
+	var r0 domain.URLData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.URLData, error)); ok {
+		return rf(ctx, shortURL, ownerID, longURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) domain.URLData); ok {
+		r0 = rf(ctx, shortURL, ownerID, longURL)
+	} else {
+		r0 = ret.Get(0).(domain.URLData)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, shortURL, ownerID, longURL)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/internal/repository/models"
+)
+
+const (
+	// maxPublishAttempts bounds retries of a batch within a single poll, the batch stays
+	// in the outbox after that and is picked up by the next poll
+	maxPublishAttempts    = 5
+	initialPublishBackoff = 100 * time.Millisecond
+
+	outboxPruneInterval = time.Minute
+)
+
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// LeaseTTL bounds how long a relay holds a partition of the outbox, a partition
+	// of a relay that stopped is taken over by another one after that
+	LeaseTTL time.Duration
+	// Retention is how long sent events are kept before they are pruned
+	Retention time.Duration
+}
+
+// OutboxRelay publishes events from the outbox at least once.
+// Replicas may run relays concurrently, a partition of short urls is leased by one of them
+// while its events are published, so events of a url keep their order
+type OutboxRelay interface {
+	// Run relays events until ctx is done
+	Run(ctx context.Context)
+	// RelayBatch publishes a batch of unsent events of one partition and returns its size
+	RelayBatch(ctx context.Context) (int, error)
+	// Prune removes events sent longer than retention ago
+	Prune(ctx context.Context) error
+}
+
+type outboxRelay struct {
+	logger       *slog.Logger
+	txManager    repository.TxManager
+	eventsOutbox repository.EventsOutbox
+	publisher    repository.EventsPublisher
+	cfg          OutboxRelayConfig
+}
+
+func NewOutboxRelay(
+	logger *slog.Logger,
+	txManager repository.TxManager,
+	eventsOutbox repository.EventsOutbox,
+	publisher repository.EventsPublisher,
+	cfg OutboxRelayConfig,
+) OutboxRelay {
+	return &outboxRelay{
+		logger:       logger,
+		txManager:    txManager,
+		eventsOutbox: eventsOutbox,
+		publisher:    publisher,
+		cfg:          cfg,
+	}
+}
+
+func (r *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var prunedAt time.Time
+	for {
+		// Batches are relayed until no partition with unsent events is free
+		for {
+			relayed, err := r.RelayBatch(ctx)
+			if err != nil {
+				r.logger.Error("relay outbox events", slog.String("error", err.Error()))
+				break
+			}
+			if relayed == 0 {
+				break
+			}
+		}
+
+		if time.Since(prunedAt) >= outboxPruneInterval {
+			err := r.Prune(ctx)
+			if err != nil {
+				r.logger.Error("prune outbox events", slog.String("error", err.Error()))
+			}
+			prunedAt = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch claims a partition and reads its events in a short transaction, publishes them
+// without holding locks and marks them sent with the release of the partition.
+// Publishing stops before the lease ends, the partition is not published by two relays at once
+func (r *outboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	publishCtx, cancel := context.WithTimeout(ctx, r.cfg.LeaseTTL)
+	defer cancel()
+
+	var lease models.OutboxLease
+	var outboxEvents []models.OutboxEvent
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		lease, err = r.eventsOutbox.ClaimPartition(ctx, r.cfg.LeaseTTL)
+		if err != nil {
+			return err
+		}
+
+		outboxEvents, err = r.eventsOutbox.UnsentEvents(ctx, lease.Partition, r.cfg.BatchSize)
+		return err
+	})
+	if errors.Is(err, errs.ErrNoOutboxPartition) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]int64, len(outboxEvents))
+	events := make([]models.URLEvent, len(outboxEvents))
+	for i, outboxEvent := range outboxEvents {
+		ids[i] = outboxEvent.ID
+		events[i] = outboxEvent.Event
+	}
+
+	if len(events) > 0 {
+		err = r.publish(publishCtx, events)
+		if err != nil {
+			// The partition is retried by the next poll of any relay
+			return 0, errors.Join(err, r.eventsOutbox.ReleasePartition(context.WithoutCancel(ctx), lease))
+		}
+	}
+
+	err = r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if len(ids) > 0 {
+			err := r.eventsOutbox.MarkEventsSent(ctx, ids, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		return r.eventsOutbox.ReleasePartition(ctx, lease)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(outboxEvents), nil
+}
+
+// publish retries the whole batch with exponential backoff, events delivered
+// before a failed attempt are delivered again
+func (r *outboxRelay) publish(ctx context.Context, events []models.URLEvent) error {
+	backoff := initialPublishBackoff
+	var err error
+	for attempt := 1; attempt <= maxPublishAttempts; attempt++ {
+		err = r.publisher.PublishEvents(ctx, events)
+		if err == nil {
+			return nil
+		}
+		if attempt == maxPublishAttempts {
+			break
+		}
+
+		r.logger.Warn("publish outbox events", slog.String("error", err.Error()), slog.Int("attempt", attempt))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return err
+}
+
+func (r *outboxRelay) Prune(ctx context.Context) error {
+	pruned, err := r.eventsOutbox.DeleteSentEvents(ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		r.logger.Debug("pruned outbox events", slog.Int("count", pruned))
+	}
+
+	return nil
+}
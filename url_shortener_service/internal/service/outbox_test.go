@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/repository"
+	"CoolUrlShortener/internal/repository/mocks"
+	"CoolUrlShortener/internal/repository/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRelayBatch(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	cfg := OutboxRelayConfig{PollInterval: time.Second, BatchSize: 10, Retention: time.Hour, LeaseTTL: time.Minute}
+	lease := models.OutboxLease{Partition: 3, ClaimedUntil: time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)}
+
+	outboxEvents := []models.OutboxEvent{
+		{ID: 1, Event: models.URLEvent{ShortURL: "first", EventType: models.EventTypeCreate}},
+		{ID: 2, Event: models.URLEvent{ShortURL: "second", EventType: models.EventTypeDelete}},
+	}
+	events := []models.URLEvent{outboxEvents[0].Event, outboxEvents[1].Event}
+
+	unexpectedErr := errors.New("unexpected error")
+
+	testCases := []struct {
+		name                 string
+		buildEventsOutbox    func() repository.EventsOutbox
+		buildEventsPublisher func() repository.EventsPublisher
+		expectedRelayed      int
+		expectedErr          error
+	}{
+		{
+			name: "Publish and mark events sent",
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("ClaimPartition", mock.Anything, cfg.LeaseTTL).
+					Return(lease, nil).
+					Once()
+				mockEventsOutbox.On("UnsentEvents", mock.Anything, lease.Partition, cfg.BatchSize).
+					Return(outboxEvents, nil).
+					Once()
+				mockEventsOutbox.On("MarkEventsSent", mock.Anything, []int64{1, 2}, mock.Anything).
+					Return(nil).
+					Once()
+				mockEventsOutbox.On("ReleasePartition", mock.Anything, lease).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildEventsPublisher: func() repository.EventsPublisher {
+				mockEventsPublisher := mocks.NewEventsPublisher(t)
+				mockEventsPublisher.On("PublishEvents", mock.Anything, events).
+					Return(nil).
+					Once()
+
+				return mockEventsPublisher
+			},
+			expectedRelayed: 2,
+		},
+		{
+			name: "Retry failed publish",
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("ClaimPartition", mock.Anything, cfg.LeaseTTL).
+					Return(lease, nil).
+					Once()
+				mockEventsOutbox.On("UnsentEvents", mock.Anything, lease.Partition, cfg.BatchSize).
+					Return(outboxEvents, nil).
+					Once()
+				mockEventsOutbox.On("MarkEventsSent", mock.Anything, []int64{1, 2}, mock.Anything).
+					Return(nil).
+					Once()
+				mockEventsOutbox.On("ReleasePartition", mock.Anything, lease).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildEventsPublisher: func() repository.EventsPublisher {
+				mockEventsPublisher := mocks.NewEventsPublisher(t)
+				mockEventsPublisher.On("PublishEvents", mock.Anything, events).
+					Return(unexpectedErr).
+					Once()
+				mockEventsPublisher.On("PublishEvents", mock.Anything, events).
+					Return(nil).
+					Once()
+
+				return mockEventsPublisher
+			},
+			expectedRelayed: 2,
+		},
+		{
+			name: "Release partition sent by another relay",
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("ClaimPartition", mock.Anything, cfg.LeaseTTL).
+					Return(lease, nil).
+					Once()
+				mockEventsOutbox.On("UnsentEvents", mock.Anything, lease.Partition, cfg.BatchSize).
+					Return([]models.OutboxEvent{}, nil).
+					Once()
+				mockEventsOutbox.On("ReleasePartition", mock.Anything, lease).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildEventsPublisher: func() repository.EventsPublisher {
+				return mocks.NewEventsPublisher(t)
+			},
+			expectedRelayed: 0,
+		},
+		{
+			name: "Every partition is leased",
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("ClaimPartition", mock.Anything, cfg.LeaseTTL).
+					Return(models.OutboxLease{}, errs.ErrNoOutboxPartition).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildEventsPublisher: func() repository.EventsPublisher {
+				return mocks.NewEventsPublisher(t)
+			},
+			expectedRelayed: 0,
+		},
+		{
+			name: "Error while reading events",
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("ClaimPartition", mock.Anything, cfg.LeaseTTL).
+					Return(lease, nil).
+					Once()
+				mockEventsOutbox.On("UnsentEvents", mock.Anything, lease.Partition, cfg.BatchSize).
+					Return(nil, unexpectedErr).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildEventsPublisher: func() repository.EventsPublisher {
+				return mocks.NewEventsPublisher(t)
+			},
+			expectedErr: unexpectedErr,
+		},
+		{
+			name: "Release partition when publish fails",
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("ClaimPartition", mock.Anything, cfg.LeaseTTL).
+					Return(lease, nil).
+					Once()
+				mockEventsOutbox.On("UnsentEvents", mock.Anything, lease.Partition, cfg.BatchSize).
+					Return(outboxEvents, nil).
+					Once()
+				mockEventsOutbox.On("ReleasePartition", mock.Anything, lease).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildEventsPublisher: func() repository.EventsPublisher {
+				mockEventsPublisher := mocks.NewEventsPublisher(t)
+				mockEventsPublisher.On("PublishEvents", mock.Anything, events).
+					Return(unexpectedErr).
+					Times(maxPublishAttempts)
+
+				return mockEventsPublisher
+			},
+			expectedErr: unexpectedErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			relay := NewOutboxRelay(
+				logger,
+				newTxManager(t),
+				tc.buildEventsOutbox(),
+				tc.buildEventsPublisher(),
+				cfg,
+			)
+
+			relayed, err := relay.RelayBatch(context.Background())
+			assert.Equal(t, tc.expectedRelayed, relayed)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRelayBatchCanceled(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockEventsOutbox := mocks.NewEventsOutbox(t)
+	mockEventsOutbox.On("ClaimPartition", mock.Anything, time.Minute).
+		Return(models.OutboxLease{Partition: 1}, nil).
+		Once()
+	mockEventsOutbox.On("UnsentEvents", mock.Anything, 1, 10).
+		Return([]models.OutboxEvent{{ID: 1}}, nil).
+		Once()
+	// The lease is released although ctx is canceled, another relay takes the partition right away
+	mockEventsOutbox.On("ReleasePartition", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	}), models.OutboxLease{Partition: 1}).
+		Return(nil).
+		Once()
+
+	mockEventsPublisher := mocks.NewEventsPublisher(t)
+	mockEventsPublisher.On("PublishEvents", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cancel()
+		}).
+		Return(errors.New("kafka is down")).
+		Once()
+
+	relay := NewOutboxRelay(
+		logger,
+		newTxManager(t),
+		mockEventsOutbox,
+		mockEventsPublisher,
+		OutboxRelayConfig{PollInterval: time.Second, BatchSize: 10, Retention: time.Hour, LeaseTTL: time.Minute},
+	)
+
+	relayed, err := relay.RelayBatch(ctx)
+	assert.Equal(t, 0, relayed)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPrune(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	retention := time.Hour
+
+	mockEventsOutbox := mocks.NewEventsOutbox(t)
+	mockEventsOutbox.On("DeleteSentEvents", mock.Anything, mock.MatchedBy(func(sentBefore time.Time) bool {
+		return time.Since(sentBefore) >= retention
+	})).
+		Return(3, nil).
+		Once()
+
+	relay := NewOutboxRelay(
+		logger,
+		newTxManager(t),
+		mockEventsOutbox,
+		mocks.NewEventsPublisher(t),
+		OutboxRelayConfig{PollInterval: time.Second, BatchSize: 10, Retention: retention},
+	)
+
+	err := relay.Prune(context.Background())
+	assert.NoError(t, err)
+}
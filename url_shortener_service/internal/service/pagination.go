@@ -0,0 +1,27 @@
+package service
+
+import "CoolUrlShortener/internal/domain"
+
+func newPagination(paginationParams domain.PaginationParams, recordsCount int) domain.Pagination {
+	var pagination domain.Pagination
+
+	pagination.CurrentPage = paginationParams.Page
+	pagination.RecordPerPage = paginationParams.Limit
+
+	pagination.TotalPage = recordsCount / paginationParams.Limit
+	if recordsCount%paginationParams.Limit != 0 {
+		pagination.TotalPage++
+	}
+
+	if paginationParams.Page <= 0 {
+		pagination.Next = paginationParams.Page + 1
+	} else if paginationParams.Page < pagination.TotalPage {
+		pagination.Previous = paginationParams.Page - 1
+		pagination.Next = paginationParams.Page + 1
+	} else if paginationParams.Page == pagination.TotalPage {
+		pagination.Previous = paginationParams.Page - 1
+		pagination.Next = 0
+	}
+
+	return pagination
+}
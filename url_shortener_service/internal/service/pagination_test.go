@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"CoolUrlShortener/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPagination(t *testing.T) {
+	testCases := []struct {
+		name               string
+		paginationParams   domain.PaginationParams
+		recordsCount       int
+		expectedPagination domain.Pagination
+	}{
+		{
+			name:             "100 records",
+			paginationParams: domain.PaginationParams{Page: 5, Limit: 10},
+			recordsCount:     100,
+			expectedPagination: domain.Pagination{
+				Next:          6,
+				Previous:      4,
+				RecordPerPage: 10,
+				CurrentPage:   5,
+				TotalPage:     10,
+			},
+		},
+		{
+			name:             "101 records. Last page is not full",
+			paginationParams: domain.PaginationParams{Page: 11, Limit: 10},
+			recordsCount:     101,
+			expectedPagination: domain.Pagination{
+				Next:          0,
+				Previous:      10,
+				RecordPerPage: 10,
+				CurrentPage:   11,
+				TotalPage:     11,
+			},
+		},
+		{
+			name:             "No records",
+			paginationParams: domain.PaginationParams{Page: 1, Limit: 10},
+			recordsCount:     0,
+			expectedPagination: domain.Pagination{
+				RecordPerPage: 10,
+				CurrentPage:   1,
+				TotalPage:     0,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pagination := newPagination(tc.paginationParams, tc.recordsCount)
+			assert.Equal(t, tc.expectedPagination, pagination)
+		})
+	}
+}
@@ -5,121 +5,612 @@ import (
 	"errors"
 	"log/slog"
 	"time"
+	"unicode/utf8"
 
 	"CoolUrlShortener/internal/domain"
 	"CoolUrlShortener/internal/errs"
 	"CoolUrlShortener/internal/repository"
 	"CoolUrlShortener/internal/repository/models"
+	"CoolUrlShortener/pkg/idgen"
 	"CoolUrlShortener/pkg/shortener"
 	"github.com/google/uuid"
 )
 
+// maxSaveURLAttempts bounds the number of fresh ids tried when a generated id or short url collides
+const maxSaveURLAttempts = 5
+
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name URLService
 type URLService interface {
-	GetLongURL(ctx context.Context, shortUrl string) (string, error)
-	SaveURL(ctx context.Context, longURL string) (string, error)
+	// GetLongURL resolves a short url followed by the client, the client is attached to the follow event
+	GetLongURL(ctx context.Context, shortUrl string, client domain.ClientInfo) (string, error)
+	SaveURL(ctx context.Context, params domain.SaveURLParams) (string, error)
+	// SaveURLs saves every url as SaveURL would, results are in the order of params
+	SaveURLs(ctx context.Context, params []domain.SaveURLParams) []domain.SaveURLResult
+	DeleteURL(ctx context.Context, shortURL string, ownerID string) error
+	SetURLActive(ctx context.Context, shortURL string, ownerID string, active bool) error
+	UpdateURL(ctx context.Context, shortURL string, ownerID string, longURL string) (domain.URLData, error)
+	ListURLs(
+		ctx context.Context,
+		ownerID string,
+		paginationParams domain.PaginationParams,
+	) ([]domain.URLData, domain.Pagination, error)
 }
 
+// urlService stores create, update and delete events in the outbox in the same transaction
+// as the change. Follow events do not touch the database, so they are produced directly
 type urlService struct {
 	logger         *slog.Logger
+	txManager      repository.TxManager
 	urlRepo        repository.UrlRepo
+	eventsOutbox   repository.EventsOutbox
 	urlCache       repository.URLCache
 	eventsProducer repository.EventsProducer
 	urlShortener   shortener.URLShortener
+	idGenerator    idgen.IDGenerator
 }
 
 func NewURLService(
 	logger *slog.Logger,
+	txManager repository.TxManager,
 	repo repository.UrlRepo,
+	eventsOutbox repository.EventsOutbox,
 	urlCache repository.URLCache,
 	eventsProducer repository.EventsProducer,
 	urlShortener shortener.URLShortener,
+	idGenerator idgen.IDGenerator,
 ) URLService {
 	return &urlService{
 		logger:         logger,
+		txManager:      txManager,
 		urlRepo:        repo,
+		eventsOutbox:   eventsOutbox,
 		urlCache:       urlCache,
 		eventsProducer: eventsProducer,
 		urlShortener:   urlShortener,
+		idGenerator:    idGenerator,
+	}
+}
+
+func newURLEvent(eventType int8, longURL string, shortURL string, eventContext models.EventContext) models.URLEvent {
+	return models.URLEvent{
+		ID:        uuid.NewString(),
+		LongURL:   longURL,
+		ShortURL:  shortURL,
+		EventTime: time.Now().UnixMilli(),
+		EventType: eventType,
+		Context:   eventContext,
+	}
+}
+
+// Headers are sent by clients as they like, so they are cut to bound the size of events
+const (
+	maxReferrerLength       = 2048
+	maxUserAgentLength      = 512
+	maxAcceptLanguageLength = 256
+)
+
+func followContext(client domain.ClientInfo) models.EventContext {
+	return models.EventContext{
+		Referrer:       truncate(client.Referrer, maxReferrerLength),
+		UserAgent:      truncate(client.UserAgent, maxUserAgentLength),
+		AcceptLanguage: truncate(client.AcceptLanguage, maxAcceptLanguageLength),
+		ClientIP:       client.IP,
+	}
+}
+
+// truncate cuts s to at most maxLen bytes without splitting a utf-8 character
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
 	}
+
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
 }
 
-func (s *urlService) GetLongURL(ctx context.Context, shortURL string) (string, error) {
+func (s *urlService) GetLongURL(ctx context.Context, shortURL string, client domain.ClientInfo) (string, error) {
 	longURLCache, err := s.urlCache.GetLongURL(ctx, shortURL)
 	if err == nil {
-		s.eventsProducer.ProduceEvent(
-			models.URLEvent{
-				LongURL:   longURLCache,
-				ShortURL:  shortURL,
-				EventTime: time.Now().Unix(),
-				EventType: models.EventTypeFollow,
-			},
-		)
+		s.eventsProducer.ProduceEvent(newURLEvent(models.EventTypeFollow, longURLCache, shortURL, followContext(client)))
 		return longURLCache, nil
 	}
 
-	longURL, err := s.urlRepo.GetLongURL(ctx, shortURL)
+	urlData, err := s.urlRepo.GetURL(ctx, shortURL)
 	if err != nil {
 		return "", err
 	}
-	err = s.urlCache.SetLongURL(ctx, shortURL, longURL)
+	if !urlData.IsActive {
+		return "", errs.ErrURLInactive
+	}
+	if urlData.IsExpired(time.Now()) {
+		return "", errs.ErrURLExpired
+	}
+	longURL := urlData.LongUrl
+
+	err = s.urlCache.SetLongURL(ctx, shortURL, longURL, urlData.ExpiresAt)
 	if err != nil {
 		s.logger.Error(err.Error())
 	}
 
-	s.eventsProducer.ProduceEvent(
-		models.URLEvent{
-			LongURL:   longURL,
-			ShortURL:  shortURL,
-			EventTime: time.Now().Unix(),
-			EventType: models.EventTypeFollow,
-		},
-	)
+	s.eventsProducer.ProduceEvent(newURLEvent(models.EventTypeFollow, longURL, shortURL, followContext(client)))
 	return longURL, nil
 }
 
-func (s *urlService) SaveURL(ctx context.Context, longURL string) (string, error) {
-	gotShortURL, err := s.urlRepo.GetShortURLByLongURL(ctx, longURL)
+func (s *urlService) SaveURL(ctx context.Context, params domain.SaveURLParams) (string, error) {
+	if !params.ExpiresAt.IsZero() && !params.ExpiresAt.After(time.Now()) {
+		return "", errs.ErrInvalidExpiry
+	}
+
+	if params.Alias != "" {
+		return s.saveAlias(ctx, params)
+	}
+
+	// Links with an expiry always get their own short url
+	if params.ExpiresAt.IsZero() {
+		gotShortURL, err := s.urlRepo.GetShortURLByLongURL(ctx, params.LongURL, params.OwnerID)
+		if err == nil {
+			err = s.eventsOutbox.AddEvents(
+				ctx,
+				[]models.URLEvent{newURLEvent(models.EventTypeCreate, params.LongURL, gotShortURL, models.EventContext{OwnerID: params.OwnerID})},
+			)
+			if err != nil {
+				return "", err
+			}
+			return gotShortURL, nil
+		}
+		if err != nil && !errors.Is(err, errs.ErrNoURL) {
+			return "", err
+		}
+	}
+
+	for attempt := 1; attempt <= maxSaveURLAttempts; attempt++ {
+		id, err := s.idGenerator.NextID(ctx)
+		if err != nil {
+			return "", err
+		}
+		shortUrl := s.urlShortener.ShortenURL(id)
+
+		err = s.storeURL(ctx, id, shortUrl, params)
+		if errors.Is(err, errs.ErrIDConflict) || errors.Is(err, errs.ErrShortURLConflict) {
+			s.logger.Warn("short url collision", slog.String("short_url", shortUrl), slog.Int("attempt", attempt))
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return shortUrl, nil
+	}
+
+	return "", errs.ErrShortURLGeneration
+}
+
+// saveAlias stores long url under the alias chosen by the caller.
+// Saving the same alias for the same long url by the same owner again is not an error
+// unless the alias was deactivated.
+func (s *urlService) saveAlias(ctx context.Context, params domain.SaveURLParams) (string, error) {
+	err := validateAlias(params.Alias)
+	if err != nil {
+		return "", err
+	}
+
+	gotURLData, err := s.urlRepo.GetURL(ctx, params.Alias)
 	if err == nil {
-		s.eventsProducer.ProduceEvent(
-			models.URLEvent{
-				LongURL:   longURL,
-				ShortURL:  gotShortURL,
-				EventTime: time.Now().Unix(),
-				EventType: models.EventTypeCreate,
-			},
+		if gotURLData.LongUrl != params.LongURL ||
+			!gotURLData.ExpiresAt.Equal(params.ExpiresAt) ||
+			gotURLData.OwnerID != params.OwnerID ||
+			!gotURLData.IsActive {
+			return "", errs.ErrAliasAlreadyExists
+		}
+
+		err = s.eventsOutbox.AddEvents(
+			ctx,
+			[]models.URLEvent{newURLEvent(
+				models.EventTypeCreate,
+				params.LongURL,
+				params.Alias,
+				models.EventContext{OwnerID: params.OwnerID},
+			)},
 		)
-		return gotShortURL, nil
+		if err != nil {
+			return "", err
+		}
+		return params.Alias, nil
+	}
+	if !errors.Is(err, errs.ErrNoURL) {
+		return "", err
+	}
+
+	for attempt := 1; attempt <= maxSaveURLAttempts; attempt++ {
+		var id uint64
+		id, err = s.idGenerator.NextID(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		err = s.storeURL(ctx, id, params.Alias, params)
+		if !errors.Is(err, errs.ErrIDConflict) {
+			break
+		}
+	}
+	if errors.Is(err, errs.ErrShortURLConflict) {
+		// alias was taken concurrently
+		return "", errs.ErrAliasAlreadyExists
 	}
-	if err != nil && !errors.Is(err, errs.ErrNoURL) {
+	if errors.Is(err, errs.ErrIDConflict) {
+		return "", errs.ErrShortURLGeneration
+	}
+	if err != nil {
 		return "", err
 	}
+	return params.Alias, nil
+}
 
-	id := uuid.New().ID()
-	shortUrl := s.urlShortener.ShortenURL(id)
+func (s *urlService) storeURL(ctx context.Context, id uint64, shortURL string, params domain.SaveURLParams) error {
 	urlData := domain.URLData{
 		ID:        int64(id),
-		ShortUrl:  shortUrl,
-		LongUrl:   longURL,
+		ShortUrl:  shortURL,
+		LongUrl:   params.LongURL,
 		CreatedAt: time.Now(),
+		ExpiresAt: params.ExpiresAt,
+		IsActive:  true,
+		OwnerID:   params.OwnerID,
 	}
 
-	err = s.urlRepo.SaveURL(ctx, urlData)
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.urlRepo.SaveURL(ctx, urlData)
+		if err != nil {
+			return err
+		}
+		return s.eventsOutbox.AddEvents(
+			ctx,
+			[]models.URLEvent{newURLEvent(models.EventTypeCreate, params.LongURL, shortURL, models.EventContext{OwnerID: params.OwnerID})},
+		)
+	})
 	if err != nil {
-		return "", err
+		return err
 	}
-	err = s.urlCache.SetLongURL(ctx, shortUrl, longURL)
+
+	err = s.urlCache.SetLongURL(ctx, shortURL, params.LongURL, params.ExpiresAt)
 	if err != nil {
 		s.logger.Error(err.Error())
 	}
+	return nil
+}
+
+func (s *urlService) SaveURLs(ctx context.Context, params []domain.SaveURLParams) []domain.SaveURLResult {
+	results := make([]domain.SaveURLResult, len(params))
+	now := time.Now()
+
+	var generated []int
+	for i, p := range params {
+		if !p.ExpiresAt.IsZero() && !p.ExpiresAt.After(now) {
+			results[i].Err = errs.ErrInvalidExpiry
+			continue
+		}
+		// Aliases are rare in batches, they are saved one by one
+		if p.Alias != "" {
+			results[i].ShortURL, results[i].Err = s.saveAlias(ctx, p)
+			continue
+		}
+		generated = append(generated, i)
+	}
+
+	generated = s.reuseShortURLs(ctx, params, generated, results)
+	s.storeURLs(ctx, params, generated, results)
+
+	return results
+}
+
+// dedupKey identifies urls that share a short url when they never expire
+type dedupKey struct {
+	ownerID string
+	longURL string
+}
+
+// reuseShortURLs fills results of urls that already have a short url and returns the rest
+func (s *urlService) reuseShortURLs(
+	ctx context.Context,
+	params []domain.SaveURLParams,
+	indexes []int,
+	results []domain.SaveURLResult,
+) []int {
+	longURLsByOwner := make(map[string][]string)
+	seen := make(map[dedupKey]bool)
+	for _, i := range indexes {
+		key := dedupKey{ownerID: params[i].OwnerID, longURL: params[i].LongURL}
+		if params[i].ExpiresAt.IsZero() && !seen[key] {
+			seen[key] = true
+			longURLsByOwner[key.ownerID] = append(longURLsByOwner[key.ownerID], key.longURL)
+		}
+	}
+
+	shortURLs := make(map[dedupKey]string)
+	failedOwners := make(map[string]error)
+	for ownerID, longURLs := range longURLsByOwner {
+		gotShortURLs, err := s.urlRepo.GetShortURLsByLongURLs(ctx, longURLs, ownerID)
+		if err != nil {
+			failedOwners[ownerID] = err
+			continue
+		}
+		for longURL, shortURL := range gotShortURLs {
+			shortURLs[dedupKey{ownerID: ownerID, longURL: longURL}] = shortURL
+		}
+	}
+
+	var rest, reused []int
+	for _, i := range indexes {
+		p := params[i]
+		if !p.ExpiresAt.IsZero() {
+			rest = append(rest, i)
+			continue
+		}
+		if err, ok := failedOwners[p.OwnerID]; ok {
+			results[i].Err = err
+			continue
+		}
+
+		shortURL, ok := shortURLs[dedupKey{ownerID: p.OwnerID, longURL: p.LongURL}]
+		if !ok {
+			rest = append(rest, i)
+			continue
+		}
+
+		results[i].ShortURL = shortURL
+		reused = append(reused, i)
+	}
+	s.addCreateEvents(ctx, params, reused, results)
+
+	return rest
+}
+
+// addCreateEvents stores create events of urls that were saved before, a failure fails their results
+func (s *urlService) addCreateEvents(
+	ctx context.Context,
+	params []domain.SaveURLParams,
+	indexes []int,
+	results []domain.SaveURLResult,
+) {
+	if len(indexes) == 0 {
+		return
+	}
+
+	events := make([]models.URLEvent, len(indexes))
+	for k, i := range indexes {
+		events[k] = newURLEvent(
+			models.EventTypeCreate,
+			params[i].LongURL,
+			results[i].ShortURL,
+			models.EventContext{OwnerID: params[i].OwnerID},
+		)
+	}
+
+	err := s.eventsOutbox.AddEvents(ctx, events)
+	if err != nil {
+		for _, i := range indexes {
+			results[i] = domain.SaveURLResult{Err: err}
+		}
+	}
+}
+
+// storeURLs saves urls with generated short urls in a single insert per attempt.
+// Only urls that collided are retried with fresh ids, the same long url repeated
+// in the batch is stored once.
+func (s *urlService) storeURLs(
+	ctx context.Context,
+	params []domain.SaveURLParams,
+	indexes []int,
+	results []domain.SaveURLResult,
+) {
+	firstIndexes := make(map[dedupKey]int)
+	duplicates := make(map[int]int)
+	var pending []int
+	for _, i := range indexes {
+		if params[i].ExpiresAt.IsZero() {
+			key := dedupKey{ownerID: params[i].OwnerID, longURL: params[i].LongURL}
+			if first, ok := firstIndexes[key]; ok {
+				duplicates[i] = first
+				continue
+			}
+			firstIndexes[key] = i
+		}
+		pending = append(pending, i)
+	}
+
+	var savedURLs []domain.URLData
+	for attempt := 1; attempt <= maxSaveURLAttempts && len(pending) > 0; attempt++ {
+		urls := make([]domain.URLData, 0, len(pending))
+		urlIndexes := make([]int, 0, len(pending))
+		for _, i := range pending {
+			id, err := s.idGenerator.NextID(ctx)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+
+			urls = append(urls, domain.URLData{
+				ID:        int64(id),
+				ShortUrl:  s.urlShortener.ShortenURL(id),
+				LongUrl:   params[i].LongURL,
+				CreatedAt: time.Now(),
+				ExpiresAt: params[i].ExpiresAt,
+				IsActive:  true,
+				OwnerID:   params[i].OwnerID,
+			})
+			urlIndexes = append(urlIndexes, i)
+		}
+		if len(urls) == 0 {
+			pending = nil
+			break
+		}
+
+		saved := make(map[int64]bool, len(urls))
+		err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			savedIDs, err := s.urlRepo.SaveURLs(ctx, urls)
+			if err != nil {
+				return err
+			}
+
+			events := make([]models.URLEvent, 0, len(savedIDs))
+			for _, id := range savedIDs {
+				saved[id] = true
+			}
+			for _, urlData := range urls {
+				if saved[urlData.ID] {
+					events = append(events, newURLEvent(
+						models.EventTypeCreate,
+						urlData.LongUrl,
+						urlData.ShortUrl,
+						models.EventContext{OwnerID: urlData.OwnerID},
+					))
+				}
+			}
+			if len(events) == 0 {
+				return nil
+			}
+			return s.eventsOutbox.AddEvents(ctx, events)
+		})
+		if err != nil {
+			for _, i := range urlIndexes {
+				results[i].Err = err
+			}
+			pending = nil
+			break
+		}
+
+		pending = nil
+		for k, urlData := range urls {
+			if !saved[urlData.ID] {
+				pending = append(pending, urlIndexes[k])
+				continue
+			}
+			results[urlIndexes[k]].ShortURL = urlData.ShortUrl
+			savedURLs = append(savedURLs, urlData)
+		}
+		if len(pending) > 0 {
+			s.logger.Warn("short url collisions in batch", slog.Int("count", len(pending)), slog.Int("attempt", attempt))
+		}
+	}
+	for _, i := range pending {
+		results[i].Err = errs.ErrShortURLGeneration
+	}
+
+	if len(savedURLs) > 0 {
+		err := s.urlCache.SetLongURLs(ctx, savedURLs)
+		if err != nil {
+			s.logger.Error(err.Error())
+		}
+	}
+
+	// Duplicates reuse the short url of their first occurrence like reused urls do
+	var reused []int
+	for i, first := range duplicates {
+		results[i] = results[first]
+		if results[i].Err == nil {
+			reused = append(reused, i)
+		}
+	}
+	s.addCreateEvents(ctx, params, reused, results)
+}
+
+func (s *urlService) DeleteURL(ctx context.Context, shortURL string, ownerID string) error {
+	if ownerID == "" {
+		return errs.ErrOwnerRequired
+	}
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		longURL, err := s.urlRepo.DeleteURL(ctx, shortURL, ownerID)
+		if err != nil {
+			return err
+		}
+		return s.eventsOutbox.AddEvents(
+			ctx,
+			[]models.URLEvent{newURLEvent(models.EventTypeDelete, longURL, shortURL, models.EventContext{OwnerID: ownerID})},
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.urlCache.DeleteLongURL(ctx, shortURL)
+	if err != nil {
+		s.logger.Error(err.Error())
+	}
+	return nil
+}
+
+func (s *urlService) SetURLActive(ctx context.Context, shortURL string, ownerID string, active bool) error {
+	if ownerID == "" {
+		return errs.ErrOwnerRequired
+	}
+
+	err := s.urlRepo.SetURLActive(ctx, shortURL, ownerID, active)
+	if err != nil {
+		return err
+	}
+
+	// Active links are cached again on the next follow
+	if !active {
+		err = s.urlCache.DeleteLongURL(ctx, shortURL)
+		if err != nil {
+			s.logger.Error(err.Error())
+		}
+	}
+
+	return nil
+}
+
+// UpdateURL points an existing short url to a new long url.
+// Edited links are no longer returned by long url deduplication.
+func (s *urlService) UpdateURL(
+	ctx context.Context,
+	shortURL string,
+	ownerID string,
+	longURL string,
+) (domain.URLData, error) {
+	if ownerID == "" {
+		return domain.URLData{}, errs.ErrOwnerRequired
+	}
+
+	var urlData domain.URLData
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		urlData, err = s.urlRepo.UpdateLongURL(ctx, shortURL, ownerID, longURL, time.Now())
+		if err != nil {
+			return err
+		}
+		return s.eventsOutbox.AddEvents(
+			ctx,
+			[]models.URLEvent{newURLEvent(models.EventTypeUpdate, urlData.LongUrl, shortURL, models.EventContext{OwnerID: ownerID})},
+		)
+	})
+	if err != nil {
+		return domain.URLData{}, err
+	}
+
+	err = s.urlCache.DeleteLongURL(ctx, shortURL)
+	if err != nil {
+		s.logger.Error(err.Error())
+	}
+	return urlData, nil
+}
+
+func (s *urlService) ListURLs(
+	ctx context.Context,
+	ownerID string,
+	paginationParams domain.PaginationParams,
+) ([]domain.URLData, domain.Pagination, error) {
+	urls, err := s.urlRepo.ListURLs(ctx, ownerID, paginationParams)
+	if err != nil {
+		return nil, domain.Pagination{}, err
+	}
+
+	recordsCount, err := s.urlRepo.CountURLs(ctx, ownerID)
+	if err != nil {
+		return nil, domain.Pagination{}, err
+	}
 
-	s.eventsProducer.ProduceEvent(
-		models.URLEvent{
-			LongURL:   longURL,
-			ShortURL:  shortUrl,
-			EventTime: time.Now().Unix(),
-			EventType: models.EventTypeCreate,
-		},
-	)
-	return shortUrl, nil
+	return urls, newPagination(paginationParams, recordsCount), nil
 }
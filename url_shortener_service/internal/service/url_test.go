@@ -5,15 +5,21 @@ import (
 	"errors"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"CoolUrlShortener/internal/domain"
 	"CoolUrlShortener/internal/errs"
 	"CoolUrlShortener/internal/repository"
 	"CoolUrlShortener/internal/repository/mocks"
+	"CoolUrlShortener/internal/repository/models"
+	"CoolUrlShortener/pkg/idgen"
 	"CoolUrlShortener/pkg/shortener"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	idgenmocks "CoolUrlShortener/pkg/idgen/mocks"
 	shortenermocks "CoolUrlShortener/pkg/shortener/mocks"
 )
 
@@ -25,6 +31,20 @@ func TestGetLongURL(t *testing.T) {
 
 	testLongURL := "https://test.longurl"
 	testShortURL := "short"
+	testExpiresAt := time.Now().Add(time.Hour)
+	testClient := domain.ClientInfo{
+		Referrer:       "https://referrer.test",
+		UserAgent:      strings.Repeat("a", 600),
+		AcceptLanguage: "en-US,en;q=0.9",
+		IP:             "203.0.113.7",
+	}
+	hasClient := func(event models.URLEvent) bool {
+		return event.EventType == models.EventTypeFollow &&
+			event.Context.Referrer == testClient.Referrer &&
+			event.Context.UserAgent == strings.Repeat("a", maxUserAgentLength) &&
+			event.Context.AcceptLanguage == testClient.AcceptLanguage &&
+			event.Context.ClientIP == testClient.IP
+	}
 
 	testCases := []struct {
 		name                string
@@ -51,7 +71,7 @@ func TestGetLongURL(t *testing.T) {
 			},
 			buildEventsProducer: func() repository.EventsProducer {
 				mockEventsServiceProducer := mocks.NewEventsProducer(t)
-				mockEventsServiceProducer.On("ProduceEvent", mock.Anything).
+				mockEventsServiceProducer.On("ProduceEvent", mock.MatchedBy(hasClient)).
 					Once()
 
 				return mockEventsServiceProducer
@@ -63,8 +83,8 @@ func TestGetLongURL(t *testing.T) {
 			name: "Get long url from database",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetLongURL", mock.Anything, testShortURL).
-					Return(testLongURL, nil).
+				mockRepo.On("GetURL", mock.Anything, testShortURL).
+					Return(domain.URLData{ShortUrl: testShortURL, LongUrl: testLongURL, IsActive: true}, nil).
 					Once()
 
 				return mockRepo
@@ -75,7 +95,7 @@ func TestGetLongURL(t *testing.T) {
 					Return("", errors.New("no long url in cache")).
 					Once()
 
-				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL).
+				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL, mock.Anything).
 					Return(nil).
 					Once()
 
@@ -83,7 +103,7 @@ func TestGetLongURL(t *testing.T) {
 			},
 			buildEventsProducer: func() repository.EventsProducer {
 				mockEventsServiceProducer := mocks.NewEventsProducer(t)
-				mockEventsServiceProducer.On("ProduceEvent", mock.Anything).
+				mockEventsServiceProducer.On("ProduceEvent", mock.MatchedBy(hasClient)).
 					Once()
 
 				return mockEventsServiceProducer
@@ -95,8 +115,8 @@ func TestGetLongURL(t *testing.T) {
 			name: "long url not found in db. Should be error",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetLongURL", mock.Anything, testShortURL).
-					Return("", errs.ErrNoURL).
+				mockRepo.On("GetURL", mock.Anything, testShortURL).
+					Return(domain.URLData{}, errs.ErrNoURL).
 					Once()
 
 				return mockRepo
@@ -117,12 +137,106 @@ func TestGetLongURL(t *testing.T) {
 			expectedLongURL: "",
 			expectedErr:     errs.ErrNoURL,
 		},
+		{
+			name: "long url is expired. Should be error",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testShortURL).
+					Return(domain.URLData{
+						ShortUrl:  testShortURL,
+						LongUrl:   testLongURL,
+						ExpiresAt: time.Now().Add(-time.Minute),
+						IsActive:  true,
+					}, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("GetLongURL", mock.Anything, mock.Anything).
+					Return("", errors.New("no long url in cache")).
+					Once()
+
+				return mockCache
+			},
+			buildEventsProducer: func() repository.EventsProducer {
+				mockEventsServiceProducer := mocks.NewEventsProducer(t)
+
+				return mockEventsServiceProducer
+			},
+			expectedLongURL: "",
+			expectedErr:     errs.ErrURLExpired,
+		},
+		{
+			name: "long url is deactivated. Should be error",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testShortURL).
+					Return(domain.URLData{ShortUrl: testShortURL, LongUrl: testLongURL, IsActive: false}, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("GetLongURL", mock.Anything, mock.Anything).
+					Return("", errors.New("no long url in cache")).
+					Once()
+
+				return mockCache
+			},
+			buildEventsProducer: func() repository.EventsProducer {
+				mockEventsServiceProducer := mocks.NewEventsProducer(t)
+
+				return mockEventsServiceProducer
+			},
+			expectedLongURL: "",
+			expectedErr:     errs.ErrURLInactive,
+		},
+		{
+			name: "long url expires later. Cache entry should not outlive it",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testShortURL).
+					Return(domain.URLData{
+						ShortUrl:  testShortURL,
+						LongUrl:   testLongURL,
+						ExpiresAt: testExpiresAt,
+						IsActive:  true,
+					}, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("GetLongURL", mock.Anything, mock.Anything).
+					Return("", errors.New("no long url in cache")).
+					Once()
+
+				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL, testExpiresAt).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			buildEventsProducer: func() repository.EventsProducer {
+				mockEventsServiceProducer := mocks.NewEventsProducer(t)
+				mockEventsServiceProducer.On("ProduceEvent", mock.Anything).
+					Once()
+
+				return mockEventsServiceProducer
+			},
+			expectedLongURL: testLongURL,
+			expectedErr:     nil,
+		},
 		{
 			name: "could not write to cache. Should not be error",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetLongURL", mock.Anything, testShortURL).
-					Return(testLongURL, nil).
+				mockRepo.On("GetURL", mock.Anything, testShortURL).
+					Return(domain.URLData{ShortUrl: testShortURL, LongUrl: testLongURL, IsActive: true}, nil).
 					Once()
 
 				return mockRepo
@@ -133,7 +247,7 @@ func TestGetLongURL(t *testing.T) {
 					Return("", errors.New("no long url in cache")).
 					Once()
 
-				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL).
+				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL, mock.Anything).
 					Return(errors.New("unexpected error"))
 
 				return mockCache
@@ -154,13 +268,16 @@ func TestGetLongURL(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			urlService := NewURLService(
 				logger,
+				newTxManager(t),
 				tc.buildURLRepo(),
+				mocks.NewEventsOutbox(t),
 				tc.buildURLCache(),
 				tc.buildEventsProducer(),
 				urlShortener,
+				idgen.NewUUIDIDGenerator(),
 			)
 
-			longURL, err := urlService.GetLongURL(context.Background(), testShortURL)
+			longURL, err := urlService.GetLongURL(context.Background(), testShortURL, testClient)
 			assert.Equal(t, tc.expectedLongURL, longURL)
 			assert.Equal(t, tc.expectedErr, err)
 		})
@@ -177,19 +294,19 @@ func TestSaveURL(t *testing.T) {
 	unexpectedErr := errors.New("unexpected error")
 
 	testCases := []struct {
-		name                string
-		buildURLRepo        func() repository.UrlRepo
-		buildURLCache       func() repository.URLCache
-		buildEventsProducer func() repository.EventsProducer
-		buildURLShortener   func() shortener.URLShortener
-		expectedShortURL    string
-		expectedErr         error
+		name              string
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		buildURLShortener func() shortener.URLShortener
+		expectedShortURL  string
+		expectedErr       error
 	}{
 		{
 			name: "Short url exists. Should return existing short url",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL).
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
 					Return(testShortURL, nil)
 
 				return mockRepo
@@ -199,12 +316,13 @@ func TestSaveURL(t *testing.T) {
 
 				return mockCache
 			},
-			buildEventsProducer: func() repository.EventsProducer {
-				mockEventsServiceProducer := mocks.NewEventsProducer(t)
-				mockEventsServiceProducer.On("ProduceEvent", mock.Anything).
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
 					Once()
 
-				return mockEventsServiceProducer
+				return mockEventsOutbox
 			},
 			buildURLShortener: func() shortener.URLShortener {
 				mockURLShortener := shortenermocks.NewURLShortener(t)
@@ -217,7 +335,7 @@ func TestSaveURL(t *testing.T) {
 			name: "unexpected error when reading db",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL).
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
 					Return("", unexpectedErr)
 
 				return mockRepo
@@ -227,10 +345,10 @@ func TestSaveURL(t *testing.T) {
 
 				return mockCache
 			},
-			buildEventsProducer: func() repository.EventsProducer {
-				mockEventsServiceProducer := mocks.NewEventsProducer(t)
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
 
-				return mockEventsServiceProducer
+				return mockEventsOutbox
 			},
 			buildURLShortener: func() shortener.URLShortener {
 				mockURLShortener := shortenermocks.NewURLShortener(t)
@@ -243,7 +361,7 @@ func TestSaveURL(t *testing.T) {
 			name: "create new short url without error",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL).
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
 					Return("", errs.ErrNoURL)
 
 				mockRepo.On("SaveURL", mock.Anything, mock.Anything).
@@ -253,21 +371,22 @@ func TestSaveURL(t *testing.T) {
 			},
 			buildURLCache: func() repository.URLCache {
 				mockCache := mocks.NewURLCache(t)
-				mockCache.On("SetLongURL", mock.Anything, mock.Anything, mock.Anything).
+				mockCache.On("SetLongURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
 
 				return mockCache
 			},
-			buildEventsProducer: func() repository.EventsProducer {
-				mockEventsServiceProducer := mocks.NewEventsProducer(t)
-				mockEventsServiceProducer.On("ProduceEvent", mock.Anything).
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
 					Once()
 
-				return mockEventsServiceProducer
+				return mockEventsOutbox
 			},
 			buildURLShortener: func() shortener.URLShortener {
 				mockURLShortener := shortenermocks.NewURLShortener(t)
-				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint32")).
+				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint64")).
 					Return(testShortURL)
 
 				return mockURLShortener
@@ -279,7 +398,7 @@ func TestSaveURL(t *testing.T) {
 			name: "error while saving url to db. Should return error",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL).
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
 					Return("", errs.ErrNoURL)
 
 				mockRepo.On("SaveURL", mock.Anything, mock.Anything).
@@ -292,14 +411,47 @@ func TestSaveURL(t *testing.T) {
 
 				return mockCache
 			},
-			buildEventsProducer: func() repository.EventsProducer {
-				mockEventsServiceProducer := mocks.NewEventsProducer(t)
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
 
-				return mockEventsServiceProducer
+				return mockEventsOutbox
+			},
+			buildURLShortener: func() shortener.URLShortener {
+				mockURLShortener := shortenermocks.NewURLShortener(t)
+				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint64")).
+					Return(testShortURL)
+
+				return mockURLShortener
+			},
+			expectedShortURL: "",
+			expectedErr:      unexpectedErr,
+		},
+		{
+			name: "error while saving event to outbox. Should return error and not cache url",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
+					Return("", errs.ErrNoURL)
+
+				mockRepo.On("SaveURL", mock.Anything, mock.Anything).
+					Return(nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(unexpectedErr).
+					Once()
+
+				return mockEventsOutbox
 			},
 			buildURLShortener: func() shortener.URLShortener {
 				mockURLShortener := shortenermocks.NewURLShortener(t)
-				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint32")).
+				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint64")).
 					Return(testShortURL)
 
 				return mockURLShortener
@@ -311,7 +463,7 @@ func TestSaveURL(t *testing.T) {
 			name: "error while saving url to cache. Should not return error",
 			buildURLRepo: func() repository.UrlRepo {
 				mockRepo := mocks.NewUrlRepo(t)
-				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL).
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
 					Return("", errs.ErrNoURL)
 
 				mockRepo.On("SaveURL", mock.Anything, mock.Anything).
@@ -321,21 +473,22 @@ func TestSaveURL(t *testing.T) {
 			},
 			buildURLCache: func() repository.URLCache {
 				mockCache := mocks.NewURLCache(t)
-				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL).
+				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL, mock.Anything).
 					Return(unexpectedErr)
 
 				return mockCache
 			},
-			buildEventsProducer: func() repository.EventsProducer {
-				mockEventsServiceProducer := mocks.NewEventsProducer(t)
-				mockEventsServiceProducer.On("ProduceEvent", mock.Anything).
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
 					Once()
 
-				return mockEventsServiceProducer
+				return mockEventsOutbox
 			},
 			buildURLShortener: func() shortener.URLShortener {
 				mockURLShortener := shortenermocks.NewURLShortener(t)
-				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint32")).
+				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint64")).
 					Return(testShortURL)
 
 				return mockURLShortener
@@ -349,15 +502,1396 @@ func TestSaveURL(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			urlService := NewURLService(
 				logger,
+				newTxManager(t),
 				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
 				tc.buildURLCache(),
-				tc.buildEventsProducer(),
+				mocks.NewEventsProducer(t),
 				tc.buildURLShortener(),
+				idgen.NewUUIDIDGenerator(),
 			)
 
-			shortURL, err := urlService.SaveURL(context.Background(), testLongURL)
+			shortURL, err := urlService.SaveURL(context.Background(), domain.SaveURLParams{LongURL: testLongURL})
 			assert.Equal(t, tc.expectedShortURL, shortURL)
 			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
+
+func TestSaveURLWithAlias(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	testLongURL := "https://test.longurl"
+	testAlias := "spring-sale"
+
+	unexpectedErr := errors.New("unexpected error")
+
+	testCases := []struct {
+		name              string
+		alias             string
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		expectedShortURL  string
+		expectedErr       error
+	}{
+		{
+			name:  "Alias is free. Should save url under alias",
+			alias: testAlias,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testAlias).
+					Return(domain.URLData{}, errs.ErrNoURL)
+
+				mockRepo.On("SaveURL", mock.Anything, mock.MatchedBy(func(urlData domain.URLData) bool {
+					return urlData.ShortUrl == testAlias && urlData.LongUrl == testLongURL
+				})).
+					Return(nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURL", mock.Anything, testAlias, testLongURL, mock.Anything).
+					Return(nil)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: testAlias,
+			expectedErr:      nil,
+		},
+		{
+			name:  "Alias already points to the same long url. Should return alias",
+			alias: testAlias,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testAlias).
+					Return(domain.URLData{ShortUrl: testAlias, LongUrl: testLongURL, IsActive: true}, nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: testAlias,
+			expectedErr:      nil,
+		},
+		{
+			name:  "Alias is taken by another long url. Should return error",
+			alias: testAlias,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testAlias).
+					Return(domain.URLData{ShortUrl: testAlias, LongUrl: "https://another.longurl"}, nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrAliasAlreadyExists,
+		},
+		{
+			name:  "Alias points to the same long url but is deactivated. Should return error",
+			alias: testAlias,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testAlias).
+					Return(domain.URLData{ShortUrl: testAlias, LongUrl: testLongURL, IsActive: false}, nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrAliasAlreadyExists,
+		},
+		{
+			name:  "Alias was taken concurrently. Should return error",
+			alias: testAlias,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testAlias).
+					Return(domain.URLData{}, errs.ErrNoURL)
+
+				mockRepo.On("SaveURL", mock.Anything, mock.Anything).
+					Return(errs.ErrShortURLConflict).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrAliasAlreadyExists,
+		},
+		{
+			name:  "Alias is reserved. Should return error",
+			alias: "API",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrReservedAlias,
+		},
+		{
+			name:  "Alias has invalid characters. Should return error",
+			alias: "spring sale!",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrInvalidAlias,
+		},
+		{
+			name:  "unexpected error when reading db",
+			alias: testAlias,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testAlias).
+					Return(domain.URLData{}, unexpectedErr)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: "",
+			expectedErr:      unexpectedErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				shortenermocks.NewURLShortener(t),
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			shortURL, err := urlService.SaveURL(context.Background(), domain.SaveURLParams{
+				LongURL: testLongURL,
+				Alias:   tc.alias,
+			})
+			assert.Equal(t, tc.expectedShortURL, shortURL)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestSaveURLWithExpiry(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	testLongURL := "https://test.longurl"
+	testShortURL := "short"
+
+	testCases := []struct {
+		name              string
+		expiresAt         time.Time
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		buildURLShortener func() shortener.URLShortener
+		expectedShortURL  string
+		expectedErr       error
+	}{
+		{
+			name:      "Expiry in the future. Should create new short url without deduplication",
+			expiresAt: time.Now().Add(time.Hour),
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("SaveURL", mock.Anything, mock.MatchedBy(func(urlData domain.URLData) bool {
+					return !urlData.ExpiresAt.IsZero()
+				})).
+					Return(nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL, mock.AnythingOfType("time.Time")).
+					Return(nil)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildURLShortener: func() shortener.URLShortener {
+				mockURLShortener := shortenermocks.NewURLShortener(t)
+				mockURLShortener.On("ShortenURL", mock.AnythingOfType("uint64")).
+					Return(testShortURL)
+
+				return mockURLShortener
+			},
+			expectedShortURL: testShortURL,
+			expectedErr:      nil,
+		},
+		{
+			name:      "Expiry in the past. Should return error",
+			expiresAt: time.Now().Add(-time.Hour),
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			buildURLShortener: func() shortener.URLShortener {
+				mockURLShortener := shortenermocks.NewURLShortener(t)
+				return mockURLShortener
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrInvalidExpiry,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				tc.buildURLShortener(),
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			shortURL, err := urlService.SaveURL(context.Background(), domain.SaveURLParams{
+				LongURL:   testLongURL,
+				ExpiresAt: tc.expiresAt,
+			})
+			assert.Equal(t, tc.expectedShortURL, shortURL)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+// sequenceURLShortener returns codes in order regardless of id.
+// It is used to force short url collisions deterministically.
+type sequenceURLShortener struct {
+	codes []string
+	calls int
+}
+
+func (s *sequenceURLShortener) ShortenURL(_ uint64) string {
+	code := s.codes[s.calls%len(s.codes)]
+	s.calls++
+	return code
+}
+
+func (s *sequenceURLShortener) Decode(_ string) (uint64, error) {
+	return 0, shortener.ErrInvalidCode
+}
+
+func TestSaveURLCollision(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	testLongURL := "https://test.longurl"
+	takenShortURL := "taken"
+	freeShortURL := "free"
+
+	isShortURL := func(shortURL string) interface{} {
+		return mock.MatchedBy(func(urlData domain.URLData) bool {
+			return urlData.ShortUrl == shortURL
+		})
+	}
+
+	testCases := []struct {
+		name              string
+		codes             []string
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		expectedShortURL  string
+		expectedErr       error
+	}{
+		{
+			name:  "Generated short url is taken. Should retry with fresh id",
+			codes: []string{takenShortURL, freeShortURL},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
+					Return("", errs.ErrNoURL)
+
+				mockRepo.On("SaveURL", mock.Anything, isShortURL(takenShortURL)).
+					Return(errs.ErrShortURLConflict).
+					Once()
+				mockRepo.On("SaveURL", mock.Anything, isShortURL(freeShortURL)).
+					Return(nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURL", mock.Anything, freeShortURL, testLongURL, mock.Anything).
+					Return(nil)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: freeShortURL,
+			expectedErr:      nil,
+		},
+		{
+			name:  "Generated id is taken. Should retry with fresh id",
+			codes: []string{takenShortURL, freeShortURL},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
+					Return("", errs.ErrNoURL)
+
+				mockRepo.On("SaveURL", mock.Anything, isShortURL(takenShortURL)).
+					Return(errs.ErrIDConflict).
+					Once()
+				mockRepo.On("SaveURL", mock.Anything, isShortURL(freeShortURL)).
+					Return(nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURL", mock.Anything, freeShortURL, testLongURL, mock.Anything).
+					Return(nil)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: freeShortURL,
+			expectedErr:      nil,
+		},
+		{
+			name:  "Every generated short url is taken. Should give up after max attempts",
+			codes: []string{takenShortURL},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
+					Return("", errs.ErrNoURL)
+
+				mockRepo.On("SaveURL", mock.Anything, isShortURL(takenShortURL)).
+					Return(errs.ErrShortURLConflict).
+					Times(maxSaveURLAttempts)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+
+				return mockEventsOutbox
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrShortURLGeneration,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				&sequenceURLShortener{codes: tc.codes},
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			shortURL, err := urlService.SaveURL(context.Background(), domain.SaveURLParams{LongURL: testLongURL})
+			assert.Equal(t, tc.expectedShortURL, shortURL)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestSaveURLIDGeneratorError(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	testLongURL := "https://test.longurl"
+	unexpectedErr := errors.New("unexpected error")
+
+	mockRepo := mocks.NewUrlRepo(t)
+	mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, "").
+		Return("", errs.ErrNoURL)
+
+	mockIDGenerator := idgenmocks.NewIDGenerator(t)
+	mockIDGenerator.On("NextID", mock.Anything).
+		Return(uint64(0), unexpectedErr).
+		Once()
+
+	urlService := NewURLService(
+		logger,
+		newTxManager(t),
+		mockRepo,
+		mocks.NewEventsOutbox(t),
+		mocks.NewURLCache(t),
+		mocks.NewEventsProducer(t),
+		shortenermocks.NewURLShortener(t),
+		mockIDGenerator,
+	)
+
+	shortURL, err := urlService.SaveURL(context.Background(), domain.SaveURLParams{LongURL: testLongURL})
+	assert.Equal(t, "", shortURL)
+	assert.Equal(t, unexpectedErr, err)
+}
+
+func TestDeleteURL(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	urlShortener := shortenermocks.NewURLShortener(t)
+
+	testLongURL := "https://test.longurl"
+	testShortURL := "short"
+	testOwnerID := "owner"
+	unexpectedErr := errors.New("unexpected error")
+
+	testCases := []struct {
+		name              string
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		expectedErr       error
+	}{
+		{
+			name: "Delete url. Should evict cache and produce delete event",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("DeleteURL", mock.Anything, testShortURL, testOwnerID).
+					Return(testLongURL, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("DeleteLongURL", mock.Anything, testShortURL).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.MatchedBy(func(events []models.URLEvent) bool {
+					return len(events) == 1 &&
+						events[0].EventType == models.EventTypeDelete &&
+						events[0].ShortURL == testShortURL &&
+						events[0].LongURL == testLongURL
+				})).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Url not found. Should be error",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("DeleteURL", mock.Anything, testShortURL, testOwnerID).
+					Return("", errs.ErrNoURL).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				return mocks.NewEventsOutbox(t)
+			},
+			expectedErr: errs.ErrNoURL,
+		},
+		{
+			name: "Could not evict cache. Should not be error",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("DeleteURL", mock.Anything, testShortURL, testOwnerID).
+					Return(testLongURL, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("DeleteLongURL", mock.Anything, testShortURL).
+					Return(unexpectedErr).
+					Once()
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				urlShortener,
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			err := urlService.DeleteURL(context.Background(), testShortURL, testOwnerID)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestSetURLActive(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	urlShortener := shortenermocks.NewURLShortener(t)
+
+	testShortURL := "short"
+	testOwnerID := "owner"
+
+	testCases := []struct {
+		name          string
+		active        bool
+		buildURLRepo  func() repository.UrlRepo
+		buildURLCache func() repository.URLCache
+		expectedErr   error
+	}{
+		{
+			name:   "Deactivate url. Should evict cache",
+			active: false,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("SetURLActive", mock.Anything, testShortURL, testOwnerID, false).
+					Return(nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("DeleteLongURL", mock.Anything, testShortURL).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			expectedErr: nil,
+		},
+		{
+			name:   "Activate url. Should not touch cache",
+			active: true,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("SetURLActive", mock.Anything, testShortURL, testOwnerID, true).
+					Return(nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:   "Url not found. Should be error",
+			active: false,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("SetURLActive", mock.Anything, testShortURL, testOwnerID, false).
+					Return(errs.ErrNoURL).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			expectedErr: errs.ErrNoURL,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				mocks.NewEventsOutbox(t),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				urlShortener,
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			err := urlService.SetURLActive(context.Background(), testShortURL, testOwnerID, tc.active)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestUpdateURL(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	urlShortener := shortenermocks.NewURLShortener(t)
+
+	testLongURL := "https://new.longurl"
+	testShortURL := "short"
+	testOwnerID := "owner"
+	updatedURLData := domain.URLData{ShortUrl: testShortURL, LongUrl: testLongURL, IsActive: true}
+
+	testCases := []struct {
+		name              string
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		expectedURLData   domain.URLData
+		expectedErr       error
+	}{
+		{
+			name: "Update url. Should evict cache and produce update event",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("UpdateLongURL", mock.Anything, testShortURL, testOwnerID, testLongURL, mock.Anything).
+					Return(updatedURLData, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("DeleteLongURL", mock.Anything, testShortURL).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.MatchedBy(func(events []models.URLEvent) bool {
+					return len(events) == 1 &&
+						events[0].EventType == models.EventTypeUpdate &&
+						events[0].ShortURL == testShortURL &&
+						events[0].LongURL == testLongURL
+				})).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedURLData: updatedURLData,
+			expectedErr:     nil,
+		},
+		{
+			name: "Url not found. Should be error",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("UpdateLongURL", mock.Anything, testShortURL, testOwnerID, testLongURL, mock.Anything).
+					Return(domain.URLData{}, errs.ErrNoURL).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				return mocks.NewEventsOutbox(t)
+			},
+			expectedURLData: domain.URLData{},
+			expectedErr:     errs.ErrNoURL,
+		},
+		{
+			name: "Could not evict cache. Should not be error",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("UpdateLongURL", mock.Anything, testShortURL, testOwnerID, testLongURL, mock.Anything).
+					Return(updatedURLData, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("DeleteLongURL", mock.Anything, testShortURL).
+					Return(errors.New("unexpected error")).
+					Once()
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedURLData: updatedURLData,
+			expectedErr:     nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				urlShortener,
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			urlData, err := urlService.UpdateURL(context.Background(), testShortURL, testOwnerID, testLongURL)
+			assert.Equal(t, tc.expectedURLData, urlData)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestChangeURLWithoutOwner(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+
+	// Mocks without expectations fail the test if an anonymous url is touched
+	urlService := NewURLService(
+		logger,
+		newTxManager(t),
+		mocks.NewUrlRepo(t),
+		mocks.NewEventsOutbox(t),
+		mocks.NewURLCache(t),
+		mocks.NewEventsProducer(t),
+		shortenermocks.NewURLShortener(t),
+		idgen.NewUUIDIDGenerator(),
+	)
+
+	err := urlService.DeleteURL(context.Background(), "short", "")
+	assert.ErrorIs(t, err, errs.ErrOwnerRequired)
+
+	err = urlService.SetURLActive(context.Background(), "short", "", false)
+	assert.ErrorIs(t, err, errs.ErrOwnerRequired)
+
+	_, err = urlService.UpdateURL(context.Background(), "short", "", "https://new.longurl")
+	assert.ErrorIs(t, err, errs.ErrOwnerRequired)
+}
+
+func TestSaveURLWithOwner(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	testLongURL := "https://test.longurl"
+	testShortURL := "short"
+	testAlias := "team-link"
+	testOwnerID := "owner"
+
+	testCases := []struct {
+		name              string
+		alias             string
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		buildURLShortener func() shortener.URLShortener
+		expectedShortURL  string
+		expectedErr       error
+	}{
+		{
+			name: "Owner already has short url. Should return it",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, testOwnerID).
+					Return(testShortURL, nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildURLShortener: func() shortener.URLShortener {
+				return shortenermocks.NewURLShortener(t)
+			},
+			expectedShortURL: testShortURL,
+			expectedErr:      nil,
+		},
+		{
+			name: "Owner has no short url. Should save url with owner",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLByLongURL", mock.Anything, testLongURL, testOwnerID).
+					Return("", errs.ErrNoURL)
+
+				mockRepo.On("SaveURL", mock.Anything, mock.MatchedBy(func(urlData domain.URLData) bool {
+					return urlData.ShortUrl == testShortURL && urlData.OwnerID == testOwnerID
+				})).
+					Return(nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURL", mock.Anything, testShortURL, testLongURL, mock.Anything).
+					Return(nil)
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			buildURLShortener: func() shortener.URLShortener {
+				mockURLShortener := shortenermocks.NewURLShortener(t)
+				mockURLShortener.On("ShortenURL", mock.Anything).
+					Return(testShortURL)
+
+				return mockURLShortener
+			},
+			expectedShortURL: testShortURL,
+			expectedErr:      nil,
+		},
+		{
+			name:  "Alias points to the same long url of another owner. Should return error",
+			alias: testAlias,
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetURL", mock.Anything, testAlias).
+					Return(domain.URLData{
+						ShortUrl: testAlias,
+						LongUrl:  testLongURL,
+						IsActive: true,
+						OwnerID:  "another-owner",
+					}, nil)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				return mocks.NewEventsOutbox(t)
+			},
+			buildURLShortener: func() shortener.URLShortener {
+				return shortenermocks.NewURLShortener(t)
+			},
+			expectedShortURL: "",
+			expectedErr:      errs.ErrAliasAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				tc.buildURLShortener(),
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			shortURL, err := urlService.SaveURL(context.Background(), domain.SaveURLParams{
+				LongURL: testLongURL,
+				Alias:   tc.alias,
+				OwnerID: testOwnerID,
+			})
+			assert.Equal(t, tc.expectedShortURL, shortURL)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestListURLs(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	urlShortener := shortenermocks.NewURLShortener(t)
+
+	testOwnerID := "owner"
+	paginationParams := domain.PaginationParams{Page: 1, Limit: 2}
+	testURLs := []domain.URLData{
+		{ShortUrl: "first", LongUrl: "https://first.longurl", IsActive: true, OwnerID: testOwnerID},
+		{ShortUrl: "second", LongUrl: "https://second.longurl", IsActive: false, OwnerID: testOwnerID},
+	}
+	unexpectedErr := errors.New("unexpected error")
+
+	testCases := []struct {
+		name               string
+		buildURLRepo       func() repository.UrlRepo
+		expectedURLs       []domain.URLData
+		expectedPagination domain.Pagination
+		expectedErr        error
+	}{
+		{
+			name: "List owner urls",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("ListURLs", mock.Anything, testOwnerID, paginationParams).
+					Return(testURLs, nil)
+				mockRepo.On("CountURLs", mock.Anything, testOwnerID).
+					Return(3, nil)
+
+				return mockRepo
+			},
+			expectedURLs: testURLs,
+			expectedPagination: domain.Pagination{
+				Next:          2,
+				Previous:      0,
+				RecordPerPage: 2,
+				CurrentPage:   1,
+				TotalPage:     2,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Error while listing urls",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("ListURLs", mock.Anything, testOwnerID, paginationParams).
+					Return(nil, unexpectedErr)
+
+				return mockRepo
+			},
+			expectedURLs:       nil,
+			expectedPagination: domain.Pagination{},
+			expectedErr:        unexpectedErr,
+		},
+		{
+			name: "Error while counting urls",
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("ListURLs", mock.Anything, testOwnerID, paginationParams).
+					Return(testURLs, nil)
+				mockRepo.On("CountURLs", mock.Anything, testOwnerID).
+					Return(0, unexpectedErr)
+
+				return mockRepo
+			},
+			expectedURLs:       nil,
+			expectedPagination: domain.Pagination{},
+			expectedErr:        unexpectedErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				mocks.NewEventsOutbox(t),
+				mocks.NewURLCache(t),
+				mocks.NewEventsProducer(t),
+				urlShortener,
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			urls, pagination, err := urlService.ListURLs(context.Background(), testOwnerID, paginationParams)
+			assert.Equal(t, tc.expectedURLs, urls)
+			assert.Equal(t, tc.expectedPagination, pagination)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestSaveURLs(t *testing.T) {
+	logger := slog.New(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+	existingLongURL := "https://test.existing"
+	firstLongURL := "https://test.first"
+	secondLongURL := "https://test.second"
+	existingShortURL := "existing"
+	takenShortURL := "taken"
+	unexpectedErr := errors.New("unexpected error")
+
+	// saveFreeURLs saves every url except those with the taken short url
+	saveFreeURLs := func(_ context.Context, urls []domain.URLData) ([]int64, error) {
+		var savedIDs []int64
+		for _, urlData := range urls {
+			if urlData.ShortUrl != takenShortURL {
+				savedIDs = append(savedIDs, urlData.ID)
+			}
+		}
+		return savedIDs, nil
+	}
+
+	testCases := []struct {
+		name              string
+		codes             []string
+		params            []domain.SaveURLParams
+		buildURLRepo      func() repository.UrlRepo
+		buildURLCache     func() repository.URLCache
+		buildEventsOutbox func() repository.EventsOutbox
+		expectedResults   []domain.SaveURLResult
+	}{
+		{
+			name:  "Mixed batch. Should reuse existing, store new once and fail invalid urls",
+			codes: []string{"first", "second"},
+			params: []domain.SaveURLParams{
+				{LongURL: existingLongURL},
+				{LongURL: firstLongURL},
+				{LongURL: secondLongURL, ExpiresAt: time.Now().Add(-time.Hour)},
+				{LongURL: firstLongURL},
+				{LongURL: secondLongURL, Alias: "api"},
+			},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On(
+					"GetShortURLsByLongURLs",
+					mock.Anything,
+					[]string{existingLongURL, firstLongURL},
+					"",
+				).Return(map[string]string{existingLongURL: existingShortURL}, nil)
+
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(func(urls []domain.URLData) bool {
+					return len(urls) == 1 && urls[0].LongUrl == firstLongURL && urls[0].ShortUrl == "first"
+				})).
+					Return(saveFreeURLs, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURLs", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Times(3)
+
+				return mockEventsOutbox
+			},
+			expectedResults: []domain.SaveURLResult{
+				{ShortURL: existingShortURL},
+				{ShortURL: "first"},
+				{Err: errs.ErrInvalidExpiry},
+				{ShortURL: "first"},
+				{Err: errs.ErrReservedAlias},
+			},
+		},
+		{
+			name:  "Generated short url is taken. Should retry only the collided url",
+			codes: []string{takenShortURL, "second", "first"},
+			params: []domain.SaveURLParams{
+				{LongURL: firstLongURL},
+				{LongURL: secondLongURL},
+			},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLsByLongURLs", mock.Anything, mock.Anything, "").
+					Return(map[string]string{}, nil)
+
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(func(urls []domain.URLData) bool {
+					return len(urls) == 2
+				})).
+					Return(saveFreeURLs, nil).
+					Once()
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(func(urls []domain.URLData) bool {
+					return len(urls) == 1 && urls[0].LongUrl == firstLongURL
+				})).
+					Return(saveFreeURLs, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURLs", mock.Anything, mock.MatchedBy(func(urls []domain.URLData) bool {
+					return len(urls) == 2
+				})).
+					Return(nil).
+					Once()
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Times(2)
+
+				return mockEventsOutbox
+			},
+			expectedResults: []domain.SaveURLResult{
+				{ShortURL: "first"},
+				{ShortURL: "second"},
+			},
+		},
+		{
+			name:  "Every generated short url is taken. Should give up after max attempts",
+			codes: []string{takenShortURL},
+			params: []domain.SaveURLParams{
+				{LongURL: firstLongURL, ExpiresAt: time.Now().Add(time.Hour)},
+			},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("SaveURLs", mock.Anything, mock.Anything).
+					Return(saveFreeURLs, nil).
+					Times(maxSaveURLAttempts)
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				return mocks.NewEventsOutbox(t)
+			},
+			expectedResults: []domain.SaveURLResult{
+				{Err: errs.ErrShortURLGeneration},
+			},
+		},
+		{
+			name:  "Unexpected error when saving. Should fail every generated url",
+			codes: []string{"first", "second"},
+			params: []domain.SaveURLParams{
+				{LongURL: firstLongURL},
+				{LongURL: secondLongURL},
+			},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLsByLongURLs", mock.Anything, mock.Anything, "").
+					Return(map[string]string{}, nil)
+				mockRepo.On("SaveURLs", mock.Anything, mock.Anything).
+					Return(nil, unexpectedErr).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				return mocks.NewURLCache(t)
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				return mocks.NewEventsOutbox(t)
+			},
+			expectedResults: []domain.SaveURLResult{
+				{Err: unexpectedErr},
+				{Err: unexpectedErr},
+			},
+		},
+		{
+			name:  "Unexpected error when reading db. Should fail urls of the owner",
+			codes: []string{"first"},
+			params: []domain.SaveURLParams{
+				{LongURL: firstLongURL, OwnerID: "owner"},
+				{LongURL: secondLongURL, ExpiresAt: time.Now().Add(time.Hour), OwnerID: "owner"},
+			},
+			buildURLRepo: func() repository.UrlRepo {
+				mockRepo := mocks.NewUrlRepo(t)
+				mockRepo.On("GetShortURLsByLongURLs", mock.Anything, []string{firstLongURL}, "owner").
+					Return(nil, unexpectedErr)
+				mockRepo.On("SaveURLs", mock.Anything, mock.MatchedBy(func(urls []domain.URLData) bool {
+					return len(urls) == 1 && urls[0].LongUrl == secondLongURL && urls[0].OwnerID == "owner"
+				})).
+					Return(saveFreeURLs, nil).
+					Once()
+
+				return mockRepo
+			},
+			buildURLCache: func() repository.URLCache {
+				mockCache := mocks.NewURLCache(t)
+				mockCache.On("SetLongURLs", mock.Anything, mock.Anything).
+					Return(unexpectedErr).
+					Once()
+
+				return mockCache
+			},
+			buildEventsOutbox: func() repository.EventsOutbox {
+				mockEventsOutbox := mocks.NewEventsOutbox(t)
+				mockEventsOutbox.On("AddEvents", mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockEventsOutbox
+			},
+			expectedResults: []domain.SaveURLResult{
+				{Err: unexpectedErr},
+				{ShortURL: "first"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			urlService := NewURLService(
+				logger,
+				newTxManager(t),
+				tc.buildURLRepo(),
+				tc.buildEventsOutbox(),
+				tc.buildURLCache(),
+				mocks.NewEventsProducer(t),
+				&sequenceURLShortener{codes: tc.codes},
+				idgen.NewUUIDIDGenerator(),
+			)
+
+			results := urlService.SaveURLs(context.Background(), tc.params)
+			assert.Equal(t, tc.expectedResults, results)
+		})
+	}
+}
+
+// newTxManager runs functions passed to WithinTx right away
+func newTxManager(t *testing.T) repository.TxManager {
+	txManager := mocks.NewTxManager(t)
+	txManager.On("WithinTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return txManager
+}
@@ -0,0 +1,205 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/service"
+)
+
+const (
+	commandExport = "export"
+	commandImport = "import"
+
+	defaultBatchSize = 1000
+	// maxBatchSize keeps a batch within limits of a single postgres query
+	maxBatchSize = 10000
+)
+
+var errUsage = errors.New("usage: admin export|import [flags], run admin <command> -h for flags")
+
+// CLI exports and imports urls of all owners in csv or ndjson files
+type CLI struct {
+	logger           *slog.Logger
+	migrationService service.MigrationService
+	stdin            io.Reader
+	stdout           io.Writer
+	stderr           io.Writer
+}
+
+func NewCLI(
+	logger *slog.Logger,
+	migrationService service.MigrationService,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) *CLI {
+	return &CLI{
+		logger:           logger,
+		migrationService: migrationService,
+		stdin:            stdin,
+		stdout:           stdout,
+		stderr:           stderr,
+	}
+}
+
+// Run executes the command given by args without the program name
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case commandExport:
+		return c.runExport(ctx, args[1:])
+	case commandImport:
+		return c.runImport(ctx, args[1:])
+	default:
+		return errUsage
+	}
+}
+
+func (c *CLI) runExport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet(commandExport, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	format := flags.String("format", FormatCSV, "format of the file: csv or ndjson")
+	out := flags.String("out", "", "file to write urls to, stdout if empty")
+	batchSize := flags.Int("batch-size", defaultBatchSize, "number of urls read from the database at once")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *batchSize <= 0 || *batchSize > maxBatchSize {
+		return fmt.Errorf("batch-size must be in 1..%d", maxBatchSize)
+	}
+
+	w := c.stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	urlWriter, err := newURLWriter(w, *format)
+	if err != nil {
+		return err
+	}
+
+	exported := 0
+	err = c.migrationService.ExportURLs(ctx, *batchSize, func(urls []domain.URLData) error {
+		for _, urlData := range urls {
+			err := urlWriter.Write(urlData)
+			if err != nil {
+				return err
+			}
+		}
+		exported += len(urls)
+		c.logger.Debug("exported urls", slog.Int("count", exported))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = urlWriter.Flush()
+	if err != nil {
+		return err
+	}
+	c.logger.Info("export finished", slog.Int("exported", exported))
+	return nil
+}
+
+func (c *CLI) runImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet(commandImport, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	format := flags.String("format", FormatCSV, "format of the file: csv or ndjson")
+	in := flags.String("in", "", "file to read urls from, stdin if empty")
+	conflict := flags.String(
+		"conflict",
+		string(domain.ConflictFail),
+		"what to do with a url whose short url is taken: skip, overwrite or fail",
+	)
+	dryRun := flags.Bool("dry-run", false, "resolve conflicts and count urls without saving them")
+	overwriteOwner := flags.Bool("overwrite-owner", false, "give overwritten urls the owner from the file instead of keeping theirs")
+	batchSize := flags.Int("batch-size", defaultBatchSize, "number of urls saved by a single query")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *batchSize <= 0 || *batchSize > maxBatchSize {
+		return fmt.Errorf("batch-size must be in 1..%d", maxBatchSize)
+	}
+
+	params := domain.ImportParams{
+		Conflict:       domain.ConflictPolicy(*conflict),
+		OverwriteOwner: *overwriteOwner,
+		DryRun:         *dryRun,
+		BatchSize:      *batchSize,
+	}
+	switch params.Conflict {
+	case domain.ConflictSkip, domain.ConflictOverwrite, domain.ConflictFail:
+	default:
+		return fmt.Errorf("unknown conflict policy: %s", *conflict)
+	}
+
+	r := c.stdin
+	if *in != "" {
+		file, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	urlReader, err := newURLReader(r, *format)
+	if err != nil {
+		return err
+	}
+
+	return c.importURLs(ctx, urlReader, params)
+}
+
+// importURLs reads the whole file before saving anything, so conflicts are resolved
+// across all urls of the file and a failed import leaves the database untouched
+func (c *CLI) importURLs(ctx context.Context, urlReader urlReader, params domain.ImportParams) error {
+	var urls []domain.URLData
+	for {
+		urlData, err := urlReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		urls = append(urls, urlData)
+	}
+	c.logger.Debug("read urls", slog.Int("count", len(urls)))
+
+	result, err := c.migrationService.ImportURLs(ctx, urls, params)
+	if len(result.Conflicts) > 0 {
+		c.logger.Warn("short urls are taken", slog.Any("short_urls", result.Conflicts))
+	}
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info(
+		"import finished",
+		slog.Bool("dry_run", params.DryRun),
+		slog.Int("created", result.Created),
+		slog.Int("overwritten", result.Overwritten),
+		slog.Int("skipped", result.Skipped),
+		slog.Int("conflicts", len(result.Conflicts)),
+	)
+	return nil
+}
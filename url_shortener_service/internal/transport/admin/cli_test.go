@@ -0,0 +1,242 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	urls := []domain.URLData{
+		{
+			ShortUrl:  "abc",
+			LongUrl:   "https://test.longurl/?a=1&b=2",
+			CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			ExpiresAt: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+			IsActive:  false,
+			UpdatedAt: time.Date(2024, 2, 2, 3, 4, 5, 0, time.UTC),
+			OwnerID:   "owner",
+		},
+		{
+			ShortUrl:  "x",
+			LongUrl:   "https://test.longurl/with,comma",
+			CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			IsActive:  true,
+		},
+	}
+
+	for _, format := range []string{FormatCSV, FormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := newURLWriter(&buf, format)
+			require.NoError(t, err)
+			for _, urlData := range urls {
+				require.NoError(t, w.Write(urlData))
+			}
+			require.NoError(t, w.Flush())
+
+			r, err := newURLReader(&buf, format)
+			require.NoError(t, err)
+			var got []domain.URLData
+			for {
+				urlData, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				got = append(got, urlData)
+			}
+			assert.Equal(t, urls, got)
+		})
+	}
+}
+
+func TestReadURLs(t *testing.T) {
+	testCases := []struct {
+		name         string
+		format       string
+		input        string
+		expectedURLs []domain.URLData
+		expectedErr  string
+	}{
+		{
+			name:   "Csv columns in any order with defaults",
+			format: FormatCSV,
+			input:  "long_url,short_url\nhttps://test.longurl,abc\nhttps://other.longurl,\n",
+			expectedURLs: []domain.URLData{
+				{ShortUrl: "abc", LongUrl: "https://test.longurl", IsActive: true},
+				{LongUrl: "https://other.longurl", IsActive: true},
+			},
+		},
+		{
+			name:        "Csv without long url column",
+			format:      FormatCSV,
+			input:       "short_url\nabc\n",
+			expectedErr: "csv header: long_url is required",
+		},
+		{
+			name:        "Csv with unknown column",
+			format:      FormatCSV,
+			input:       "long_url,code\nhttps://test.longurl,abc\n",
+			expectedErr: "unknown csv column: code",
+		},
+		{
+			name:        "Csv with invalid time",
+			format:      FormatCSV,
+			input:       "long_url,created_at\nhttps://test.longurl,yesterday\n",
+			expectedErr: "line 2: created_at",
+		},
+		{
+			name:   "Ndjson skips blank lines",
+			format: FormatNDJSON,
+			input:  "{\"short_url\":\"abc\",\"long_url\":\"https://test.longurl\",\"is_active\":false}\n\n",
+			expectedURLs: []domain.URLData{
+				{ShortUrl: "abc", LongUrl: "https://test.longurl", IsActive: false},
+			},
+		},
+		{
+			name:        "Ndjson without long url",
+			format:      FormatNDJSON,
+			input:       "{\"short_url\":\"abc\"}\n\n{\"short_url\":\"abd\"}\n",
+			expectedErr: "line 1: long_url is required",
+		},
+		{
+			name:        "Unknown format",
+			format:      "xml",
+			expectedErr: "unknown format: xml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []domain.URLData
+			r, err := newURLReader(strings.NewReader(tc.input), tc.format)
+			for err == nil {
+				var urlData domain.URLData
+				urlData, err = r.Read()
+				if err == nil {
+					got = append(got, urlData)
+				}
+			}
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.ErrorIs(t, err, io.EOF)
+			assert.Equal(t, tc.expectedURLs, got)
+		})
+	}
+}
+
+func TestRunImport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	input := "short_url,long_url\na1,https://test.longurl/1\na2,https://test.longurl/2\na3,https://test.longurl/3\n"
+
+	testCases := []struct {
+		name                   string
+		args                   []string
+		buildMigrationService  func() *mocks.MigrationService
+		expectedErr            error
+		expectedErrDescription string
+	}{
+		{
+			name: "Import the whole file at once",
+			args: []string{"import", "-batch-size", "2", "-conflict", "overwrite", "-overwrite-owner"},
+			buildMigrationService: func() *mocks.MigrationService {
+				migrationService := mocks.NewMigrationService(t)
+				params := domain.ImportParams{Conflict: domain.ConflictOverwrite, OverwriteOwner: true, BatchSize: 2}
+				migrationService.On("ImportURLs", mock.Anything, mock.MatchedBy(func(urls []domain.URLData) bool {
+					return len(urls) == 3 && urls[0].ShortUrl == "a1" && urls[2].ShortUrl == "a3"
+				}), params).
+					Return(domain.ImportResult{Created: 2, Overwritten: 1, Conflicts: []string{"a2"}}, nil).
+					Once()
+
+				return migrationService
+			},
+		},
+		{
+			name: "Fail on conflict",
+			args: []string{"import", "-batch-size", "2"},
+			buildMigrationService: func() *mocks.MigrationService {
+				migrationService := mocks.NewMigrationService(t)
+				migrationService.On("ImportURLs", mock.Anything, mock.Anything, domain.ImportParams{Conflict: domain.ConflictFail, BatchSize: 2}).
+					Return(domain.ImportResult{Conflicts: []string{"a2"}}, fmt.Errorf("%w: 1 short urls are taken", errs.ErrShortURLConflict)).
+					Once()
+
+				return migrationService
+			},
+			expectedErr:            errs.ErrShortURLConflict,
+			expectedErrDescription: "1 short urls are taken",
+		},
+		{
+			name: "Dry run fails on conflict",
+			args: []string{"import", "-dry-run"},
+			buildMigrationService: func() *mocks.MigrationService {
+				migrationService := mocks.NewMigrationService(t)
+				migrationService.On("ImportURLs", mock.Anything, mock.Anything, domain.ImportParams{Conflict: domain.ConflictFail, DryRun: true, BatchSize: 1000}).
+					Return(domain.ImportResult{Created: 2, Conflicts: []string{"a2"}}, fmt.Errorf("%w: 1 short urls are taken", errs.ErrShortURLConflict)).
+					Once()
+
+				return migrationService
+			},
+			expectedErr:            errs.ErrShortURLConflict,
+			expectedErrDescription: "1 short urls are taken",
+		},
+		{
+			name: "Unknown conflict policy",
+			args: []string{"import", "-conflict", "merge"},
+			buildMigrationService: func() *mocks.MigrationService {
+				return mocks.NewMigrationService(t)
+			},
+			expectedErrDescription: "unknown conflict policy: merge",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := NewCLI(logger, tc.buildMigrationService(), strings.NewReader(input), io.Discard, io.Discard)
+
+			err := cli.Run(context.Background(), tc.args)
+			if tc.expectedErrDescription == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.expectedErrDescription)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRunExport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	migrationService := mocks.NewMigrationService(t)
+	migrationService.On("ExportURLs", mock.Anything, 1000, mock.Anything).
+		Return(func(ctx context.Context, batchSize int, fn func([]domain.URLData) error) error {
+			return fn([]domain.URLData{{ShortUrl: "abc", LongUrl: "https://test.longurl", IsActive: true}})
+		}).
+		Once()
+
+	var out bytes.Buffer
+	cli := NewCLI(logger, migrationService, strings.NewReader(""), &out, io.Discard)
+
+	err := cli.Run(context.Background(), []string{"export", "-format", "ndjson"})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"short_url\":\"abc\",\"long_url\":\"https://test.longurl\",\"is_active\":true}\n", out.String())
+}
@@ -0,0 +1,366 @@
+package admin
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+)
+
+// Formats of exported and imported files
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Columns of csv files and keys of ndjson objects.
+// Only long_url is required on import, empty short_url gets a generated one
+const (
+	columnShortURL  = "short_url"
+	columnLongURL   = "long_url"
+	columnCreatedAt = "created_at"
+	columnExpiresAt = "expires_at"
+	columnIsActive  = "is_active"
+	columnUpdatedAt = "updated_at"
+	columnOwnerID   = "owner_id"
+)
+
+var csvHeader = []string{
+	columnShortURL,
+	columnLongURL,
+	columnCreatedAt,
+	columnExpiresAt,
+	columnIsActive,
+	columnUpdatedAt,
+	columnOwnerID,
+}
+
+// maxNDJSONLineSize bounds a single ndjson line, long urls rarely exceed a few kilobytes
+const maxNDJSONLineSize = 1 << 20
+
+var (
+	errUnknownFormat = errors.New("unknown format")
+	errNoLongURL     = errors.New("long_url is required")
+)
+
+type urlWriter interface {
+	Write(urlData domain.URLData) error
+	Flush() error
+}
+
+// urlReader returns io.EOF after the last url
+type urlReader interface {
+	Read() (domain.URLData, error)
+	// Line is the line of the url returned by the last Read
+	Line() int
+}
+
+func newURLWriter(w io.Writer, format string) (urlWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVURLWriter(w), nil
+	case FormatNDJSON:
+		return newNDJSONURLWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownFormat, format)
+	}
+}
+
+func newURLReader(r io.Reader, format string) (urlReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVURLReader(r)
+	case FormatNDJSON:
+		return newNDJSONURLReader(r), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownFormat, format)
+	}
+}
+
+type csvURLWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVURLWriter(w io.Writer) *csvURLWriter {
+	return &csvURLWriter{
+		w: csv.NewWriter(w),
+	}
+}
+
+func (c *csvURLWriter) Write(urlData domain.URLData) error {
+	if !c.headerWritten {
+		err := c.w.Write(csvHeader)
+		if err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+
+	return c.w.Write([]string{
+		urlData.ShortUrl,
+		urlData.LongUrl,
+		formatTime(urlData.CreatedAt),
+		formatTime(urlData.ExpiresAt),
+		strconv.FormatBool(urlData.IsActive),
+		formatTime(urlData.UpdatedAt),
+		urlData.OwnerID,
+	})
+}
+
+func (c *csvURLWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// csvURLReader matches columns by the header, so they may come in any order
+type csvURLReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+func newCSVURLReader(r io.Reader) (*csvURLReader, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.ReuseRecord = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	known := make(map[string]struct{}, len(csvHeader))
+	for _, column := range csvHeader {
+		known[column] = struct{}{}
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if _, ok := known[column]; !ok {
+			return nil, fmt.Errorf("unknown csv column: %s", column)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns[columnLongURL]; !ok {
+		return nil, fmt.Errorf("csv header: %w", errNoLongURL)
+	}
+
+	return &csvURLReader{
+		r:       csvReader,
+		columns: columns,
+	}, nil
+}
+
+func (c *csvURLReader) Read() (domain.URLData, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		return domain.URLData{}, err
+	}
+	c.line, _ = c.r.FieldPos(0)
+
+	value := func(column string) string {
+		i, ok := c.columns[column]
+		if !ok {
+			return ""
+		}
+		return record[i]
+	}
+
+	urlData, err := parseURLData(urlRecord{
+		ShortURL:  value(columnShortURL),
+		LongURL:   value(columnLongURL),
+		CreatedAt: value(columnCreatedAt),
+		ExpiresAt: value(columnExpiresAt),
+		IsActive:  value(columnIsActive),
+		UpdatedAt: value(columnUpdatedAt),
+		OwnerID:   value(columnOwnerID),
+	})
+	if err != nil {
+		return domain.URLData{}, fmt.Errorf("line %d: %w", c.line, err)
+	}
+
+	return urlData, nil
+}
+
+func (c *csvURLReader) Line() int {
+	return c.line
+}
+
+type ndjsonURLWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONURLWriter(w io.Writer) *ndjsonURLWriter {
+	bufWriter := bufio.NewWriter(w)
+	enc := json.NewEncoder(bufWriter)
+	enc.SetEscapeHTML(false)
+
+	return &ndjsonURLWriter{
+		w:   bufWriter,
+		enc: enc,
+	}
+}
+
+func (n *ndjsonURLWriter) Write(urlData domain.URLData) error {
+	return n.enc.Encode(ndjsonRecord{
+		ShortURL:  urlData.ShortUrl,
+		LongURL:   urlData.LongUrl,
+		CreatedAt: formatTime(urlData.CreatedAt),
+		ExpiresAt: formatTime(urlData.ExpiresAt),
+		IsActive:  &urlData.IsActive,
+		UpdatedAt: formatTime(urlData.UpdatedAt),
+		OwnerID:   urlData.OwnerID,
+	})
+}
+
+func (n *ndjsonURLWriter) Flush() error {
+	return n.w.Flush()
+}
+
+type ndjsonURLReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNDJSONURLReader(r io.Reader) *ndjsonURLReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxNDJSONLineSize)
+
+	return &ndjsonURLReader{
+		scanner: scanner,
+	}
+}
+
+func (n *ndjsonURLReader) Read() (domain.URLData, error) {
+	for n.scanner.Scan() {
+		n.line++
+		line := n.scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		var record ndjsonRecord
+		err := json.Unmarshal(line, &record)
+		if err != nil {
+			return domain.URLData{}, fmt.Errorf("line %d: %w", n.line, err)
+		}
+
+		urlData, err := parseURLData(record.urlRecord())
+		if err != nil {
+			return domain.URLData{}, fmt.Errorf("line %d: %w", n.line, err)
+		}
+		return urlData, nil
+	}
+
+	err := n.scanner.Err()
+	if err != nil {
+		return domain.URLData{}, fmt.Errorf("line %d: %w", n.line+1, err)
+	}
+	return domain.URLData{}, io.EOF
+}
+
+func (n *ndjsonURLReader) Line() int {
+	return n.line
+}
+
+// urlRecord is a url as it is written in a file, empty fields take default values on import
+type urlRecord struct {
+	ShortURL  string
+	LongURL   string
+	CreatedAt string
+	ExpiresAt string
+	IsActive  string
+	UpdatedAt string
+	OwnerID   string
+}
+
+type ndjsonRecord struct {
+	ShortURL  string `json:"short_url"`
+	LongURL   string `json:"long_url"`
+	CreatedAt string `json:"created_at,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	IsActive  *bool  `json:"is_active,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	OwnerID   string `json:"owner_id,omitempty"`
+}
+
+func (r ndjsonRecord) urlRecord() urlRecord {
+	record := urlRecord{
+		ShortURL:  r.ShortURL,
+		LongURL:   r.LongURL,
+		CreatedAt: r.CreatedAt,
+		ExpiresAt: r.ExpiresAt,
+		UpdatedAt: r.UpdatedAt,
+		OwnerID:   r.OwnerID,
+	}
+	if r.IsActive != nil {
+		record.IsActive = strconv.FormatBool(*r.IsActive)
+	}
+
+	return record
+}
+
+func parseURLData(record urlRecord) (domain.URLData, error) {
+	urlData := domain.URLData{
+		ShortUrl: strings.TrimSpace(record.ShortURL),
+		LongUrl:  strings.TrimSpace(record.LongURL),
+		IsActive: true,
+		OwnerID:  strings.TrimSpace(record.OwnerID),
+	}
+	if urlData.LongUrl == "" {
+		return domain.URLData{}, errNoLongURL
+	}
+
+	var err error
+	urlData.CreatedAt, err = parseTime(columnCreatedAt, record.CreatedAt)
+	if err != nil {
+		return domain.URLData{}, err
+	}
+	urlData.ExpiresAt, err = parseTime(columnExpiresAt, record.ExpiresAt)
+	if err != nil {
+		return domain.URLData{}, err
+	}
+	urlData.UpdatedAt, err = parseTime(columnUpdatedAt, record.UpdatedAt)
+	if err != nil {
+		return domain.URLData{}, err
+	}
+
+	isActive := strings.TrimSpace(record.IsActive)
+	if isActive != "" {
+		urlData.IsActive, err = strconv.ParseBool(isActive)
+		if err != nil {
+			return domain.URLData{}, fmt.Errorf("%s: %w", columnIsActive, err)
+		}
+	}
+
+	return urlData, nil
+}
+
+// Zero time is written as an empty string, it stands for absent created_at, expires_at or updated_at
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(column string, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", column, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/service"
+	url "CoolUrlShortener/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ApiKeyServer struct {
+	logger        *slog.Logger
+	apiKeyService service.APIKeyService
+	url.UnimplementedApiKeyServer
+}
+
+func NewApiKeyServer(
+	logger *slog.Logger,
+	apiKeyService service.APIKeyService,
+) *ApiKeyServer {
+	return &ApiKeyServer{
+		logger:        logger,
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (s *ApiKeyServer) IssueApiKey(ctx context.Context, req *url.IssueApiKeyRequest) (*url.IssueApiKeyResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	apiKey, key, err := s.apiKeyService.IssueAPIKey(ctx, req.OwnerId, req.Name)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &url.IssueApiKeyResponse{
+		Id:        apiKey.ID,
+		Key:       key,
+		OwnerId:   apiKey.OwnerID,
+		Name:      apiKey.Name,
+		CreatedAt: timestamppb.New(apiKey.CreatedAt),
+	}, nil
+}
+
+func (s *ApiKeyServer) RevokeApiKey(ctx context.Context, req *url.RevokeApiKeyRequest) (*emptypb.Empty, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.apiKeyService.RevokeAPIKey(ctx, req.Id)
+	if err != nil {
+		s.logger.Error(err.Error())
+		if errors.Is(err, errs.ErrNoAPIKey) {
+			return nil, status.Error(codes.NotFound, "api key not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ApiKeyServer) VerifyApiKey(ctx context.Context, req *url.VerifyApiKeyRequest) (*url.VerifyApiKeyResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	apiKey, err := s.apiKeyService.VerifyAPIKey(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, errs.ErrNoAPIKey) || errors.Is(err, errs.ErrAPIKeyRevoked) {
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &url.VerifyApiKeyResponse{
+		Id:      apiKey.ID,
+		OwnerId: apiKey.OwnerID,
+	}, nil
+}
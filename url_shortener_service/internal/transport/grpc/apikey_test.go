@@ -0,0 +1,329 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"CoolUrlShortener/internal/domain"
+	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/service"
+	"CoolUrlShortener/internal/service/mocks"
+	url "CoolUrlShortener/pkg/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func initApiKeyClient(
+	logger *slog.Logger,
+	apiKeyService service.APIKeyService,
+) (url.ApiKeyClient, func()) {
+	const bufSize = 1024 * 1024
+	lis := bufconn.Listen(bufSize)
+
+	apiKeyServer := NewApiKeyServer(
+		logger, apiKeyService,
+	)
+
+	baseServer := grpc.NewServer()
+
+	url.RegisterApiKeyServer(baseServer, apiKeyServer)
+	go func() {
+		if err := baseServer.Serve(lis); err != nil {
+			log.Printf("Server exited with error: %v", err)
+		}
+	}()
+
+	bufDialer := func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}
+
+	transportOpt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	conn, err := grpc.NewClient("passthrough://bufnet", grpc.WithContextDialer(bufDialer), transportOpt)
+	if err != nil {
+		log.Fatalf("Failed to dial bufnet: %v", err)
+	}
+
+	closer := func() {
+		err := lis.Close()
+		if err != nil {
+			log.Printf("error closing listener: %v", err)
+		}
+		err = conn.Close()
+		if err != nil {
+			log.Printf("error closing conn: %v", err)
+		}
+		baseServer.Stop()
+	}
+
+	client := url.NewApiKeyClient(conn)
+
+	return client, closer
+}
+
+func TestIssueApiKey(t *testing.T) {
+	testOwnerID := "owner"
+	testKey := "cus_key"
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name               string
+		buildApiKeyService func() service.APIKeyService
+		request            *url.IssueApiKeyRequest
+		expectedResp       *url.IssueApiKeyResponse
+		isErrExpected      bool
+		expectedCode       codes.Code
+	}{
+		{
+			name: "issue api key without error. 0 OK",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("IssueAPIKey", mock.Anything, testOwnerID, "tool").
+					Return(domain.APIKey{ID: 1, OwnerID: testOwnerID, Name: "tool", CreatedAt: time.Now()}, testKey, nil)
+
+				return mockService
+			},
+			request:       &url.IssueApiKeyRequest{OwnerId: testOwnerID, Name: "tool"},
+			expectedResp:  &url.IssueApiKeyResponse{Id: 1, Key: testKey, OwnerId: testOwnerID, Name: "tool"},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "issue api key while internal error. 13 Internal",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("IssueAPIKey", mock.Anything, testOwnerID, "").
+					Return(domain.APIKey{}, "", testErr)
+
+				return mockService
+			},
+			request:       &url.IssueApiKeyRequest{OwnerId: testOwnerID},
+			isErrExpected: true,
+			expectedCode:  codes.Internal,
+		},
+		{
+			name: "empty owner should be error. 3 InvalidArgument",
+			buildApiKeyService: func() service.APIKeyService {
+				return mocks.NewAPIKeyService(t)
+			},
+			request:       &url.IssueApiKeyRequest{OwnerId: ""},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			apiKeyClient, cancel := initApiKeyClient(logger, tc.buildApiKeyService())
+			defer cancel()
+
+			resp, err := apiKeyClient.IssueApiKey(context.Background(), tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+
+				assert.Equal(t, ok, true)
+				assert.Equal(t, tc.expectedCode, st.Code())
+				return
+			}
+
+			assert.Equal(t, tc.expectedResp.Id, resp.Id)
+			assert.Equal(t, tc.expectedResp.Key, resp.Key)
+			assert.Equal(t, tc.expectedResp.OwnerId, resp.OwnerId)
+			assert.Equal(t, tc.expectedResp.Name, resp.Name)
+		})
+	}
+}
+
+func TestRevokeApiKey(t *testing.T) {
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name               string
+		buildApiKeyService func() service.APIKeyService
+		request            *url.RevokeApiKeyRequest
+		isErrExpected      bool
+		expectedCode       codes.Code
+	}{
+		{
+			name: "revoke api key without error. 0 OK",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("RevokeAPIKey", mock.Anything, int64(1)).
+					Return(nil)
+
+				return mockService
+			},
+			request:       &url.RevokeApiKeyRequest{Id: 1},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "api key not found. 5 Not found",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("RevokeAPIKey", mock.Anything, int64(1)).
+					Return(errs.ErrNoAPIKey)
+
+				return mockService
+			},
+			request:       &url.RevokeApiKeyRequest{Id: 1},
+			isErrExpected: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name: "revoke api key while internal error. 13 Internal",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("RevokeAPIKey", mock.Anything, int64(1)).
+					Return(testErr)
+
+				return mockService
+			},
+			request:       &url.RevokeApiKeyRequest{Id: 1},
+			isErrExpected: true,
+			expectedCode:  codes.Internal,
+		},
+		{
+			name: "zero id should be error. 3 InvalidArgument",
+			buildApiKeyService: func() service.APIKeyService {
+				return mocks.NewAPIKeyService(t)
+			},
+			request:       &url.RevokeApiKeyRequest{Id: 0},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			apiKeyClient, cancel := initApiKeyClient(logger, tc.buildApiKeyService())
+			defer cancel()
+
+			_, err := apiKeyClient.RevokeApiKey(context.Background(), tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+
+				assert.Equal(t, ok, true)
+				assert.Equal(t, tc.expectedCode, st.Code())
+			}
+		})
+	}
+}
+
+func TestVerifyApiKey(t *testing.T) {
+	testKey := "cus_key"
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name               string
+		buildApiKeyService func() service.APIKeyService
+		request            *url.VerifyApiKeyRequest
+		expectedResp       *url.VerifyApiKeyResponse
+		isErrExpected      bool
+		expectedCode       codes.Code
+	}{
+		{
+			name: "valid api key. 0 OK",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("VerifyAPIKey", mock.Anything, testKey).
+					Return(domain.APIKey{ID: 1, OwnerID: "owner"}, nil)
+
+				return mockService
+			},
+			request:       &url.VerifyApiKeyRequest{Key: testKey},
+			expectedResp:  &url.VerifyApiKeyResponse{Id: 1, OwnerId: "owner"},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "unknown api key. 16 Unauthenticated",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("VerifyAPIKey", mock.Anything, testKey).
+					Return(domain.APIKey{}, errs.ErrNoAPIKey)
+
+				return mockService
+			},
+			request:       &url.VerifyApiKeyRequest{Key: testKey},
+			isErrExpected: true,
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name: "revoked api key. 16 Unauthenticated",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("VerifyAPIKey", mock.Anything, testKey).
+					Return(domain.APIKey{}, errs.ErrAPIKeyRevoked)
+
+				return mockService
+			},
+			request:       &url.VerifyApiKeyRequest{Key: testKey},
+			isErrExpected: true,
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name: "verify api key while internal error. 13 Internal",
+			buildApiKeyService: func() service.APIKeyService {
+				mockService := mocks.NewAPIKeyService(t)
+				mockService.On("VerifyAPIKey", mock.Anything, testKey).
+					Return(domain.APIKey{}, testErr)
+
+				return mockService
+			},
+			request:       &url.VerifyApiKeyRequest{Key: testKey},
+			isErrExpected: true,
+			expectedCode:  codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			apiKeyClient, cancel := initApiKeyClient(logger, tc.buildApiKeyService())
+			defer cancel()
+
+			resp, err := apiKeyClient.VerifyApiKey(context.Background(), tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+
+				assert.Equal(t, ok, true)
+				assert.Equal(t, tc.expectedCode, st.Code())
+				return
+			}
+
+			assert.Equal(t, tc.expectedResp.Id, resp.Id)
+			assert.Equal(t, tc.expectedResp.OwnerId, resp.OwnerId)
+		})
+	}
+}
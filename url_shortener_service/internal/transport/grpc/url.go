@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
+	"CoolUrlShortener/internal/domain"
 	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/principal"
 	"CoolUrlShortener/internal/service"
 	url "CoolUrlShortener/pkg/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type UrlServer struct {
@@ -34,16 +39,121 @@ func (s *UrlServer) ShortenUrl(ctx context.Context, req *url.LongUrlRequest) (*u
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	shortURL, err := s.urlService.SaveURL(ctx, req.LongUrl)
+	ownerID, err := ownerIDFromContext(ctx, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	params := domain.SaveURLParams{
+		LongURL:   req.LongUrl,
+		Alias:     req.Alias,
+		ExpiresAt: expiresAtFromRequest(req, time.Now()),
+		OwnerID:   ownerID,
+	}
+
+	shortURL, err := s.urlService.SaveURL(ctx, params)
 	if err != nil {
 		s.logger.Error(err.Error())
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, saveURLStatus(err).Err()
 	}
 
-	return &url.UrlDataResponse{
-		LongUrl:  req.LongUrl,
+	return urlDataResponse(params, shortURL), nil
+}
+
+func (s *UrlServer) ShortenUrls(ctx context.Context, req *url.ShortenUrlsRequest) (*url.ShortenUrlsResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ownerID, err := ownerIDFromContext(ctx, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	results := make([]*url.ShortenUrlResult, len(req.Urls))
+	params := make([]domain.SaveURLParams, 0, len(req.Urls))
+	paramsIndexes := make([]int, 0, len(req.Urls))
+	for i, longURLReq := range req.Urls {
+		err = longURLReq.Validate()
+		if err != nil {
+			results[i] = shortenUrlErrorResult(status.New(codes.InvalidArgument, err.Error()))
+			continue
+		}
+
+		params = append(params, domain.SaveURLParams{
+			LongURL:   longURLReq.LongUrl,
+			Alias:     longURLReq.Alias,
+			ExpiresAt: expiresAtFromRequest(longURLReq, now),
+			OwnerID:   ownerID,
+		})
+		paramsIndexes = append(paramsIndexes, i)
+	}
+
+	if len(params) > 0 {
+		saveResults := s.urlService.SaveURLs(ctx, params)
+		for k, saveResult := range saveResults {
+			i := paramsIndexes[k]
+			if saveResult.Err != nil {
+				s.logger.Error(saveResult.Err.Error())
+				results[i] = shortenUrlErrorResult(saveURLStatus(saveResult.Err))
+				continue
+			}
+
+			results[i] = &url.ShortenUrlResult{
+				Result: &url.ShortenUrlResult_Url{Url: urlDataResponse(params[k], saveResult.ShortURL)},
+			}
+		}
+	}
+
+	return &url.ShortenUrlsResponse{Results: results}, nil
+}
+
+func saveURLStatus(err error) *status.Status {
+	if errors.Is(err, errs.ErrInvalidAlias) ||
+		errors.Is(err, errs.ErrReservedAlias) ||
+		errors.Is(err, errs.ErrInvalidExpiry) {
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, errs.ErrAliasAlreadyExists) {
+		return status.New(codes.AlreadyExists, err.Error())
+	}
+	return status.New(codes.Internal, err.Error())
+}
+
+func shortenUrlErrorResult(st *status.Status) *url.ShortenUrlResult {
+	return &url.ShortenUrlResult{
+		Result: &url.ShortenUrlResult_Error{
+			Error: &url.ShortenUrlError{
+				Code:    int32(st.Code()),
+				Message: st.Message(),
+			},
+		},
+	}
+}
+
+func urlDataResponse(params domain.SaveURLParams, shortURL string) *url.UrlDataResponse {
+	resp := &url.UrlDataResponse{
+		LongUrl:  params.LongURL,
 		ShortUrl: shortURL,
-	}, nil
+	}
+	if !params.ExpiresAt.IsZero() {
+		resp.ExpiresAt = timestamppb.New(params.ExpiresAt)
+	}
+
+	return resp
+}
+
+func expiresAtFromRequest(req *url.LongUrlRequest, now time.Time) time.Time {
+	switch expiry := req.Expiry.(type) {
+	case *url.LongUrlRequest_ExpiresIn:
+		return now.Add(time.Duration(expiry.ExpiresIn) * time.Second)
+	case *url.LongUrlRequest_ExpiresAt:
+		return expiry.ExpiresAt.AsTime()
+	default:
+		return time.Time{}
+	}
 }
 
 func (s *UrlServer) FollowUrl(ctx context.Context, req *url.ShortUrlRequest) (*url.LongUrlResponse, error) {
@@ -52,12 +162,24 @@ func (s *UrlServer) FollowUrl(ctx context.Context, req *url.ShortUrlRequest) (*u
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	longUrl, err := s.urlService.GetLongURL(ctx, req.ShortUrl)
+	client := domain.ClientInfo{
+		Referrer:       req.GetClient().GetReferrer(),
+		UserAgent:      req.GetClient().GetUserAgent(),
+		AcceptLanguage: req.GetClient().GetAcceptLanguage(),
+		IP:             req.GetClient().GetIp(),
+	}
+	longUrl, err := s.urlService.GetLongURL(ctx, req.ShortUrl, client)
 	if err != nil {
 		s.logger.Error(err.Error())
 		if errors.Is(err, errs.ErrNoURL) {
 			return nil, status.Error(codes.NotFound, "short url not found")
 		}
+		if errors.Is(err, errs.ErrURLExpired) {
+			return nil, status.Error(codes.FailedPrecondition, "short url expired")
+		}
+		if errors.Is(err, errs.ErrURLInactive) {
+			return nil, status.Error(codes.FailedPrecondition, "short url is deactivated")
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -65,3 +187,153 @@ func (s *UrlServer) FollowUrl(ctx context.Context, req *url.ShortUrlRequest) (*u
 		LongUrl: longUrl,
 	}, nil
 }
+
+func (s *UrlServer) DeleteUrl(ctx context.Context, req *url.DeleteUrlRequest) (*emptypb.Empty, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ownerID, err := ownerIDFromContext(ctx, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.urlService.DeleteURL(ctx, req.ShortUrl, ownerID)
+	if err != nil {
+		s.logger.Error(err.Error())
+		if errors.Is(err, errs.ErrNoURL) {
+			return nil, status.Error(codes.NotFound, "short url not found")
+		}
+		if errors.Is(err, errs.ErrOwnerRequired) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UrlServer) SetUrlActive(ctx context.Context, req *url.SetUrlActiveRequest) (*emptypb.Empty, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ownerID, err := ownerIDFromContext(ctx, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.urlService.SetURLActive(ctx, req.ShortUrl, ownerID, req.Active)
+	if err != nil {
+		s.logger.Error(err.Error())
+		if errors.Is(err, errs.ErrNoURL) {
+			return nil, status.Error(codes.NotFound, "short url not found")
+		}
+		if errors.Is(err, errs.ErrOwnerRequired) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UrlServer) UpdateUrl(ctx context.Context, req *url.UpdateUrlRequest) (*url.UrlDataResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ownerID, err := ownerIDFromContext(ctx, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	urlData, err := s.urlService.UpdateURL(ctx, req.ShortUrl, ownerID, req.LongUrl)
+	if err != nil {
+		s.logger.Error(err.Error())
+		if errors.Is(err, errs.ErrNoURL) {
+			return nil, status.Error(codes.NotFound, "short url not found")
+		}
+		if errors.Is(err, errs.ErrOwnerRequired) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &url.UrlDataResponse{
+		LongUrl:  urlData.LongUrl,
+		ShortUrl: urlData.ShortUrl,
+	}
+	if !urlData.ExpiresAt.IsZero() {
+		resp.ExpiresAt = timestamppb.New(urlData.ExpiresAt)
+	}
+
+	return resp, nil
+}
+
+func (s *UrlServer) ListUrls(ctx context.Context, req *url.ListUrlsRequest) (*url.ListUrlsResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ownerID, err := ownerIDFromContext(ctx, req.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	paginationParams := domain.PaginationParams{
+		Page:  int(req.Page),
+		Limit: int(req.Limit),
+	}
+
+	urls, pagination, err := s.urlService.ListURLs(ctx, ownerID, paginationParams)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	urlInfos := make([]*url.UrlInfo, len(urls))
+	for i, urlData := range urls {
+		urlInfos[i] = urlInfoFromDomain(urlData)
+	}
+
+	return &url.ListUrlsResponse{
+		Urls: urlInfos,
+		Pagination: &url.Pagination{
+			Next:          int64(pagination.Next),
+			Previous:      int64(pagination.Previous),
+			RecordPerPage: int64(pagination.RecordPerPage),
+			CurrentPage:   int64(pagination.CurrentPage),
+			TotalPage:     int64(pagination.TotalPage),
+		},
+	}, nil
+}
+
+func urlInfoFromDomain(urlData domain.URLData) *url.UrlInfo {
+	urlInfo := &url.UrlInfo{
+		ShortUrl:  urlData.ShortUrl,
+		LongUrl:   urlData.LongUrl,
+		CreatedAt: timestamppb.New(urlData.CreatedAt),
+		Active:    urlData.IsActive,
+	}
+	if !urlData.ExpiresAt.IsZero() {
+		urlInfo.ExpiresAt = timestamppb.New(urlData.ExpiresAt)
+	}
+
+	return urlInfo
+}
+
+// ownerIDFromContext returns the authenticated caller the request acts on behalf of,
+// callers without a principal are anonymous. A request naming another owner is rejected.
+func ownerIDFromContext(ctx context.Context, requestOwnerID string) (string, error) {
+	p, _ := principal.FromContext(ctx)
+	if requestOwnerID != "" && requestOwnerID != p.OwnerID {
+		return "", status.Error(codes.PermissionDenied, "owner id does not match the caller")
+	}
+
+	return p.OwnerID, nil
+}
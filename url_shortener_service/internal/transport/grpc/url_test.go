@@ -8,8 +8,11 @@ import (
 	"net"
 	"os"
 	"testing"
+	"time"
 
+	"CoolUrlShortener/internal/domain"
 	"CoolUrlShortener/internal/errs"
+	"CoolUrlShortener/internal/principal"
 	"CoolUrlShortener/internal/service"
 	"CoolUrlShortener/internal/service/mocks"
 	url "CoolUrlShortener/pkg/proto"
@@ -18,8 +21,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func initUrlClient(
@@ -33,7 +38,9 @@ func initUrlClient(
 		logger, urlService,
 	)
 
-	baseServer := grpc.NewServer()
+	baseServer := grpc.NewServer(
+		grpc.UnaryInterceptor(principal.UnaryServerInterceptor),
+	)
 
 	url.RegisterUrlServer(baseServer, urlServer)
 	go func() {
@@ -131,6 +138,132 @@ func TestShortenUrl(t *testing.T) {
 			isErrExpected: true,
 			expectedCode:  codes.Internal,
 		},
+		{
+			name: "shorten url with custom alias. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SaveURL", mock.Anything, domain.SaveURLParams{
+					LongURL: testLongUrl,
+					Alias:   "spring-sale",
+				}).
+					Return("spring-sale", nil)
+
+				return mockService
+			},
+			request: &url.LongUrlRequest{
+				LongUrl: testLongUrl,
+				Alias:   "spring-sale",
+			},
+			expectedResp: &url.UrlDataResponse{
+				LongUrl:  testLongUrl,
+				ShortUrl: "spring-sale",
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "alias with invalid characters. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+
+				return mockService
+			},
+			request: &url.LongUrlRequest{
+				LongUrl: testLongUrl,
+				Alias:   "spring sale!",
+			},
+			expectedResp:  &url.UrlDataResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "alias is reserved. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SaveURL", mock.Anything, mock.Anything).
+					Return("", errs.ErrReservedAlias)
+
+				return mockService
+			},
+			request: &url.LongUrlRequest{
+				LongUrl: testLongUrl,
+				Alias:   "api",
+			},
+			expectedResp:  &url.UrlDataResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "alias already exists. 6 AlreadyExists",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SaveURL", mock.Anything, mock.Anything).
+					Return("", errs.ErrAliasAlreadyExists)
+
+				return mockService
+			},
+			request: &url.LongUrlRequest{
+				LongUrl: testLongUrl,
+				Alias:   "spring-sale",
+			},
+			expectedResp:  &url.UrlDataResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.AlreadyExists,
+		},
+		{
+			name: "shorten url with expires in. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SaveURL", mock.Anything, mock.MatchedBy(func(params domain.SaveURLParams) bool {
+					return params.ExpiresAt.After(time.Now())
+				})).
+					Return(testShortUrl, nil)
+
+				return mockService
+			},
+			request: &url.LongUrlRequest{
+				LongUrl: testLongUrl,
+				Expiry:  &url.LongUrlRequest_ExpiresIn{ExpiresIn: 3600},
+			},
+			expectedResp: &url.UrlDataResponse{
+				LongUrl:  testLongUrl,
+				ShortUrl: testShortUrl,
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "negative expires in. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+
+				return mockService
+			},
+			request: &url.LongUrlRequest{
+				LongUrl: testLongUrl,
+				Expiry:  &url.LongUrlRequest_ExpiresIn{ExpiresIn: -1},
+			},
+			expectedResp:  &url.UrlDataResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "expires at in the past. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SaveURL", mock.Anything, mock.Anything).
+					Return("", errs.ErrInvalidExpiry)
+
+				return mockService
+			},
+			request: &url.LongUrlRequest{
+				LongUrl: testLongUrl,
+				Expiry:  &url.LongUrlRequest_ExpiresAt{ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour))},
+			},
+			expectedResp:  &url.UrlDataResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -178,12 +311,23 @@ func TestFollowUrl(t *testing.T) {
 			name: "get long url without error. 0 OK",
 			buildUrlService: func() service.URLService {
 				mockService := mocks.NewURLService(t)
-				mockService.On("GetLongURL", mock.Anything, mock.Anything).
+				mockService.On("GetLongURL", mock.Anything, testShortUrl, domain.ClientInfo{
+					Referrer:  "https://referrer.test",
+					UserAgent: "test-agent",
+					IP:        "203.0.113.7",
+				}).
 					Return(testLongUrl, nil)
 
 				return mockService
 			},
-			request:       &url.ShortUrlRequest{ShortUrl: testShortUrl},
+			request: &url.ShortUrlRequest{
+				ShortUrl: testShortUrl,
+				Client: &url.ClientInfo{
+					Referrer:  "https://referrer.test",
+					UserAgent: "test-agent",
+					Ip:        "203.0.113.7",
+				},
+			},
 			expectedResp:  &url.LongUrlResponse{LongUrl: testLongUrl},
 			isErrExpected: false,
 			expectedCode:  codes.OK,
@@ -192,7 +336,7 @@ func TestFollowUrl(t *testing.T) {
 			name: "url not found . 5 Not found",
 			buildUrlService: func() service.URLService {
 				mockService := mocks.NewURLService(t)
-				mockService.On("GetLongURL", mock.Anything, mock.Anything).
+				mockService.On("GetLongURL", mock.Anything, mock.Anything, mock.Anything).
 					Return("", errs.ErrNoURL)
 
 				return mockService
@@ -202,11 +346,39 @@ func TestFollowUrl(t *testing.T) {
 			isErrExpected: true,
 			expectedCode:  codes.NotFound,
 		},
+		{
+			name: "url expired. 9 FailedPrecondition",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("GetLongURL", mock.Anything, mock.Anything, mock.Anything).
+					Return("", errs.ErrURLExpired)
+
+				return mockService
+			},
+			request:       &url.ShortUrlRequest{ShortUrl: testShortUrl},
+			expectedResp:  &url.LongUrlResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.FailedPrecondition,
+		},
+		{
+			name: "url deactivated. 9 FailedPrecondition",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("GetLongURL", mock.Anything, mock.Anything, mock.Anything).
+					Return("", errs.ErrURLInactive)
+
+				return mockService
+			},
+			request:       &url.ShortUrlRequest{ShortUrl: testShortUrl},
+			expectedResp:  &url.LongUrlResponse{},
+			isErrExpected: true,
+			expectedCode:  codes.FailedPrecondition,
+		},
 		{
 			name: "get long url while internal error. 13 Internal",
 			buildUrlService: func() service.URLService {
 				mockService := mocks.NewURLService(t)
-				mockService.On("GetLongURL", mock.Anything, mock.Anything).
+				mockService.On("GetLongURL", mock.Anything, mock.Anything, mock.Anything).
 					Return("", testErr)
 
 				return mockService
@@ -255,3 +427,605 @@ func TestFollowUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteUrl(t *testing.T) {
+	testShortUrl := "short"
+	testOwnerID := "owner"
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name            string
+		buildUrlService func() service.URLService
+		request         *url.DeleteUrlRequest
+		metadata        metadata.MD
+		isErrExpected   bool
+		expectedCode    codes.Code
+	}{
+		{
+			name: "delete url without error. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("DeleteURL", mock.Anything, testShortUrl, testOwnerID).
+					Return(nil)
+
+				return mockService
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, testOwnerID),
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "url not found. 5 Not found",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("DeleteURL", mock.Anything, testShortUrl, testOwnerID).
+					Return(errs.ErrNoURL)
+
+				return mockService
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, testOwnerID),
+			isErrExpected: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name: "delete url while internal error. 13 Internal",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("DeleteURL", mock.Anything, testShortUrl, testOwnerID).
+					Return(testErr)
+
+				return mockService
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, testOwnerID),
+			isErrExpected: true,
+			expectedCode:  codes.Internal,
+		},
+		{
+			name: "anonymous caller. 16 Unauthenticated",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("DeleteURL", mock.Anything, testShortUrl, "").
+					Return(errs.ErrOwnerRequired)
+
+				return mockService
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl},
+			isErrExpected: true,
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name: "pass empty short url should be error. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: ""},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "owner taken from the forwarded caller. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("DeleteURL", mock.Anything, testShortUrl, testOwnerID).
+					Return(nil)
+
+				return mockService
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, testOwnerID),
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "owner differs from the forwarded caller. 7 PermissionDenied",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl, OwnerId: "other"},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, testOwnerID),
+			isErrExpected: true,
+			expectedCode:  codes.PermissionDenied,
+		},
+		{
+			name: "owner without forwarded caller. 7 PermissionDenied",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID},
+			isErrExpected: true,
+			expectedCode:  codes.PermissionDenied,
+		},
+		{
+			name: "bad api key id metadata. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.DeleteUrlRequest{ShortUrl: testShortUrl},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, testOwnerID, principal.APIKeyIDMetadataKey, "first"),
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			urlClient, cancel := initUrlClient(logger, tc.buildUrlService())
+			defer cancel()
+
+			ctx := context.Background()
+			if tc.metadata != nil {
+				ctx = metadata.NewOutgoingContext(ctx, tc.metadata)
+			}
+
+			_, err := urlClient.DeleteUrl(ctx, tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+
+				assert.Equal(t, ok, true)
+				assert.Equal(t, tc.expectedCode, st.Code())
+			}
+		})
+	}
+}
+
+func TestSetUrlActive(t *testing.T) {
+	testShortUrl := "short"
+	testOwnerID := "owner"
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name            string
+		buildUrlService func() service.URLService
+		request         *url.SetUrlActiveRequest
+		isErrExpected   bool
+		expectedCode    codes.Code
+	}{
+		{
+			name: "deactivate url without error. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SetURLActive", mock.Anything, testShortUrl, testOwnerID, false).
+					Return(nil)
+
+				return mockService
+			},
+			request:       &url.SetUrlActiveRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID, Active: false},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "activate url without error. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SetURLActive", mock.Anything, testShortUrl, testOwnerID, true).
+					Return(nil)
+
+				return mockService
+			},
+			request:       &url.SetUrlActiveRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID, Active: true},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "url not found. 5 Not found",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SetURLActive", mock.Anything, testShortUrl, testOwnerID, false).
+					Return(errs.ErrNoURL)
+
+				return mockService
+			},
+			request:       &url.SetUrlActiveRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID},
+			isErrExpected: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name: "set active while internal error. 13 Internal",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SetURLActive", mock.Anything, testShortUrl, testOwnerID, false).
+					Return(testErr)
+
+				return mockService
+			},
+			request:       &url.SetUrlActiveRequest{ShortUrl: testShortUrl, OwnerId: testOwnerID},
+			isErrExpected: true,
+			expectedCode:  codes.Internal,
+		},
+		{
+			name: "pass empty short url should be error. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.SetUrlActiveRequest{ShortUrl: ""},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			urlClient, cancel := initUrlClient(logger, tc.buildUrlService())
+			defer cancel()
+
+			// The api gateway forwards the caller with the owner it sends in the request
+			ctx := metadata.AppendToOutgoingContext(context.Background(), principal.OwnerIDMetadataKey, tc.request.OwnerId)
+			_, err := urlClient.SetUrlActive(ctx, tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+
+				assert.Equal(t, ok, true)
+				assert.Equal(t, tc.expectedCode, st.Code())
+			}
+		})
+	}
+}
+
+func TestUpdateUrl(t *testing.T) {
+	testLongUrl := "http://test.long"
+	testShortUrl := "short"
+	testOwnerID := "owner"
+	testExpiresAt := time.Now().Add(time.Hour).UTC()
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name            string
+		buildUrlService func() service.URLService
+		request         *url.UpdateUrlRequest
+		expectedResp    *url.UrlDataResponse
+		isErrExpected   bool
+		expectedCode    codes.Code
+	}{
+		{
+			name: "update url without error. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("UpdateURL", mock.Anything, testShortUrl, testOwnerID, testLongUrl).
+					Return(domain.URLData{ShortUrl: testShortUrl, LongUrl: testLongUrl}, nil)
+
+				return mockService
+			},
+			request:       &url.UpdateUrlRequest{ShortUrl: testShortUrl, LongUrl: testLongUrl, OwnerId: testOwnerID},
+			expectedResp:  &url.UrlDataResponse{ShortUrl: testShortUrl, LongUrl: testLongUrl},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "update url with expiry. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("UpdateURL", mock.Anything, testShortUrl, testOwnerID, testLongUrl).
+					Return(domain.URLData{ShortUrl: testShortUrl, LongUrl: testLongUrl, ExpiresAt: testExpiresAt}, nil)
+
+				return mockService
+			},
+			request: &url.UpdateUrlRequest{ShortUrl: testShortUrl, LongUrl: testLongUrl, OwnerId: testOwnerID},
+			expectedResp: &url.UrlDataResponse{
+				ShortUrl:  testShortUrl,
+				LongUrl:   testLongUrl,
+				ExpiresAt: timestamppb.New(testExpiresAt),
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "url not found. 5 Not found",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("UpdateURL", mock.Anything, testShortUrl, testOwnerID, testLongUrl).
+					Return(domain.URLData{}, errs.ErrNoURL)
+
+				return mockService
+			},
+			request:       &url.UpdateUrlRequest{ShortUrl: testShortUrl, LongUrl: testLongUrl, OwnerId: testOwnerID},
+			isErrExpected: true,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name: "update url while internal error. 13 Internal",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("UpdateURL", mock.Anything, testShortUrl, testOwnerID, testLongUrl).
+					Return(domain.URLData{}, testErr)
+
+				return mockService
+			},
+			request:       &url.UpdateUrlRequest{ShortUrl: testShortUrl, LongUrl: testLongUrl, OwnerId: testOwnerID},
+			isErrExpected: true,
+			expectedCode:  codes.Internal,
+		},
+		{
+			name: "pass empty long url should be error. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.UpdateUrlRequest{ShortUrl: testShortUrl, LongUrl: ""},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			urlClient, cancel := initUrlClient(logger, tc.buildUrlService())
+			defer cancel()
+
+			// The api gateway forwards the caller with the owner it sends in the request
+			ctx := metadata.AppendToOutgoingContext(context.Background(), principal.OwnerIDMetadataKey, tc.request.OwnerId)
+			resp, err := urlClient.UpdateUrl(ctx, tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+
+				assert.Equal(t, ok, true)
+				assert.Equal(t, tc.expectedCode, st.Code())
+				return
+			}
+
+			assert.Equal(t, tc.expectedResp.LongUrl, resp.LongUrl)
+			assert.Equal(t, tc.expectedResp.ShortUrl, resp.ShortUrl)
+			assert.Equal(t, tc.expectedResp.ExpiresAt.AsTime(), resp.ExpiresAt.AsTime())
+		})
+	}
+}
+
+func TestListUrls(t *testing.T) {
+	testOwnerID := "owner"
+	testCreatedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name            string
+		buildUrlService func() service.URLService
+		request         *url.ListUrlsRequest
+		expectedResp    *url.ListUrlsResponse
+		isErrExpected   bool
+		expectedCode    codes.Code
+	}{
+		{
+			name: "list urls without error. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("ListURLs", mock.Anything, testOwnerID, domain.PaginationParams{Page: 1, Limit: 10}).
+					Return(
+						[]domain.URLData{
+							{ShortUrl: "short", LongUrl: "http://test.long", CreatedAt: testCreatedAt, IsActive: true},
+						},
+						domain.Pagination{RecordPerPage: 10, CurrentPage: 1, TotalPage: 1},
+						nil,
+					)
+
+				return mockService
+			},
+			request: &url.ListUrlsRequest{OwnerId: testOwnerID, Page: 1, Limit: 10},
+			expectedResp: &url.ListUrlsResponse{
+				Urls: []*url.UrlInfo{
+					{
+						ShortUrl:  "short",
+						LongUrl:   "http://test.long",
+						CreatedAt: timestamppb.New(testCreatedAt),
+						Active:    true,
+					},
+				},
+				Pagination: &url.Pagination{RecordPerPage: 10, CurrentPage: 1, TotalPage: 1},
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "list urls while internal error. 13 Internal",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("ListURLs", mock.Anything, testOwnerID, mock.Anything).
+					Return(nil, domain.Pagination{}, testErr)
+
+				return mockService
+			},
+			request:       &url.ListUrlsRequest{OwnerId: testOwnerID, Page: 1, Limit: 10},
+			isErrExpected: true,
+			expectedCode:  codes.Internal,
+		},
+		{
+			name: "empty owner should be error. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.ListUrlsRequest{OwnerId: "", Page: 1, Limit: 10},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "limit is too big should be error. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.ListUrlsRequest{OwnerId: testOwnerID, Page: 1, Limit: 1000},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			urlClient, cancel := initUrlClient(logger, tc.buildUrlService())
+			defer cancel()
+
+			// The api gateway forwards the caller with the owner it sends in the request
+			ctx := metadata.AppendToOutgoingContext(context.Background(), principal.OwnerIDMetadataKey, tc.request.OwnerId)
+			resp, err := urlClient.ListUrls(ctx, tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+
+				assert.Equal(t, ok, true)
+				assert.Equal(t, tc.expectedCode, st.Code())
+				return
+			}
+
+			assert.Equal(t, len(tc.expectedResp.Urls), len(resp.Urls))
+			for i := range tc.expectedResp.Urls {
+				assert.Equal(t, tc.expectedResp.Urls[i].ShortUrl, resp.Urls[i].ShortUrl)
+				assert.Equal(t, tc.expectedResp.Urls[i].LongUrl, resp.Urls[i].LongUrl)
+				assert.Equal(t, tc.expectedResp.Urls[i].CreatedAt.AsTime(), resp.Urls[i].CreatedAt.AsTime())
+				assert.Equal(t, tc.expectedResp.Urls[i].Active, resp.Urls[i].Active)
+			}
+			assert.Equal(t, tc.expectedResp.Pagination.TotalPage, resp.Pagination.TotalPage)
+			assert.Equal(t, tc.expectedResp.Pagination.CurrentPage, resp.Pagination.CurrentPage)
+		})
+	}
+}
+
+func TestShortenUrls(t *testing.T) {
+	testLongUrl := "https://test.com"
+	testShortUrl := "short"
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		name            string
+		buildUrlService func() service.URLService
+		request         *url.ShortenUrlsRequest
+		metadata        metadata.MD
+		isErrExpected   bool
+		expectedCode    codes.Code
+		expectedResults []*url.ShortenUrlResult
+	}{
+		{
+			name: "shorten urls with per item errors. 0 OK",
+			buildUrlService: func() service.URLService {
+				mockService := mocks.NewURLService(t)
+				mockService.On("SaveURLs", mock.Anything, []domain.SaveURLParams{
+					{LongURL: testLongUrl, OwnerID: "owner"},
+					{LongURL: testLongUrl, Alias: "taken", OwnerID: "owner"},
+					{LongURL: testLongUrl, OwnerID: "owner"},
+				}).
+					Return([]domain.SaveURLResult{
+						{ShortURL: testShortUrl},
+						{Err: errs.ErrAliasAlreadyExists},
+						{Err: testErr},
+					})
+
+				return mockService
+			},
+			request: &url.ShortenUrlsRequest{
+				Urls: []*url.LongUrlRequest{
+					{LongUrl: testLongUrl},
+					{LongUrl: ""},
+					{LongUrl: testLongUrl, Alias: "taken"},
+					{LongUrl: testLongUrl, OwnerId: "ignored"},
+				},
+			},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, "owner"),
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+			expectedResults: []*url.ShortenUrlResult{
+				{Result: &url.ShortenUrlResult_Url{Url: &url.UrlDataResponse{LongUrl: testLongUrl, ShortUrl: testShortUrl}}},
+				{Result: &url.ShortenUrlResult_Error{Error: &url.ShortenUrlError{Code: int32(codes.InvalidArgument)}}},
+				{Result: &url.ShortenUrlResult_Error{Error: &url.ShortenUrlError{Code: int32(codes.AlreadyExists)}}},
+				{Result: &url.ShortenUrlResult_Error{Error: &url.ShortenUrlError{Code: int32(codes.Internal)}}},
+			},
+		},
+		{
+			name: "every url is invalid. 0 OK",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request: &url.ShortenUrlsRequest{
+				Urls: []*url.LongUrlRequest{{LongUrl: testLongUrl, Alias: "a"}},
+			},
+			isErrExpected: false,
+			expectedCode:  codes.OK,
+			expectedResults: []*url.ShortenUrlResult{
+				{Result: &url.ShortenUrlResult_Error{Error: &url.ShortenUrlError{Code: int32(codes.InvalidArgument)}}},
+			},
+		},
+		{
+			name: "empty batch. 3 InvalidArgument",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request:       &url.ShortenUrlsRequest{},
+			isErrExpected: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "owner differs from the forwarded caller. 7 PermissionDenied",
+			buildUrlService: func() service.URLService {
+				return mocks.NewURLService(t)
+			},
+			request: &url.ShortenUrlsRequest{
+				Urls:    []*url.LongUrlRequest{{LongUrl: testLongUrl}},
+				OwnerId: "other",
+			},
+			metadata:      metadata.Pairs(principal.OwnerIDMetadataKey, "owner"),
+			isErrExpected: true,
+			expectedCode:  codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := slog.New(
+				slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			)
+
+			urlClient, cancel := initUrlClient(logger, tc.buildUrlService())
+			defer cancel()
+
+			ctx := context.Background()
+			if tc.metadata != nil {
+				ctx = metadata.NewOutgoingContext(ctx, tc.metadata)
+			}
+
+			resp, err := urlClient.ShortenUrls(ctx, tc.request)
+			isErrorHappened := err != nil
+
+			assert.Equal(t, tc.isErrExpected, isErrorHappened)
+			if tc.isErrExpected {
+				st, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tc.expectedCode, st.Code())
+				return
+			}
+
+			assert.Len(t, resp.Results, len(tc.expectedResults))
+			for i, expectedResult := range tc.expectedResults {
+				if expectedURL := expectedResult.GetUrl(); expectedURL != nil {
+					assert.Equal(t, expectedURL.ShortUrl, resp.Results[i].GetUrl().GetShortUrl())
+					assert.Equal(t, expectedURL.LongUrl, resp.Results[i].GetUrl().GetLongUrl())
+					continue
+				}
+				assert.Equal(t, expectedResult.GetError().GetCode(), resp.Results[i].GetError().GetCode())
+				assert.NotEmpty(t, resp.Results[i].GetError().GetMessage())
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+ALTER TABLE "url_data"
+    ALTER COLUMN "short_url" TYPE VARCHAR(10);
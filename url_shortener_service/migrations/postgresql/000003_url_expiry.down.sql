@@ -0,0 +1,2 @@
+ALTER TABLE "url_data"
+    DROP COLUMN IF EXISTS "expires_at";
@@ -0,0 +1,2 @@
+ALTER TABLE "url_data"
+    ADD COLUMN "expires_at" TIMESTAMPTZ NULL;
@@ -0,0 +1,9 @@
+-- Short urls generated before the index may collide, a follow resolved to any of the rows.
+-- The earliest row of a short url is kept, ids break ties of creation dates
+DELETE
+FROM "url_data" AS "duplicate"
+    USING "url_data" AS "earliest"
+WHERE "duplicate"."short_url" = "earliest"."short_url"
+  AND ("duplicate"."created_at", "duplicate"."id") > ("earliest"."created_at", "earliest"."id");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "url_data_short_url_key" ON "url_data" ("short_url");
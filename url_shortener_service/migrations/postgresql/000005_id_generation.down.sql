@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "id_ranges";
+DROP SEQUENCE IF EXISTS "url_data_id_seq";
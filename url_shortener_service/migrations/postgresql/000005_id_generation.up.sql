@@ -0,0 +1,13 @@
+-- Ids generated from uuid fit into 32 bits, so sequence and leased ranges start above them.
+-- Snowflake ids are far larger than both.
+CREATE SEQUENCE IF NOT EXISTS "url_data_id_seq" START WITH 4294967296;
+
+CREATE TABLE IF NOT EXISTS "id_ranges"
+(
+    "name"    VARCHAR(64) NOT NULL PRIMARY KEY,
+    "next_id" BIGINT      NOT NULL
+);
+
+INSERT INTO "id_ranges" ("name", "next_id")
+VALUES ('url_data', 1099511627776)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE "url_data"
+    ADD COLUMN "is_active" BOOLEAN NOT NULL DEFAULT TRUE;
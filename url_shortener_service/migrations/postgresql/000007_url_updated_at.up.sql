@@ -0,0 +1,2 @@
+ALTER TABLE "url_data"
+    ADD COLUMN "updated_at" TIMESTAMPTZ NULL;
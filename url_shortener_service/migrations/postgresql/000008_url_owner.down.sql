@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "url_data_owner_id_idx";
+ALTER TABLE "url_data"
+    DROP COLUMN IF EXISTS "owner_id";
@@ -0,0 +1,3 @@
+ALTER TABLE "url_data"
+    ADD COLUMN "owner_id" VARCHAR(64) NULL;
+CREATE INDEX IF NOT EXISTS "url_data_owner_id_idx" ON "url_data" ("owner_id", "created_at" DESC, "id" DESC);
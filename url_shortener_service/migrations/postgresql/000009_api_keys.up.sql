@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "api_keys"
+(
+    "id"         BIGSERIAL PRIMARY KEY,
+    "owner_id"   VARCHAR(64)  NOT NULL,
+    "name"       VARCHAR(128) NOT NULL DEFAULT '',
+    "key_hash"   CHAR(64)     NOT NULL,
+    "created_at" TIMESTAMPTZ  NOT NULL,
+    "revoked_at" TIMESTAMPTZ  NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "api_keys_key_hash_key" ON "api_keys" ("key_hash");
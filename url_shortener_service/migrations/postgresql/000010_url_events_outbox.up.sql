@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "url_events_outbox"
+(
+    "id"         BIGSERIAL PRIMARY KEY,
+    "short_url"  VARCHAR(32) NOT NULL,
+    "long_url"   TEXT        NOT NULL,
+    "event_time" BIGINT      NOT NULL,
+    "event_type" SMALLINT    NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+    "sent_at"    TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS "url_events_outbox_unsent_idx" ON "url_events_outbox" ("id") WHERE "sent_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "url_events_outbox_sent_at_idx" ON "url_events_outbox" ("sent_at") WHERE "sent_at" IS NOT NULL;
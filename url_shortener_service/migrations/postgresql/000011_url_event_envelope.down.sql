@@ -0,0 +1,3 @@
+ALTER TABLE "url_events_outbox" DROP COLUMN IF EXISTS "event_time_ms";
+ALTER TABLE "url_events_outbox" DROP COLUMN IF EXISTS "context";
+ALTER TABLE "url_events_outbox" DROP COLUMN IF EXISTS "event_id";
@@ -0,0 +1,6 @@
+-- Replicas of the previous version keep writing and relaying events during a rolling upgrade,
+-- so existing columns keep their meaning and new ones have defaults.
+-- event_time stays in seconds, event_time_ms is set by newer replicas only
+ALTER TABLE "url_events_outbox" ADD COLUMN IF NOT EXISTS "event_id" UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE "url_events_outbox" ADD COLUMN IF NOT EXISTS "context" JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE "url_events_outbox" ADD COLUMN IF NOT EXISTS "event_time_ms" BIGINT NULL;
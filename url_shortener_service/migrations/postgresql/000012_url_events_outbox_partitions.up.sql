@@ -0,0 +1,10 @@
+-- Events are relayed by partitions of short urls, a partition is leased by one relay at a time,
+-- so events of a url are published in order. The number of rows is the number of partitions
+CREATE TABLE IF NOT EXISTS "url_events_outbox_partitions"
+(
+    "partition"     INT PRIMARY KEY,
+    "claimed_until" TIMESTAMPTZ NOT NULL DEFAULT '-infinity'
+);
+INSERT INTO "url_events_outbox_partitions" ("partition")
+SELECT generate_series(0, 15)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+-- Worker ids of snowflake generators leased by replicas, a row of an expired lease may be taken over
+CREATE TABLE IF NOT EXISTS "snowflake_workers"
+(
+    "worker_id"    INT PRIMARY KEY,
+    "owner"        VARCHAR(64) NOT NULL,
+    "leased_until" TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,16 @@
+package idgen
+
+import "context"
+
+// Names of id generation strategies that can be selected through config
+const (
+	StrategyUUID      = "uuid"
+	StrategySnowflake = "snowflake"
+	StrategySequence  = "sequence"
+	StrategyRange     = "range"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name IDGenerator
+type IDGenerator interface {
+	NextID(ctx context.Context) (uint64, error)
+}
@@ -0,0 +1,309 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnowflakeIDGenerator(t *testing.T) {
+	t.Run("worker id out of range", func(t *testing.T) {
+		_, err := NewSnowflakeIDGenerator(MaxWorkerID + 1)
+		assert.Error(t, err)
+
+		_, err = NewSnowflakeIDGenerator(-1)
+		assert.Error(t, err)
+	})
+
+	t.Run("ids are unique and increasing", func(t *testing.T) {
+		generator, err := NewSnowflakeIDGenerator(7)
+		assert.NoError(t, err)
+
+		var prevID uint64
+		for i := 0; i < 10000; i++ {
+			id, err := generator.NextID(context.Background())
+			assert.NoError(t, err)
+			assert.Greater(t, id, prevID)
+			prevID = id
+		}
+	})
+
+	t.Run("worker id is encoded into id", func(t *testing.T) {
+		generator, err := NewSnowflakeIDGenerator(7)
+		assert.NoError(t, err)
+
+		id, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), id>>sequenceBits&MaxWorkerID)
+	})
+
+	t.Run("clock moved backwards", func(t *testing.T) {
+		now := time.Now()
+		var slept time.Duration
+		generator := &snowflakeIDGenerator{
+			workerID: 1,
+			now:      func() time.Time { return now },
+			sleep: func(d time.Duration) {
+				slept += d
+				now = now.Add(d)
+			},
+		}
+
+		firstID, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+
+		// A small step back is waited out
+		now = now.Add(-3 * time.Millisecond)
+		secondID, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Greater(t, secondID, firstID)
+		assert.Equal(t, 3*time.Millisecond, slept)
+
+		now = now.Add(-time.Second)
+		_, err = generator.NextID(context.Background())
+		assert.ErrorIs(t, err, ErrClockMovedBackwards)
+		assert.Equal(t, 3*time.Millisecond, slept)
+
+		now = now.Add(time.Second + time.Millisecond)
+		thirdID, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Greater(t, thirdID, secondID)
+	})
+
+	t.Run("sequence is used up", func(t *testing.T) {
+		now := time.Now()
+		generator := &snowflakeIDGenerator{
+			workerID: 1,
+			now:      func() time.Time { return now },
+			sleep:    func(time.Duration) {},
+		}
+
+		var lastID uint64
+		for range maxSequence + 1 {
+			id, err := generator.NextID(context.Background())
+			assert.NoError(t, err)
+			lastID = id
+		}
+
+		// The clock is stuck, an id of the millisecond would repeat
+		_, err := generator.NextID(context.Background())
+		assert.ErrorIs(t, err, ErrClockMovedBackwards)
+
+		now = now.Add(time.Millisecond)
+		id, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Greater(t, id, lastID)
+	})
+
+	t.Run("concurrent calls", func(t *testing.T) {
+		generator, err := NewSnowflakeIDGenerator(1)
+		assert.NoError(t, err)
+
+		const goroutines = 8
+		const idsPerGoroutine = 1000
+
+		var mu sync.Mutex
+		ids := make(map[uint64]struct{}, goroutines*idsPerGoroutine)
+
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < idsPerGoroutine; j++ {
+					id, err := generator.NextID(context.Background())
+					assert.NoError(t, err)
+
+					mu.Lock()
+					ids[id] = struct{}{}
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, ids, goroutines*idsPerGoroutine)
+	})
+}
+
+type fakeRangeLeaser struct {
+	next  uint64
+	calls int
+	err   error
+}
+
+func (l *fakeRangeLeaser) LeaseRange(_ context.Context, size uint64) (uint64, error) {
+	l.calls++
+	if l.err != nil {
+		return 0, l.err
+	}
+
+	start := l.next
+	l.next += size
+	return start, nil
+}
+
+func TestRangeIDGenerator(t *testing.T) {
+	t.Run("non positive range size", func(t *testing.T) {
+		_, err := NewRangeIDGenerator(&fakeRangeLeaser{}, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("leases next range when current is used up", func(t *testing.T) {
+		leaser := &fakeRangeLeaser{next: 100}
+		generator, err := NewRangeIDGenerator(leaser, 3)
+		assert.NoError(t, err)
+
+		ids := make([]uint64, 0)
+		for i := 0; i < 7; i++ {
+			id, err := generator.NextID(context.Background())
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		assert.Equal(t, []uint64{100, 101, 102, 103, 104, 105, 106}, ids)
+		assert.Equal(t, 3, leaser.calls)
+	})
+
+	t.Run("lease error", func(t *testing.T) {
+		leaseErr := errors.New("lease error")
+		generator, err := NewRangeIDGenerator(&fakeRangeLeaser{err: leaseErr}, 10)
+		assert.NoError(t, err)
+
+		_, err = generator.NextID(context.Background())
+		assert.Equal(t, leaseErr, err)
+	})
+}
+
+type fakeWorkerLeaser struct {
+	mu         sync.Mutex
+	workerIDs  []int64
+	leased     []int64
+	renewErr   error
+	renewCalls int
+	released   []int64
+}
+
+func (l *fakeWorkerLeaser) LeaseWorkerID(_ context.Context, _ string, _ time.Duration) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.workerIDs) == 0 {
+		return 0, errors.New("no free worker id")
+	}
+	workerID := l.workerIDs[0]
+	l.workerIDs = l.workerIDs[1:]
+	l.leased = append(l.leased, workerID)
+	return workerID, nil
+}
+
+func (l *fakeWorkerLeaser) RenewWorkerID(_ context.Context, _ int64, _ string, _ time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.renewCalls++
+	return l.renewErr
+}
+
+func (l *fakeWorkerLeaser) ReleaseWorkerID(_ context.Context, workerID int64, _ string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.released = append(l.released, workerID)
+	return nil
+}
+
+func TestLeasedSnowflakeIDGenerator(t *testing.T) {
+	newGenerator := func(t *testing.T, leaser *fakeWorkerLeaser, now *time.Time) *leasedSnowflakeIDGenerator {
+		doneCh := make(chan struct{})
+		var wg sync.WaitGroup
+		t.Cleanup(func() {
+			close(doneCh)
+			wg.Wait()
+		})
+
+		generator, err := NewLeasedSnowflakeIDGenerator(context.Background(), leaser, time.Minute, doneCh, &wg)
+		assert.NoError(t, err)
+		g := generator.(*leasedSnowflakeIDGenerator)
+		g.now = func() time.Time { return *now }
+		g.validUntil = now.Add(time.Minute)
+		return g
+	}
+	workerID := func(id uint64) uint64 {
+		return id >> sequenceBits & MaxWorkerID
+	}
+
+	t.Run("no free worker id", func(t *testing.T) {
+		_, err := NewLeasedSnowflakeIDGenerator(context.Background(), &fakeWorkerLeaser{}, time.Minute, nil, &sync.WaitGroup{})
+		assert.Error(t, err)
+	})
+
+	t.Run("lease is renewed after half of ttl", func(t *testing.T) {
+		now := time.Now()
+		leaser := &fakeWorkerLeaser{workerIDs: []int64{5}}
+		generator := newGenerator(t, leaser, &now)
+
+		id, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), workerID(id))
+		assert.Equal(t, 0, leaser.renewCalls)
+
+		now = now.Add(31 * time.Second)
+		_, err = generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, leaser.renewCalls)
+		assert.Equal(t, now.Add(time.Minute), generator.validUntil)
+	})
+
+	t.Run("failed renewal is retried while lease is valid", func(t *testing.T) {
+		now := time.Now()
+		leaser := &fakeWorkerLeaser{workerIDs: []int64{5, 6}, renewErr: errors.New("db is unavailable")}
+		generator := newGenerator(t, leaser, &now)
+
+		now = now.Add(15 * time.Second)
+		for range 2 {
+			now = now.Add(15 * time.Second)
+			id, err := generator.NextID(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(5), workerID(id))
+		}
+
+		// The lease has expired, a new one is taken
+		now = now.Add(31 * time.Second)
+		id, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(6), workerID(id))
+		assert.Equal(t, 2, leaser.renewCalls)
+	})
+
+	t.Run("lost lease is taken again", func(t *testing.T) {
+		now := time.Now()
+		leaser := &fakeWorkerLeaser{workerIDs: []int64{5, 6}, renewErr: ErrWorkerLeaseLost}
+		generator := newGenerator(t, leaser, &now)
+
+		now = now.Add(31 * time.Second)
+		id, err := generator.NextID(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(6), workerID(id))
+		assert.Equal(t, []int64{5, 6}, leaser.leased)
+	})
+
+	t.Run("no ids after release", func(t *testing.T) {
+		leaser := &fakeWorkerLeaser{workerIDs: []int64{5}}
+		doneCh := make(chan struct{})
+		var wg sync.WaitGroup
+		generator, err := NewLeasedSnowflakeIDGenerator(context.Background(), leaser, time.Minute, doneCh, &wg)
+		assert.NoError(t, err)
+
+		close(doneCh)
+		wg.Wait()
+		assert.Equal(t, []int64{5}, leaser.released)
+
+		_, err = generator.NextID(context.Background())
+		assert.ErrorIs(t, err, ErrWorkerLeaseReleased)
+	})
+}
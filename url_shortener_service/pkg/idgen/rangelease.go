@@ -0,0 +1,53 @@
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type RangeLeaser interface {
+	// LeaseRange reserves size ids and returns the first of them.
+	// Leased ranges never overlap.
+	LeaseRange(ctx context.Context, size uint64) (uint64, error)
+}
+
+// rangeIDGenerator hands out ids from a block leased from RangeLeaser and
+// leases the next block once the current one is used up. Ids left in the
+// block on shutdown are lost.
+type rangeIDGenerator struct {
+	mu     sync.Mutex
+	leaser RangeLeaser
+	size   uint64
+	next   uint64
+	end    uint64
+}
+
+func NewRangeIDGenerator(leaser RangeLeaser, size int64) (IDGenerator, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("id range size must be positive, got %d", size)
+	}
+
+	return &rangeIDGenerator{
+		leaser: leaser,
+		size:   uint64(size),
+	}, nil
+}
+
+func (g *rangeIDGenerator) NextID(ctx context.Context) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.next >= g.end {
+		start, err := g.leaser.LeaseRange(ctx, g.size)
+		if err != nil {
+			return 0, err
+		}
+		g.next = start
+		g.end = start + g.size
+	}
+
+	id := g.next
+	g.next++
+	return id, nil
+}
@@ -0,0 +1,102 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	workerIDBits = 10
+	sequenceBits = 12
+
+	MaxWorkerID = 1<<workerIDBits - 1
+	maxSequence = 1<<sequenceBits - 1
+
+	// maxClockBackwards is the largest step back of the clock that is waited out,
+	// no ids are issued during larger ones
+	maxClockBackwards = 5 * time.Millisecond
+)
+
+var ErrClockMovedBackwards = errors.New("clock moved backwards")
+
+// snowflakeEpoch is the start of the timestamp part of snowflake ids
+var snowflakeEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// snowflakeIDGenerator builds ids from milliseconds since snowflakeEpoch,
+// worker id and a per-millisecond sequence. Ids are unique as long as every
+// replica has its own worker id.
+type snowflakeIDGenerator struct {
+	mu         sync.Mutex
+	workerID   uint64
+	lastMillis int64
+	sequence   uint64
+	now        func() time.Time
+	sleep      func(time.Duration)
+}
+
+func NewSnowflakeIDGenerator(workerID int64) (IDGenerator, error) {
+	if workerID < 0 || workerID > MaxWorkerID {
+		return nil, fmt.Errorf("snowflake worker id must be in range [0, %d], got %d", MaxWorkerID, workerID)
+	}
+
+	return &snowflakeIDGenerator{
+		workerID: uint64(workerID),
+		now:      time.Now,
+		sleep:    time.Sleep,
+	}, nil
+}
+
+func (g *snowflakeIDGenerator) NextID(_ context.Context) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	millis := g.currentMillis()
+	if millis < g.lastMillis {
+		millis = g.waitUntil(g.lastMillis)
+		if millis < g.lastMillis {
+			return 0, g.clockError(millis)
+		}
+	}
+
+	// Sequence of the millisecond is used up
+	if millis == g.lastMillis && g.sequence == maxSequence {
+		millis = g.waitUntil(g.lastMillis + 1)
+		if millis <= g.lastMillis {
+			return 0, g.clockError(millis)
+		}
+	}
+
+	if millis == g.lastMillis {
+		g.sequence++
+	} else {
+		g.sequence = 0
+	}
+	g.lastMillis = millis
+
+	id := uint64(millis)<<(workerIDBits+sequenceBits) | g.workerID<<sequenceBits | g.sequence
+	return id, nil
+}
+
+// waitUntil sleeps once until the clock reaches millis when it is at most maxClockBackwards behind
+// and returns the current millisecond
+func (g *snowflakeIDGenerator) waitUntil(millis int64) int64 {
+	current := g.currentMillis()
+	behind := time.Duration(millis-current) * time.Millisecond
+	if behind > 0 && behind <= maxClockBackwards {
+		g.sleep(behind)
+		current = g.currentMillis()
+	}
+
+	return current
+}
+
+func (g *snowflakeIDGenerator) clockError(millis int64) error {
+	return fmt.Errorf("%w: clock is at %dms, last id was issued at %dms", ErrClockMovedBackwards, millis, g.lastMillis)
+}
+
+func (g *snowflakeIDGenerator) currentMillis() int64 {
+	return g.now().Sub(snowflakeEpoch).Milliseconds()
+}
@@ -0,0 +1,20 @@
+package idgen
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// uuidIDGenerator takes 32 bits of a random uuid.
+// Ids are not unique, so callers must handle collisions.
+type uuidIDGenerator struct {
+}
+
+func NewUUIDIDGenerator() IDGenerator {
+	return &uuidIDGenerator{}
+}
+
+func (g *uuidIDGenerator) NextID(_ context.Context) (uint64, error) {
+	return uint64(uuid.New().ID()), nil
+}
@@ -0,0 +1,148 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrWorkerLeaseLost is returned by WorkerLeaser when the worker id was taken by another owner
+	ErrWorkerLeaseLost = errors.New("snowflake worker id lease is lost")
+	// ErrWorkerLeaseReleased is returned by ids requested after the shutdown
+	ErrWorkerLeaseReleased = errors.New("snowflake worker id lease is released")
+)
+
+// WorkerLeaser hands out worker ids of snowflake generators, a worker id is leased by one owner at a time
+type WorkerLeaser interface {
+	// LeaseWorkerID takes a worker id that is not leased or whose lease has expired
+	LeaseWorkerID(ctx context.Context, owner string, ttl time.Duration) (int64, error)
+	// RenewWorkerID extends the lease of owner or returns ErrWorkerLeaseLost
+	RenewWorkerID(ctx context.Context, workerID int64, owner string, ttl time.Duration) error
+	ReleaseWorkerID(ctx context.Context, workerID int64, owner string) error
+}
+
+// leasedSnowflakeIDGenerator is a snowflake generator whose worker id is leased,
+// so replicas do not need to be configured one by one. The lease is renewed by NextID
+// once half of it has passed, ids are not issued with a lease that has expired.
+type leasedSnowflakeIDGenerator struct {
+	leaser WorkerLeaser
+	owner  string
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu         sync.Mutex
+	generator  *snowflakeIDGenerator
+	workerID   int64
+	validUntil time.Time
+	released   bool
+}
+
+// NewLeasedSnowflakeIDGenerator leases a worker id and releases it when doneCh fires,
+// wg is done once it is released, so the storage of leaser must stay open until then
+func NewLeasedSnowflakeIDGenerator(
+	ctx context.Context,
+	leaser WorkerLeaser,
+	ttl time.Duration,
+	doneCh <-chan struct{},
+	wg *sync.WaitGroup,
+) (IDGenerator, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("snowflake worker id lease ttl must be positive, got %s", ttl)
+	}
+
+	g := &leasedSnowflakeIDGenerator{
+		leaser: leaser,
+		owner:  uuid.NewString(),
+		ttl:    ttl,
+		now:    time.Now,
+		generator: &snowflakeIDGenerator{
+			now:   time.Now,
+			sleep: time.Sleep,
+		},
+	}
+	err := g.lease(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-doneCh
+		g.release()
+	}()
+
+	return g, nil
+}
+
+func (g *leasedSnowflakeIDGenerator) NextID(ctx context.Context) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.released {
+		return 0, ErrWorkerLeaseReleased
+	}
+
+	err := g.ensureLease(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return g.generator.NextID(ctx)
+}
+
+// ensureLease renews the lease once half of it has passed. A lease that was lost
+// or could not be renewed in time is taken again, possibly with another worker id
+func (g *leasedSnowflakeIDGenerator) ensureLease(ctx context.Context) error {
+	now := g.now()
+	if now.Before(g.validUntil.Add(-g.ttl / 2)) {
+		return nil
+	}
+
+	if now.Before(g.validUntil) {
+		err := g.leaser.RenewWorkerID(ctx, g.workerID, g.owner, g.ttl)
+		if err == nil {
+			g.validUntil = now.Add(g.ttl)
+			return nil
+		}
+		// The lease is still valid, renewal is retried by the next call
+		if !errors.Is(err, ErrWorkerLeaseLost) {
+			return nil
+		}
+	}
+
+	return g.lease(ctx)
+}
+
+// lease takes a worker id, the lease is counted from the moment before the request,
+// so it ends here no later than in the storage
+func (g *leasedSnowflakeIDGenerator) lease(ctx context.Context) error {
+	now := g.now()
+	workerID, err := g.leaser.LeaseWorkerID(ctx, g.owner, g.ttl)
+	if err != nil {
+		return fmt.Errorf("lease snowflake worker id: %w", err)
+	}
+	if workerID < 0 || workerID > MaxWorkerID {
+		return fmt.Errorf("snowflake worker id must be in range [0, %d], got %d", MaxWorkerID, workerID)
+	}
+
+	g.workerID = workerID
+	g.generator.workerID = uint64(workerID)
+	g.validUntil = now.Add(g.ttl)
+	return nil
+}
+
+// release frees the worker id for a replica that starts next, the lease
+// expires anyway when it can not be released
+func (g *leasedSnowflakeIDGenerator) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.released = true
+	_ = g.leaser.ReleaseWorkerID(context.Background(), g.workerID, g.owner)
+}
@@ -0,0 +1,631 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: apikey.proto
+
+package url
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on IssueApiKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *IssueApiKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IssueApiKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// IssueApiKeyRequestMultiError, or nil if none found.
+func (m *IssueApiKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IssueApiKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetOwnerId()); l < 1 || l > 64 {
+		err := IssueApiKeyRequestValidationError{
+			field:  "OwnerId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetName()) > 128 {
+		err := IssueApiKeyRequestValidationError{
+			field:  "Name",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return IssueApiKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// IssueApiKeyRequestMultiError is an error wrapping multiple validation errors
+// returned by IssueApiKeyRequest.ValidateAll() if the designated constraints
+// aren't met.
+type IssueApiKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IssueApiKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IssueApiKeyRequestMultiError) AllErrors() []error { return m }
+
+// IssueApiKeyRequestValidationError is the validation error returned by
+// IssueApiKeyRequest.Validate if the designated constraints aren't met.
+type IssueApiKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IssueApiKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IssueApiKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IssueApiKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IssueApiKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IssueApiKeyRequestValidationError) ErrorName() string {
+	return "IssueApiKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e IssueApiKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIssueApiKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IssueApiKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IssueApiKeyRequestValidationError{}
+
+// Validate checks the field values on IssueApiKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *IssueApiKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IssueApiKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// IssueApiKeyResponseMultiError, or nil if none found.
+func (m *IssueApiKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IssueApiKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Key
+
+	// no validation rules for OwnerId
+
+	// no validation rules for Name
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, IssueApiKeyResponseValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, IssueApiKeyResponseValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return IssueApiKeyResponseValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return IssueApiKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// IssueApiKeyResponseMultiError is an error wrapping multiple validation
+// errors returned by IssueApiKeyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type IssueApiKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IssueApiKeyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IssueApiKeyResponseMultiError) AllErrors() []error { return m }
+
+// IssueApiKeyResponseValidationError is the validation error returned by
+// IssueApiKeyResponse.Validate if the designated constraints aren't met.
+type IssueApiKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IssueApiKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IssueApiKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IssueApiKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IssueApiKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IssueApiKeyResponseValidationError) ErrorName() string {
+	return "IssueApiKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e IssueApiKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIssueApiKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IssueApiKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IssueApiKeyResponseValidationError{}
+
+// Validate checks the field values on RevokeApiKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeApiKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeApiKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeApiKeyRequestMultiError, or nil if none found.
+func (m *RevokeApiKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeApiKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() <= 0 {
+		err := RevokeApiKeyRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeApiKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeApiKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by RevokeApiKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeApiKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeApiKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeApiKeyRequestMultiError) AllErrors() []error { return m }
+
+// RevokeApiKeyRequestValidationError is the validation error returned by
+// RevokeApiKeyRequest.Validate if the designated constraints aren't met.
+type RevokeApiKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeApiKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeApiKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeApiKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeApiKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeApiKeyRequestValidationError) ErrorName() string {
+	return "RevokeApiKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeApiKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeApiKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeApiKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeApiKeyRequestValidationError{}
+
+// Validate checks the field values on VerifyApiKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyApiKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyApiKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyApiKeyRequestMultiError, or nil if none found.
+func (m *VerifyApiKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyApiKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetKey()) < 1 {
+		err := VerifyApiKeyRequestValidationError{
+			field:  "Key",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VerifyApiKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyApiKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by VerifyApiKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyApiKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyApiKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyApiKeyRequestMultiError) AllErrors() []error { return m }
+
+// VerifyApiKeyRequestValidationError is the validation error returned by
+// VerifyApiKeyRequest.Validate if the designated constraints aren't met.
+type VerifyApiKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyApiKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyApiKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyApiKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyApiKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyApiKeyRequestValidationError) ErrorName() string {
+	return "VerifyApiKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyApiKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyApiKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyApiKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyApiKeyRequestValidationError{}
+
+// Validate checks the field values on VerifyApiKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyApiKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyApiKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyApiKeyResponseMultiError, or nil if none found.
+func (m *VerifyApiKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyApiKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for OwnerId
+
+	if len(errors) > 0 {
+		return VerifyApiKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyApiKeyResponseMultiError is an error wrapping multiple validation
+// errors returned by VerifyApiKeyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyApiKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyApiKeyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyApiKeyResponseMultiError) AllErrors() []error { return m }
+
+// VerifyApiKeyResponseValidationError is the validation error returned by
+// VerifyApiKeyResponse.Validate if the designated constraints aren't met.
+type VerifyApiKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyApiKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyApiKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyApiKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyApiKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyApiKeyResponseValidationError) ErrorName() string {
+	return "VerifyApiKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyApiKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyApiKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyApiKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyApiKeyResponseValidationError{}
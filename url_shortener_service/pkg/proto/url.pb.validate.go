@@ -68,6 +68,92 @@ func (m *LongUrlRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if m.GetAlias() != "" {
+
+		if !_LongUrlRequest_Alias_Pattern.MatchString(m.GetAlias()) {
+			err := LongUrlRequestValidationError{
+				field:  "Alias",
+				reason: "value does not match regex pattern \"^[a-zA-Z0-9_-]{3,32}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for OwnerId
+
+	switch v := m.Expiry.(type) {
+	case *LongUrlRequest_ExpiresIn:
+		if v == nil {
+			err := LongUrlRequestValidationError{
+				field:  "Expiry",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if m.GetExpiresIn() <= 0 {
+			err := LongUrlRequestValidationError{
+				field:  "ExpiresIn",
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *LongUrlRequest_ExpiresAt:
+		if v == nil {
+			err := LongUrlRequestValidationError{
+				field:  "Expiry",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetExpiresAt()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LongUrlRequestValidationError{
+						field:  "ExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LongUrlRequestValidationError{
+						field:  "ExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LongUrlRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
 	if len(errors) > 0 {
 		return LongUrlRequestMultiError(errors)
 	}
@@ -146,6 +232,8 @@ var _ interface {
 	ErrorName() string
 } = LongUrlRequestValidationError{}
 
+var _LongUrlRequest_Alias_Pattern = regexp.MustCompile("^[a-zA-Z0-9_-]{3,32}$")
+
 // Validate checks the field values on UrlDataResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -172,6 +260,35 @@ func (m *UrlDataResponse) validate(all bool) error {
 
 	// no validation rules for ShortUrl
 
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UrlDataResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UrlDataResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UrlDataResponseValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return UrlDataResponseMultiError(errors)
 	}
@@ -250,32 +367,32 @@ var _ interface {
 	ErrorName() string
 } = UrlDataResponseValidationError{}
 
-// Validate checks the field values on ShortUrlRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *ShortUrlRequest) Validate() error {
+// Validate checks the field values on ShortenUrlsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ShortenUrlsRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ShortUrlRequest with the rules
+// ValidateAll checks the field values on ShortenUrlsRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ShortUrlRequestMultiError, or nil if none found.
-func (m *ShortUrlRequest) ValidateAll() error {
+// ShortenUrlsRequestMultiError, or nil if none found.
+func (m *ShortenUrlsRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ShortUrlRequest) validate(all bool) error {
+func (m *ShortenUrlsRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if utf8.RuneCountInString(m.GetShortUrl()) < 1 {
-		err := ShortUrlRequestValidationError{
-			field:  "ShortUrl",
-			reason: "value length must be at least 1 runes",
+	if l := len(m.GetUrls()); l < 1 || l > 1000 {
+		err := ShortenUrlsRequestValidationError{
+			field:  "Urls",
+			reason: "value must contain between 1 and 1000 items, inclusive",
 		}
 		if !all {
 			return err
@@ -283,20 +400,29 @@ func (m *ShortUrlRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	for idx, item := range m.GetUrls() {
+		_, _ = idx, item
+
+		// skipping validation for urls
+
+	}
+
+	// no validation rules for OwnerId
+
 	if len(errors) > 0 {
-		return ShortUrlRequestMultiError(errors)
+		return ShortenUrlsRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ShortUrlRequestMultiError is an error wrapping multiple validation errors
-// returned by ShortUrlRequest.ValidateAll() if the designated constraints
+// ShortenUrlsRequestMultiError is an error wrapping multiple validation errors
+// returned by ShortenUrlsRequest.ValidateAll() if the designated constraints
 // aren't met.
-type ShortUrlRequestMultiError []error
+type ShortenUrlsRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ShortUrlRequestMultiError) Error() string {
+func (m ShortenUrlsRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -305,11 +431,11 @@ func (m ShortUrlRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ShortUrlRequestMultiError) AllErrors() []error { return m }
+func (m ShortenUrlsRequestMultiError) AllErrors() []error { return m }
 
-// ShortUrlRequestValidationError is the validation error returned by
-// ShortUrlRequest.Validate if the designated constraints aren't met.
-type ShortUrlRequestValidationError struct {
+// ShortenUrlsRequestValidationError is the validation error returned by
+// ShortenUrlsRequest.Validate if the designated constraints aren't met.
+type ShortenUrlsRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -317,22 +443,24 @@ type ShortUrlRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ShortUrlRequestValidationError) Field() string { return e.field }
+func (e ShortenUrlsRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ShortUrlRequestValidationError) Reason() string { return e.reason }
+func (e ShortenUrlsRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ShortUrlRequestValidationError) Cause() error { return e.cause }
+func (e ShortenUrlsRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ShortUrlRequestValidationError) Key() bool { return e.key }
+func (e ShortenUrlsRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ShortUrlRequestValidationError) ErrorName() string { return "ShortUrlRequestValidationError" }
+func (e ShortenUrlsRequestValidationError) ErrorName() string {
+	return "ShortenUrlsRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ShortUrlRequestValidationError) Error() string {
+func (e ShortenUrlsRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -344,14 +472,14 @@ func (e ShortUrlRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sShortUrlRequest.%s: %s%s",
+		"invalid %sShortenUrlsRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ShortUrlRequestValidationError{}
+var _ error = ShortenUrlsRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -359,46 +487,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ShortUrlRequestValidationError{}
+} = ShortenUrlsRequestValidationError{}
 
-// Validate checks the field values on LongUrlResponse with the rules defined
+// Validate checks the field values on ShortenUrlError with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *LongUrlResponse) Validate() error {
+func (m *ShortenUrlError) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LongUrlResponse with the rules
+// ValidateAll checks the field values on ShortenUrlError with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// LongUrlResponseMultiError, or nil if none found.
-func (m *LongUrlResponse) ValidateAll() error {
+// ShortenUrlErrorMultiError, or nil if none found.
+func (m *ShortenUrlError) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LongUrlResponse) validate(all bool) error {
+func (m *ShortenUrlError) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for LongUrl
+	// no validation rules for Code
+
+	// no validation rules for Message
 
 	if len(errors) > 0 {
-		return LongUrlResponseMultiError(errors)
+		return ShortenUrlErrorMultiError(errors)
 	}
 
 	return nil
 }
 
-// LongUrlResponseMultiError is an error wrapping multiple validation errors
-// returned by LongUrlResponse.ValidateAll() if the designated constraints
+// ShortenUrlErrorMultiError is an error wrapping multiple validation errors
+// returned by ShortenUrlError.ValidateAll() if the designated constraints
 // aren't met.
-type LongUrlResponseMultiError []error
+type ShortenUrlErrorMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LongUrlResponseMultiError) Error() string {
+func (m ShortenUrlErrorMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -407,11 +537,11 @@ func (m LongUrlResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LongUrlResponseMultiError) AllErrors() []error { return m }
+func (m ShortenUrlErrorMultiError) AllErrors() []error { return m }
 
-// LongUrlResponseValidationError is the validation error returned by
-// LongUrlResponse.Validate if the designated constraints aren't met.
-type LongUrlResponseValidationError struct {
+// ShortenUrlErrorValidationError is the validation error returned by
+// ShortenUrlError.Validate if the designated constraints aren't met.
+type ShortenUrlErrorValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -419,22 +549,22 @@ type LongUrlResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e LongUrlResponseValidationError) Field() string { return e.field }
+func (e ShortenUrlErrorValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LongUrlResponseValidationError) Reason() string { return e.reason }
+func (e ShortenUrlErrorValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LongUrlResponseValidationError) Cause() error { return e.cause }
+func (e ShortenUrlErrorValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LongUrlResponseValidationError) Key() bool { return e.key }
+func (e ShortenUrlErrorValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LongUrlResponseValidationError) ErrorName() string { return "LongUrlResponseValidationError" }
+func (e ShortenUrlErrorValidationError) ErrorName() string { return "ShortenUrlErrorValidationError" }
 
 // Error satisfies the builtin error interface
-func (e LongUrlResponseValidationError) Error() string {
+func (e ShortenUrlErrorValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -446,14 +576,14 @@ func (e LongUrlResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLongUrlResponse.%s: %s%s",
+		"invalid %sShortenUrlError.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LongUrlResponseValidationError{}
+var _ error = ShortenUrlErrorValidationError{}
 
 var _ interface {
 	Field() string
@@ -461,4 +591,1597 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LongUrlResponseValidationError{}
+} = ShortenUrlErrorValidationError{}
+
+// Validate checks the field values on ShortenUrlResult with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ShortenUrlResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ShortenUrlResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ShortenUrlResultMultiError, or nil if none found.
+func (m *ShortenUrlResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ShortenUrlResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	switch v := m.Result.(type) {
+	case *ShortenUrlResult_Url:
+		if v == nil {
+			err := ShortenUrlResultValidationError{
+				field:  "Result",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetUrl()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ShortenUrlResultValidationError{
+						field:  "Url",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ShortenUrlResultValidationError{
+						field:  "Url",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetUrl()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ShortenUrlResultValidationError{
+					field:  "Url",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ShortenUrlResult_Error:
+		if v == nil {
+			err := ShortenUrlResultValidationError{
+				field:  "Result",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetError()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ShortenUrlResultValidationError{
+						field:  "Error",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ShortenUrlResultValidationError{
+						field:  "Error",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetError()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ShortenUrlResultValidationError{
+					field:  "Error",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return ShortenUrlResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// ShortenUrlResultMultiError is an error wrapping multiple validation errors
+// returned by ShortenUrlResult.ValidateAll() if the designated constraints
+// aren't met.
+type ShortenUrlResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ShortenUrlResultMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ShortenUrlResultMultiError) AllErrors() []error { return m }
+
+// ShortenUrlResultValidationError is the validation error returned by
+// ShortenUrlResult.Validate if the designated constraints aren't met.
+type ShortenUrlResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ShortenUrlResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ShortenUrlResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ShortenUrlResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ShortenUrlResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ShortenUrlResultValidationError) ErrorName() string { return "ShortenUrlResultValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ShortenUrlResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sShortenUrlResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ShortenUrlResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ShortenUrlResultValidationError{}
+
+// Validate checks the field values on ShortenUrlsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ShortenUrlsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ShortenUrlsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ShortenUrlsResponseMultiError, or nil if none found.
+func (m *ShortenUrlsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ShortenUrlsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ShortenUrlsResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ShortenUrlsResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ShortenUrlsResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ShortenUrlsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ShortenUrlsResponseMultiError is an error wrapping multiple validation
+// errors returned by ShortenUrlsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ShortenUrlsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ShortenUrlsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ShortenUrlsResponseMultiError) AllErrors() []error { return m }
+
+// ShortenUrlsResponseValidationError is the validation error returned by
+// ShortenUrlsResponse.Validate if the designated constraints aren't met.
+type ShortenUrlsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ShortenUrlsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ShortenUrlsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ShortenUrlsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ShortenUrlsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ShortenUrlsResponseValidationError) ErrorName() string {
+	return "ShortenUrlsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ShortenUrlsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sShortenUrlsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ShortenUrlsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ShortenUrlsResponseValidationError{}
+
+// Validate checks the field values on ShortUrlRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ShortUrlRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ShortUrlRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ShortUrlRequestMultiError, or nil if none found.
+func (m *ShortUrlRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ShortUrlRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetShortUrl()) < 1 {
+		err := ShortUrlRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetClient()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ShortUrlRequestValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ShortUrlRequestValidationError{
+					field:  "Client",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClient()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ShortUrlRequestValidationError{
+				field:  "Client",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ShortUrlRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ShortUrlRequestMultiError is an error wrapping multiple validation errors
+// returned by ShortUrlRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ShortUrlRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ShortUrlRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ShortUrlRequestMultiError) AllErrors() []error { return m }
+
+// ShortUrlRequestValidationError is the validation error returned by
+// ShortUrlRequest.Validate if the designated constraints aren't met.
+type ShortUrlRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ShortUrlRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ShortUrlRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ShortUrlRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ShortUrlRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ShortUrlRequestValidationError) ErrorName() string { return "ShortUrlRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ShortUrlRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sShortUrlRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ShortUrlRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ShortUrlRequestValidationError{}
+
+// Validate checks the field values on ClientInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ClientInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ClientInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ClientInfoMultiError, or
+// nil if none found.
+func (m *ClientInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ClientInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Referrer
+
+	// no validation rules for UserAgent
+
+	// no validation rules for AcceptLanguage
+
+	// no validation rules for Ip
+
+	if len(errors) > 0 {
+		return ClientInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// ClientInfoMultiError is an error wrapping multiple validation errors
+// returned by ClientInfo.ValidateAll() if the designated constraints aren't met.
+type ClientInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ClientInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ClientInfoMultiError) AllErrors() []error { return m }
+
+// ClientInfoValidationError is the validation error returned by
+// ClientInfo.Validate if the designated constraints aren't met.
+type ClientInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ClientInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ClientInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ClientInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ClientInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ClientInfoValidationError) ErrorName() string { return "ClientInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ClientInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sClientInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ClientInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ClientInfoValidationError{}
+
+// Validate checks the field values on LongUrlResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *LongUrlResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LongUrlResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LongUrlResponseMultiError, or nil if none found.
+func (m *LongUrlResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LongUrlResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for LongUrl
+
+	if len(errors) > 0 {
+		return LongUrlResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// LongUrlResponseMultiError is an error wrapping multiple validation errors
+// returned by LongUrlResponse.ValidateAll() if the designated constraints
+// aren't met.
+type LongUrlResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LongUrlResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LongUrlResponseMultiError) AllErrors() []error { return m }
+
+// LongUrlResponseValidationError is the validation error returned by
+// LongUrlResponse.Validate if the designated constraints aren't met.
+type LongUrlResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LongUrlResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LongUrlResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LongUrlResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LongUrlResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LongUrlResponseValidationError) ErrorName() string { return "LongUrlResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LongUrlResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLongUrlResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LongUrlResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LongUrlResponseValidationError{}
+
+// Validate checks the field values on SetUrlActiveRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetUrlActiveRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetUrlActiveRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetUrlActiveRequestMultiError, or nil if none found.
+func (m *SetUrlActiveRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetUrlActiveRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetShortUrl()) < 1 {
+		err := SetUrlActiveRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Active
+
+	// no validation rules for OwnerId
+
+	if len(errors) > 0 {
+		return SetUrlActiveRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetUrlActiveRequestMultiError is an error wrapping multiple validation
+// errors returned by SetUrlActiveRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SetUrlActiveRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetUrlActiveRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetUrlActiveRequestMultiError) AllErrors() []error { return m }
+
+// SetUrlActiveRequestValidationError is the validation error returned by
+// SetUrlActiveRequest.Validate if the designated constraints aren't met.
+type SetUrlActiveRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetUrlActiveRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetUrlActiveRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetUrlActiveRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetUrlActiveRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetUrlActiveRequestValidationError) ErrorName() string {
+	return "SetUrlActiveRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetUrlActiveRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetUrlActiveRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetUrlActiveRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetUrlActiveRequestValidationError{}
+
+// Validate checks the field values on UpdateUrlRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUrlRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUrlRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUrlRequestMultiError, or nil if none found.
+func (m *UpdateUrlRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUrlRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetShortUrl()) < 1 {
+		err := UpdateUrlRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetLongUrl()) < 1 {
+		err := UpdateUrlRequestValidationError{
+			field:  "LongUrl",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for OwnerId
+
+	if len(errors) > 0 {
+		return UpdateUrlRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUrlRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateUrlRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateUrlRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUrlRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUrlRequestMultiError) AllErrors() []error { return m }
+
+// UpdateUrlRequestValidationError is the validation error returned by
+// UpdateUrlRequest.Validate if the designated constraints aren't met.
+type UpdateUrlRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUrlRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUrlRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUrlRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUrlRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUrlRequestValidationError) ErrorName() string { return "UpdateUrlRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UpdateUrlRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUrlRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUrlRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUrlRequestValidationError{}
+
+// Validate checks the field values on DeleteUrlRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteUrlRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteUrlRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteUrlRequestMultiError, or nil if none found.
+func (m *DeleteUrlRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteUrlRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetShortUrl()) < 1 {
+		err := DeleteUrlRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for OwnerId
+
+	if len(errors) > 0 {
+		return DeleteUrlRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteUrlRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteUrlRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteUrlRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteUrlRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteUrlRequestMultiError) AllErrors() []error { return m }
+
+// DeleteUrlRequestValidationError is the validation error returned by
+// DeleteUrlRequest.Validate if the designated constraints aren't met.
+type DeleteUrlRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteUrlRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteUrlRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteUrlRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteUrlRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteUrlRequestValidationError) ErrorName() string { return "DeleteUrlRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DeleteUrlRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteUrlRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteUrlRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteUrlRequestValidationError{}
+
+// Validate checks the field values on ListUrlsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUrlsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUrlsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUrlsRequestMultiError, or nil if none found.
+func (m *ListUrlsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUrlsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetOwnerId()) < 1 {
+		err := ListUrlsRequestValidationError{
+			field:  "OwnerId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPage() <= 0 {
+		err := ListUrlsRequestValidationError{
+			field:  "Page",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val <= 0 || val > 100 {
+		err := ListUrlsRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range (0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListUrlsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUrlsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListUrlsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListUrlsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUrlsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUrlsRequestMultiError) AllErrors() []error { return m }
+
+// ListUrlsRequestValidationError is the validation error returned by
+// ListUrlsRequest.Validate if the designated constraints aren't met.
+type ListUrlsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUrlsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUrlsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUrlsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUrlsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUrlsRequestValidationError) ErrorName() string { return "ListUrlsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListUrlsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUrlsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUrlsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUrlsRequestValidationError{}
+
+// Validate checks the field values on UrlInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UrlInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UrlInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UrlInfoMultiError, or nil if none found.
+func (m *UrlInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UrlInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ShortUrl
+
+	// no validation rules for LongUrl
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UrlInfoValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UrlInfoValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UrlInfoValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UrlInfoValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UrlInfoValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UrlInfoValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Active
+
+	if len(errors) > 0 {
+		return UrlInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// UrlInfoMultiError is an error wrapping multiple validation errors returned
+// by UrlInfo.ValidateAll() if the designated constraints aren't met.
+type UrlInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UrlInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UrlInfoMultiError) AllErrors() []error { return m }
+
+// UrlInfoValidationError is the validation error returned by UrlInfo.Validate
+// if the designated constraints aren't met.
+type UrlInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UrlInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UrlInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UrlInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UrlInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UrlInfoValidationError) ErrorName() string { return "UrlInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UrlInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUrlInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UrlInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UrlInfoValidationError{}
+
+// Validate checks the field values on Pagination with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Pagination) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Pagination with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PaginationMultiError, or
+// nil if none found.
+func (m *Pagination) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Pagination) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Next
+
+	// no validation rules for Previous
+
+	// no validation rules for RecordPerPage
+
+	// no validation rules for CurrentPage
+
+	// no validation rules for TotalPage
+
+	if len(errors) > 0 {
+		return PaginationMultiError(errors)
+	}
+
+	return nil
+}
+
+// PaginationMultiError is an error wrapping multiple validation errors
+// returned by Pagination.ValidateAll() if the designated constraints aren't met.
+type PaginationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PaginationMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PaginationMultiError) AllErrors() []error { return m }
+
+// PaginationValidationError is the validation error returned by
+// Pagination.Validate if the designated constraints aren't met.
+type PaginationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PaginationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PaginationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PaginationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PaginationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PaginationValidationError) ErrorName() string { return "PaginationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PaginationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPagination.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PaginationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PaginationValidationError{}
+
+// Validate checks the field values on ListUrlsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUrlsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUrlsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUrlsResponseMultiError, or nil if none found.
+func (m *ListUrlsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUrlsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUrls() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUrlsResponseValidationError{
+						field:  fmt.Sprintf("Urls[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUrlsResponseValidationError{
+						field:  fmt.Sprintf("Urls[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUrlsResponseValidationError{
+					field:  fmt.Sprintf("Urls[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetPagination()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListUrlsResponseValidationError{
+					field:  "Pagination",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListUrlsResponseValidationError{
+					field:  "Pagination",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPagination()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListUrlsResponseValidationError{
+				field:  "Pagination",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ListUrlsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUrlsResponseMultiError is an error wrapping multiple validation errors
+// returned by ListUrlsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListUrlsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUrlsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUrlsResponseMultiError) AllErrors() []error { return m }
+
+// ListUrlsResponseValidationError is the validation error returned by
+// ListUrlsResponse.Validate if the designated constraints aren't met.
+type ListUrlsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUrlsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUrlsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUrlsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUrlsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUrlsResponseValidationError) ErrorName() string { return "ListUrlsResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListUrlsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUrlsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUrlsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUrlsResponseValidationError{}
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -23,7 +24,12 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UrlClient interface {
 	ShortenUrl(ctx context.Context, in *LongUrlRequest, opts ...grpc.CallOption) (*UrlDataResponse, error)
+	ShortenUrls(ctx context.Context, in *ShortenUrlsRequest, opts ...grpc.CallOption) (*ShortenUrlsResponse, error)
 	FollowUrl(ctx context.Context, in *ShortUrlRequest, opts ...grpc.CallOption) (*LongUrlResponse, error)
+	DeleteUrl(ctx context.Context, in *DeleteUrlRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetUrlActive(ctx context.Context, in *SetUrlActiveRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateUrl(ctx context.Context, in *UpdateUrlRequest, opts ...grpc.CallOption) (*UrlDataResponse, error)
+	ListUrls(ctx context.Context, in *ListUrlsRequest, opts ...grpc.CallOption) (*ListUrlsResponse, error)
 }
 
 type urlClient struct {
@@ -43,6 +49,15 @@ func (c *urlClient) ShortenUrl(ctx context.Context, in *LongUrlRequest, opts ...
 	return out, nil
 }
 
+func (c *urlClient) ShortenUrls(ctx context.Context, in *ShortenUrlsRequest, opts ...grpc.CallOption) (*ShortenUrlsResponse, error) {
+	out := new(ShortenUrlsResponse)
+	err := c.cc.Invoke(ctx, "/url.Url/ShortenUrls", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *urlClient) FollowUrl(ctx context.Context, in *ShortUrlRequest, opts ...grpc.CallOption) (*LongUrlResponse, error) {
 	out := new(LongUrlResponse)
 	err := c.cc.Invoke(ctx, "/url.Url/FollowUrl", in, out, opts...)
@@ -52,12 +67,53 @@ func (c *urlClient) FollowUrl(ctx context.Context, in *ShortUrlRequest, opts ...
 	return out, nil
 }
 
+func (c *urlClient) DeleteUrl(ctx context.Context, in *DeleteUrlRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/url.Url/DeleteUrl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlClient) SetUrlActive(ctx context.Context, in *SetUrlActiveRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/url.Url/SetUrlActive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlClient) UpdateUrl(ctx context.Context, in *UpdateUrlRequest, opts ...grpc.CallOption) (*UrlDataResponse, error) {
+	out := new(UrlDataResponse)
+	err := c.cc.Invoke(ctx, "/url.Url/UpdateUrl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlClient) ListUrls(ctx context.Context, in *ListUrlsRequest, opts ...grpc.CallOption) (*ListUrlsResponse, error) {
+	out := new(ListUrlsResponse)
+	err := c.cc.Invoke(ctx, "/url.Url/ListUrls", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UrlServer is the server API for Url service.
 // All implementations must embed UnimplementedUrlServer
 // for forward compatibility
 type UrlServer interface {
 	ShortenUrl(context.Context, *LongUrlRequest) (*UrlDataResponse, error)
+	ShortenUrls(context.Context, *ShortenUrlsRequest) (*ShortenUrlsResponse, error)
 	FollowUrl(context.Context, *ShortUrlRequest) (*LongUrlResponse, error)
+	DeleteUrl(context.Context, *DeleteUrlRequest) (*emptypb.Empty, error)
+	SetUrlActive(context.Context, *SetUrlActiveRequest) (*emptypb.Empty, error)
+	UpdateUrl(context.Context, *UpdateUrlRequest) (*UrlDataResponse, error)
+	ListUrls(context.Context, *ListUrlsRequest) (*ListUrlsResponse, error)
 	mustEmbedUnimplementedUrlServer()
 }
 
@@ -68,9 +124,24 @@ type UnimplementedUrlServer struct {
 func (UnimplementedUrlServer) ShortenUrl(context.Context, *LongUrlRequest) (*UrlDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShortenUrl not implemented")
 }
+func (UnimplementedUrlServer) ShortenUrls(context.Context, *ShortenUrlsRequest) (*ShortenUrlsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShortenUrls not implemented")
+}
 func (UnimplementedUrlServer) FollowUrl(context.Context, *ShortUrlRequest) (*LongUrlResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FollowUrl not implemented")
 }
+func (UnimplementedUrlServer) DeleteUrl(context.Context, *DeleteUrlRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUrl not implemented")
+}
+func (UnimplementedUrlServer) SetUrlActive(context.Context, *SetUrlActiveRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUrlActive not implemented")
+}
+func (UnimplementedUrlServer) UpdateUrl(context.Context, *UpdateUrlRequest) (*UrlDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUrl not implemented")
+}
+func (UnimplementedUrlServer) ListUrls(context.Context, *ListUrlsRequest) (*ListUrlsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUrls not implemented")
+}
 func (UnimplementedUrlServer) mustEmbedUnimplementedUrlServer() {}
 
 // UnsafeUrlServer may be embedded to opt out of forward compatibility for this service.
@@ -102,6 +173,24 @@ func _Url_ShortenUrl_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Url_ShortenUrls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShortenUrlsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlServer).ShortenUrls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/url.Url/ShortenUrls",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlServer).ShortenUrls(ctx, req.(*ShortenUrlsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Url_FollowUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShortUrlRequest)
 	if err := dec(in); err != nil {
@@ -120,6 +209,78 @@ func _Url_FollowUrl_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Url_DeleteUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlServer).DeleteUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/url.Url/DeleteUrl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlServer).DeleteUrl(ctx, req.(*DeleteUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Url_SetUrlActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUrlActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlServer).SetUrlActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/url.Url/SetUrlActive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlServer).SetUrlActive(ctx, req.(*SetUrlActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Url_UpdateUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlServer).UpdateUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/url.Url/UpdateUrl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlServer).UpdateUrl(ctx, req.(*UpdateUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Url_ListUrls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUrlsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlServer).ListUrls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/url.Url/ListUrls",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlServer).ListUrls(ctx, req.(*ListUrlsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Url_ServiceDesc is the grpc.ServiceDesc for Url service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,10 +292,30 @@ var Url_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ShortenUrl",
 			Handler:    _Url_ShortenUrl_Handler,
 		},
+		{
+			MethodName: "ShortenUrls",
+			Handler:    _Url_ShortenUrls_Handler,
+		},
 		{
 			MethodName: "FollowUrl",
 			Handler:    _Url_FollowUrl_Handler,
 		},
+		{
+			MethodName: "DeleteUrl",
+			Handler:    _Url_DeleteUrl_Handler,
+		},
+		{
+			MethodName: "SetUrlActive",
+			Handler:    _Url_SetUrlActive_Handler,
+		},
+		{
+			MethodName: "UpdateUrl",
+			Handler:    _Url_UpdateUrl_Handler,
+		},
+		{
+			MethodName: "ListUrls",
+			Handler:    _Url_ListUrls_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "url.proto",
@@ -0,0 +1,78 @@
+package shortener
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	DefaultAlphabet = alphabet
+	// UnambiguousAlphabet drops characters that are easily confused when read: 0/O/o, 1/I/l
+	UnambiguousAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// isURLSafe reports whether c may appear in a short url as is, the set is the one of custom aliases
+func isURLSafe(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c == '-'
+}
+
+func validateAlphabet(alphabet string) error {
+	if len(alphabet) < 2 {
+		return fmt.Errorf("alphabet must contain at least 2 characters, got %d", len(alphabet))
+	}
+
+	for i := 0; i < len(alphabet); i++ {
+		if !isURLSafe(alphabet[i]) {
+			return fmt.Errorf("alphabet must contain only latin letters, digits, '_' or '-', got %q", alphabet[i])
+		}
+		if strings.IndexByte(alphabet[i+1:], alphabet[i]) != -1 {
+			return fmt.Errorf("alphabet contains duplicate character %q", alphabet[i])
+		}
+	}
+
+	return nil
+}
+
+// encodeDigits writes id in the numeral system of alphabet, most significant digit first
+func encodeDigits(id uint64, alphabet string) string {
+	base := uint64(len(alphabet))
+	if id == 0 {
+		return alphabet[:1]
+	}
+
+	digits := make([]byte, 0, 11)
+	for id > 0 {
+		digits = append(digits, alphabet[id%base])
+		id /= base
+	}
+
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+
+	return string(digits)
+}
+
+// decodeDigits is the reverse of encodeDigits
+func decodeDigits(code string, alphabet string) (uint64, error) {
+	if code == "" {
+		return 0, ErrInvalidCode
+	}
+
+	base := uint64(len(alphabet))
+	var id uint64
+	for i := 0; i < len(code); i++ {
+		digit := strings.IndexByte(alphabet, code[i])
+		if digit == -1 {
+			return 0, ErrInvalidCode
+		}
+		if id > (math.MaxUint64-uint64(digit))/base {
+			return 0, ErrInvalidCode
+		}
+
+		id = id*base + uint64(digit)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,42 @@
+package shortener
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fixedLengthShortener emits digits most significant first and left pads
+// codes with the zero digit of the alphabet. Ids that do not fit into length
+// digits produce longer codes.
+type fixedLengthShortener struct {
+	alphabet string
+	length   int
+}
+
+func NewFixedLengthShortener(alphabet string, length int) (URLShortener, error) {
+	err := validateAlphabet(alphabet)
+	if err != nil {
+		return nil, err
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("code length must be positive, got %d", length)
+	}
+
+	return &fixedLengthShortener{
+		alphabet: alphabet,
+		length:   length,
+	}, nil
+}
+
+func (s *fixedLengthShortener) ShortenURL(id uint64) string {
+	code := encodeDigits(id, s.alphabet)
+	if len(code) >= s.length {
+		return code
+	}
+
+	return strings.Repeat(s.alphabet[:1], s.length-len(code)) + code
+}
+
+func (s *fixedLengthShortener) Decode(code string) (uint64, error) {
+	return decodeDigits(code, s.alphabet)
+}
@@ -9,8 +9,36 @@ type URLShortener struct {
 	mock.Mock
 }
 
+// Decode provides a mock function with given fields: code
+func (_m *URLShortener) Decode(code string) (uint64, error) {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decode")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (uint64, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(string) uint64); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ShortenURL provides a mock function with given fields: id
-func (_m *URLShortener) ShortenURL(id uint32) string {
+func (_m *URLShortener) ShortenURL(id uint64) string {
 	ret := _m.Called(id)
 
 	if len(ret) == 0 {
@@ -18,7 +46,7 @@ func (_m *URLShortener) ShortenURL(id uint32) string {
 	}
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(uint32) string); ok {
+	if rf, ok := ret.Get(0).(func(uint64) string); ok {
 		r0 = rf(id)
 	} else {
 		r0 = ret.Get(0).(string)
@@ -0,0 +1,84 @@
+package shortener
+
+import (
+	"fmt"
+	"strings"
+)
+
+// obfuscatingShortener is a Hashids-style encoder. The first character of a
+// code is a lottery character derived from the id. Together with the salt it
+// shuffles the alphabet used for the rest of the code, so sequential ids give
+// unrelated looking codes. Codes can not be decoded without the salt.
+type obfuscatingShortener struct {
+	alphabet string
+	salt     string
+}
+
+func NewObfuscatingShortener(alphabet string, salt string) (URLShortener, error) {
+	err := validateAlphabet(alphabet)
+	if err != nil {
+		return nil, err
+	}
+	if salt == "" {
+		return nil, fmt.Errorf("salt must not be empty")
+	}
+
+	return &obfuscatingShortener{
+		alphabet: consistentShuffle(alphabet, salt),
+		salt:     salt,
+	}, nil
+}
+
+func (s *obfuscatingShortener) ShortenURL(id uint64) string {
+	lottery := s.alphabet[id%100%uint64(len(s.alphabet))]
+	idAlphabet := s.idAlphabet(lottery)
+
+	return string(lottery) + encodeDigits(id, idAlphabet)
+}
+
+func (s *obfuscatingShortener) Decode(code string) (uint64, error) {
+	if len(code) < 2 {
+		return 0, ErrInvalidCode
+	}
+
+	lottery := code[0]
+	if strings.IndexByte(s.alphabet, lottery) == -1 {
+		return 0, ErrInvalidCode
+	}
+
+	id, err := decodeDigits(code[1:], s.idAlphabet(lottery))
+	if err != nil {
+		return 0, err
+	}
+
+	// Codes with a wrong lottery character or leading zero digits decode to
+	// some id, but they are not what ShortenURL returns for it
+	if s.ShortenURL(id) != code {
+		return 0, ErrInvalidCode
+	}
+	return id, nil
+}
+
+func (s *obfuscatingShortener) idAlphabet(lottery byte) string {
+	buffer := string(lottery) + s.salt + s.alphabet
+	return consistentShuffle(s.alphabet, buffer[:len(s.alphabet)])
+}
+
+// consistentShuffle permutes alphabet deterministically for the given salt
+func consistentShuffle(alphabet string, salt string) string {
+	result := []byte(alphabet)
+	if salt == "" {
+		return alphabet
+	}
+
+	for i, v, p := len(result)-1, 0, 0; i > 0; i-- {
+		v %= len(salt)
+		integer := int(salt[v])
+		p += integer
+		j := (integer + v + p) % i
+		result[i], result[j] = result[j], result[i]
+		v++
+	}
+
+	return string(result)
+}
@@ -1,6 +1,8 @@
 package shortener
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -11,7 +13,7 @@ func TestBase62UrlShortener(t *testing.T) {
 	base62UrlShortener := NewBase62UrlShortener()
 	t.Run("is idempotent", func(t *testing.T) {
 
-		id := uuid.New().ID()
+		id := uint64(uuid.New().ID())
 		expectedLongURL := base62UrlShortener.ShortenURL(id)
 
 		for i := 0; i < 1000; i++ {
@@ -19,11 +21,131 @@ func TestBase62UrlShortener(t *testing.T) {
 			assert.Equal(t, expectedLongURL, longURL)
 		}
 	})
+
+	t.Run("zero id is not empty", func(t *testing.T) {
+		assert.Equal(t, "A", base62UrlShortener.ShortenURL(0))
+	})
+}
+
+func TestDecode(t *testing.T) {
+	fixedLengthShortener, err := NewFixedLengthShortener(DefaultAlphabet, 7)
+	assert.NoError(t, err)
+	unambiguousShortener, err := NewFixedLengthShortener(UnambiguousAlphabet, 6)
+	assert.NoError(t, err)
+	obfuscatingShortener, err := NewObfuscatingShortener(DefaultAlphabet, "test salt")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		urlShortener URLShortener
+	}{
+		{name: "base62", urlShortener: NewBase62UrlShortener()},
+		{name: "fixed length", urlShortener: fixedLengthShortener},
+		{name: "unambiguous alphabet", urlShortener: unambiguousShortener},
+		{name: "obfuscating", urlShortener: obfuscatingShortener},
+	}
+
+	ids := []uint64{0, 1, 61, 62, 63, 3843, 3844, math.MaxUint32, math.MaxUint64}
+	for i := 0; i < 100; i++ {
+		ids = append(ids, uint64(uuid.New().ID()))
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, id := range ids {
+				code := tc.urlShortener.ShortenURL(id)
+				assert.NotEmpty(t, code)
+
+				decodedID, err := tc.urlShortener.Decode(code)
+				assert.NoError(t, err)
+				assert.Equal(t, id, decodedID, "code %s", code)
+			}
+
+			_, err := tc.urlShortener.Decode("")
+			assert.ErrorIs(t, err, ErrInvalidCode)
+
+			_, err = tc.urlShortener.Decode("not/a/code")
+			assert.ErrorIs(t, err, ErrInvalidCode)
+		})
+	}
+}
+
+func TestFixedLengthShortener(t *testing.T) {
+	t.Run("pads codes to length", func(t *testing.T) {
+		urlShortener, err := NewFixedLengthShortener(DefaultAlphabet, 7)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "AAAAAAA", urlShortener.ShortenURL(0))
+		assert.Equal(t, "AAAAAAB", urlShortener.ShortenURL(1))
+		assert.Equal(t, "AAAAABA", urlShortener.ShortenURL(62))
+
+		for i := 0; i < 100; i++ {
+			assert.Len(t, urlShortener.ShortenURL(uint64(uuid.New().ID())), 7)
+		}
+	})
+
+	t.Run("unambiguous alphabet", func(t *testing.T) {
+		urlShortener, err := NewFixedLengthShortener(UnambiguousAlphabet, 6)
+		assert.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			code := urlShortener.ShortenURL(uint64(uuid.New().ID()))
+			assert.False(t, strings.ContainsAny(code, "0O1lI"), code)
+		}
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, err := NewFixedLengthShortener(DefaultAlphabet, 0)
+		assert.Error(t, err)
+
+		_, err = NewFixedLengthShortener("aa", 6)
+		assert.Error(t, err)
+
+		_, err = NewFixedLengthShortener("a", 6)
+		assert.Error(t, err)
+
+		// Codes must be served by the url route as is
+		for _, alphabet := range []string{"abc/", "abc?", "abc#", "abc%", "abc ", "abcé"} {
+			_, err = NewFixedLengthShortener(alphabet, 6)
+			assert.Error(t, err, alphabet)
+
+			_, err = NewObfuscatingShortener(alphabet, "test salt")
+			assert.Error(t, err, alphabet)
+		}
+	})
+}
+
+func TestObfuscatingShortener(t *testing.T) {
+	t.Run("sequential ids give unrelated codes", func(t *testing.T) {
+		urlShortener, err := NewObfuscatingShortener(DefaultAlphabet, "test salt")
+		assert.NoError(t, err)
+
+		prevCode := urlShortener.ShortenURL(1000)
+		for id := uint64(1001); id < 1100; id++ {
+			code := urlShortener.ShortenURL(id)
+			assert.NotEqual(t, prevCode[1:], code[1:])
+			prevCode = code
+		}
+	})
+
+	t.Run("salt changes codes", func(t *testing.T) {
+		firstShortener, err := NewObfuscatingShortener(DefaultAlphabet, "first salt")
+		assert.NoError(t, err)
+		secondShortener, err := NewObfuscatingShortener(DefaultAlphabet, "second salt")
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, firstShortener.ShortenURL(12345), secondShortener.ShortenURL(12345))
+	})
+
+	t.Run("empty salt", func(t *testing.T) {
+		_, err := NewObfuscatingShortener(DefaultAlphabet, "")
+		assert.Error(t, err)
+	})
 }
 
 func BenchmarkNewBase62UrlShortener(b *testing.B) {
 	base62UrlShortener := NewBase62UrlShortener()
-	id := uuid.New().ID()
+	id := uint64(uuid.New().ID())
 
 	for n := 0; n < b.N; n++ {
 		_ = base62UrlShortener.ShortenURL(id)
@@ -1,6 +1,9 @@
 package shortener
 
-import "strings"
+import (
+	"errors"
+	"strings"
+)
 
 const (
 	alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
@@ -10,11 +13,23 @@ var (
 	alphabetLen = len(alphabet)
 )
 
+// Names of encoders that can be selected through config
+const (
+	EncoderBase62      = "base62"
+	EncoderFixedLength = "fixed"
+	EncoderObfuscating = "obfuscating"
+)
+
+var ErrInvalidCode = errors.New("short url code can not be decoded")
+
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name URLShortener
 type URLShortener interface {
-	ShortenURL(id uint32) string
+	ShortenURL(id uint64) string
+	// Decode maps a code produced by ShortenURL back to its id
+	Decode(code string) (uint64, error)
 }
 
+// base62UrlShortener emits digits least significant first
 type base62UrlShortener struct {
 }
 
@@ -22,13 +37,17 @@ func NewBase62UrlShortener() URLShortener {
 	return &base62UrlShortener{}
 }
 
-func (s *base62UrlShortener) ShortenURL(id uint32) string {
+func (s *base62UrlShortener) ShortenURL(id uint64) string {
+	if id == 0 {
+		return alphabet[:1]
+	}
+
 	nums := make([]int, 0)
 	for id > 0 {
-		rem := int(id) % alphabetLen
+		rem := int(id % uint64(alphabetLen))
 		nums = append(nums, rem)
 
-		id /= uint32(alphabetLen)
+		id /= uint64(alphabetLen)
 	}
 
 	var sb strings.Builder
@@ -39,3 +58,12 @@ func (s *base62UrlShortener) ShortenURL(id uint32) string {
 
 	return sb.String()
 }
+
+func (s *base62UrlShortener) Decode(code string) (uint64, error) {
+	reversed := []byte(code)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+
+	return decodeDigits(string(reversed), alphabet)
+}